@@ -3,12 +3,14 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"regexp"
 	"strings"
 
 	"github.com/tobyjsullivan/chalk/monolith"
+	"github.com/tobyjsullivan/chalk/parsing"
 	"github.com/tobyjsullivan/chalk/resolver"
 )
 
@@ -24,8 +26,14 @@ var (
 	rePathCreateSession    = reSessionsCollection
 	rePathGetSession       = reSessionsDocument
 	rePathGetPageVariables = regexp.MustCompile("^/pages/([a-zA-Z0-9-_]+)/variables$")
+	rePathPageScenarios    = regexp.MustCompile("^/pages/([a-zA-Z0-9-_]+)/scenarios$")
+	rePathGoalSeek         = regexp.MustCompile("^/pages/([a-zA-Z0-9-_]+)/goalseek$")
+	rePathGetPageGrid      = regexp.MustCompile("^/pages/([a-zA-Z0-9-_]+)/grid$")
+	rePathSetCell          = regexp.MustCompile("^/pages/([a-zA-Z0-9-_]+)/grid/([a-zA-Z0-9]+)$")
 	rePathCreateVar        = reVariablesCollection
 	rePathUpdateVar        = reVariablesDocument
+	rePathFormatFormula    = regexp.MustCompile("^/format$")
+	rePathTranslateFormula = regexp.MustCompile("^/translate$")
 )
 
 type Event struct {
@@ -97,6 +105,10 @@ func (h *Handler) doGet(ctx context.Context, req *Event) (*Response, error) {
 		return h.doGetSession(ctx, req)
 	} else if rePathGetPageVariables.MatchString(req.Path) {
 		return h.doGetPageVariables(ctx, req)
+	} else if rePathPageScenarios.MatchString(req.Path) {
+		return h.doGetPageScenarios(ctx, req)
+	} else if rePathGetPageGrid.MatchString(req.Path) {
+		return h.doGetPageGrid(ctx, req)
 	}
 
 	return &Response{
@@ -116,6 +128,18 @@ func (h *Handler) doPost(ctx context.Context, req *Event) (*Response, error) {
 	} else if rePathUpdateVar.MatchString(req.Path) {
 		log.Println("Updating var")
 		return h.doUpdateVariable(ctx, req)
+	} else if rePathFormatFormula.MatchString(req.Path) {
+		return h.doFormatFormula(ctx, req)
+	} else if rePathTranslateFormula.MatchString(req.Path) {
+		return h.doTranslateFormula(ctx, req)
+	} else if rePathPageScenarios.MatchString(req.Path) {
+		log.Println("Saving scenario")
+		return h.doSaveScenario(ctx, req)
+	} else if rePathGoalSeek.MatchString(req.Path) {
+		return h.doGoalSeek(ctx, req)
+	} else if rePathSetCell.MatchString(req.Path) {
+		log.Println("Setting cell")
+		return h.doSetCell(ctx, req)
 	}
 
 	return &Response{
@@ -235,6 +259,90 @@ func (h *Handler) doUpdateVariable(ctx context.Context, event *Event) (*Response
 		}
 	}
 
+	for _, v := range resp.Rewritten {
+		out.Rewritten = append(out.Rewritten, &variableSummary{
+			Id:      v.VariableId,
+			Name:    v.Name,
+			Formula: v.Formula,
+		})
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		StatusCode:      http.StatusOK,
+		Headers:         determineCorsHeaders(event),
+		Body:            b,
+		IsBase64Encoded: false,
+	}, nil
+}
+
+func (h *Handler) doFormatFormula(ctx context.Context, event *Event) (*Response, error) {
+	var formatRequest formatFormulaRequest
+	err := json.Unmarshal([]byte(event.Body), &formatRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.resolverSvc.FormatFormula(ctx, &resolver.FormatFormulaRequest{
+		Formula: formatRequest.Formula,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out formatFormulaResponse
+	if resp.Error != "" {
+		out.Error = &resp.Error
+	} else {
+		out.Formula = &resp.Formula
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		StatusCode:      http.StatusOK,
+		Headers:         determineCorsHeaders(event),
+		Body:            b,
+		IsBase64Encoded: false,
+	}, nil
+}
+
+// doTranslateFormula previews the chalk equivalent of a spreadsheet formula without saving anything.
+func (h *Handler) doTranslateFormula(ctx context.Context, event *Event) (*Response, error) {
+	var translateRequest translateFormulaRequest
+	err := json.Unmarshal([]byte(event.Body), &translateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.resolverSvc.TranslateFormula(ctx, &resolver.TranslateFormulaRequest{
+		Formula: translateRequest.Formula,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := translateFormulaResponse{
+		Diagnostics: []*translationDiagnostic{},
+	}
+	if resp.Formula != "" {
+		out.Formula = &resp.Formula
+	}
+	for _, d := range resp.Diagnostics {
+		out.Diagnostics = append(out.Diagnostics, &translationDiagnostic{
+			Column:  d.Column,
+			Length:  d.Length,
+			Message: d.Message,
+		})
+	}
+
 	b, err := json.Marshal(out)
 	if err != nil {
 		return nil, err
@@ -270,6 +378,23 @@ func (h *Handler) buildVariableState(ctx context.Context, v *monolith.Variable)
 	return state, nil
 }
 
+func buildPageVariableState(v *resolver.VariableResult) (*variableState, error) {
+	result, err := mapResolveResponse(&resolver.ResolveResponse{
+		Result: v.Result,
+		Error:  v.Error,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &variableState{
+		Id:      v.VariableId,
+		Name:    v.Name,
+		Formula: v.Formula,
+		Result:  result,
+	}, nil
+}
+
 func (h *Handler) doCreateSession(ctx context.Context, event *Event) (*Response, error) {
 	sessResp, err := h.sessionsSvc.CreateSession(ctx, &monolith.CreateSessionRequest{})
 	if err != nil {
@@ -371,23 +496,349 @@ func (h *Handler) doGetPageVariables(ctx context.Context, event *Event) (*Respon
 
 	// Page ID
 	pageId := matches[1]
-	varsReq := &monolith.FindVariablesRequest{
+	resp, err := h.resolverSvc.ResolvePage(ctx, &resolver.ResolvePageRequest{
 		PageId: pageId,
-	}
-	resp, err := h.variablesSvc.FindVariables(ctx, varsReq)
+	})
 	if err != nil {
 		return nil, err
 	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
 
 	var out getPageVariablesResponse
-	out.Variables = make([]*variableState, len(resp.Values))
-	log.Println("found", len(resp.Values), "variables")
-	for i, v := range resp.Values {
+	out.Variables = make([]*variableState, len(resp.Results))
+	log.Println("found", len(resp.Results), "variables")
+	for i, v := range resp.Results {
 		log.Println("adding var to resp", v.VariableId)
-		out.Variables[i], err = h.buildVariableState(ctx, v)
+		out.Variables[i], err = buildPageVariableState(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		StatusCode:      http.StatusOK,
+		Headers:         determineCorsHeaders(event),
+		Body:            b,
+		IsBase64Encoded: false,
+	}, nil
+}
+
+func (h *Handler) doSaveScenario(ctx context.Context, event *Event) (*Response, error) {
+	var saveRequest saveScenarioRequest
+	err := json.Unmarshal([]byte(event.Body), &saveRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := rePathPageScenarios.FindStringSubmatch(event.Path)
+	if len(matches) != 2 {
+		// Panic because the router should have verified this previously.
+		panic("Expected ID in path.")
+	}
+
+	if saveRequest.Name == "" {
+		return &Response{
+			StatusCode:      http.StatusBadRequest,
+			Headers:         determineCorsHeaders(event),
+			Body:            []byte("must specify scenario name"),
+			IsBase64Encoded: false,
+		}, nil
+	}
+
+	resp, err := h.pagesSvc.SaveScenario(ctx, &monolith.SaveScenarioRequest{
+		Scenario: &monolith.Scenario{
+			Page:      matches[1],
+			Name:      saveRequest.Name,
+			Overrides: saveRequest.Overrides,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out saveScenarioResponse
+	if resp.Error != nil {
+		out.Error = &resp.Error.Message
+	} else if resp.Scenario != nil {
+		out.Scenario = &scenarioState{
+			Name:      resp.Scenario.Name,
+			Overrides: resp.Scenario.Overrides,
+		}
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		StatusCode:      http.StatusOK,
+		Headers:         determineCorsHeaders(event),
+		Body:            b,
+		IsBase64Encoded: false,
+	}, nil
+}
+
+// doGetPageScenarios resolves the page once as it is and once for each of its scenarios, and returns the results
+// for each variable side by side.
+func (h *Handler) doGetPageScenarios(ctx context.Context, event *Event) (*Response, error) {
+	matches := rePathPageScenarios.FindStringSubmatch(event.Path)
+	if len(matches) != 2 {
+		// Panic because the router should have verified this previously.
+		panic("Expected ID in path.")
+	}
+
+	pageId := matches[1]
+	scenariosResp, err := h.pagesSvc.FindScenarios(ctx, &monolith.FindScenariosRequest{
+		PageId: pageId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if scenariosResp.Error != nil {
+		return nil, errors.New(scenariosResp.Error.Message)
+	}
+
+	baseline, err := h.resolverSvc.ResolvePage(ctx, &resolver.ResolvePageRequest{
+		PageId: pageId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if baseline.Error != "" {
+		return nil, errors.New(baseline.Error)
+	}
+
+	var out getPageScenariosResponse
+	out.Scenarios = make([]*scenarioState, len(scenariosResp.Scenarios))
+	// Results of each scenario keyed by variable ID.
+	scenarioResults := make([]map[string]*resolver.VariableResult, len(scenariosResp.Scenarios))
+	for i, scenario := range scenariosResp.Scenarios {
+		out.Scenarios[i] = &scenarioState{
+			Name:      scenario.Name,
+			Overrides: scenario.Overrides,
+		}
+
+		resp, err := h.resolverSvc.ResolvePage(ctx, &resolver.ResolvePageRequest{
+			PageId:    pageId,
+			Overrides: scenario.Overrides,
+		})
 		if err != nil {
 			return nil, err
 		}
+		if resp.Error != "" {
+			return nil, errors.New(resp.Error)
+		}
+
+		scenarioResults[i] = make(map[string]*resolver.VariableResult, len(resp.Results))
+		for _, v := range resp.Results {
+			scenarioResults[i][v.VariableId] = v
+		}
+	}
+
+	out.Variables = make([]*scenarioVariableState, len(baseline.Results))
+	for i, v := range baseline.Results {
+		state, err := buildPageVariableState(v)
+		if err != nil {
+			return nil, err
+		}
+
+		variable := &scenarioVariableState{
+			variableState: state,
+			Scenarios:     make([]*scenarioResult, len(scenarioResults)),
+		}
+		for j, results := range scenarioResults {
+			res, ok := results[v.VariableId]
+			if !ok {
+				continue
+			}
+			resState, err := buildPageVariableState(res)
+			if err != nil {
+				return nil, err
+			}
+			variable.Scenarios[j] = &scenarioResult{
+				Formula: resState.Formula,
+				Result:  resState.Result,
+			}
+		}
+		out.Variables[i] = variable
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		StatusCode:      http.StatusOK,
+		Headers:         determineCorsHeaders(event),
+		Body:            b,
+		IsBase64Encoded: false,
+	}, nil
+}
+
+func (h *Handler) doGoalSeek(ctx context.Context, event *Event) (*Response, error) {
+	var seekRequest goalSeekRequest
+	err := json.Unmarshal([]byte(event.Body), &seekRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := rePathGoalSeek.FindStringSubmatch(event.Path)
+	if len(matches) != 2 {
+		// Panic because the router should have verified this previously.
+		panic("Expected ID in path.")
+	}
+
+	if seekRequest.Target == "" || seekRequest.Input == "" {
+		return &Response{
+			StatusCode:      http.StatusBadRequest,
+			Headers:         determineCorsHeaders(event),
+			Body:            []byte("must specify target and input variables"),
+			IsBase64Encoded: false,
+		}, nil
+	}
+
+	resp, err := h.resolverSvc.GoalSeek(ctx, &resolver.GoalSeekRequest{
+		PageId:        matches[1],
+		Target:        seekRequest.Target,
+		Value:         seekRequest.Value,
+		Input:         seekRequest.Input,
+		Low:           seekRequest.Low,
+		High:          seekRequest.High,
+		MaxIterations: seekRequest.MaxIterations,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out goalSeekResponse
+	if resp.Error != "" {
+		out.Error = &resp.Error
+	} else {
+		out.Result = &goalSeekResult{
+			Solution:   resp.Solution,
+			Value:      resp.Value,
+			Iterations: resp.Iterations,
+			Converged:  resp.Converged,
+		}
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		StatusCode:      http.StatusOK,
+		Headers:         determineCorsHeaders(event),
+		Body:            b,
+		IsBase64Encoded: false,
+	}, nil
+}
+
+// doGetPageGrid returns the result of every grid cell on the page which has been set.
+func (h *Handler) doGetPageGrid(ctx context.Context, event *Event) (*Response, error) {
+	matches := rePathGetPageGrid.FindStringSubmatch(event.Path)
+	if len(matches) != 2 {
+		// Panic because the router should have verified this previously.
+		panic("Expected ID in path.")
+	}
+
+	resp, err := h.resolverSvc.ResolvePage(ctx, &resolver.ResolvePageRequest{
+		PageId: matches[1],
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	out := getPageGridResponse{
+		Cells: []*cellState{},
+	}
+	for _, v := range resp.Results {
+		if _, _, ok := parsing.ParseCell(v.Name); !ok {
+			continue
+		}
+
+		state, err := buildPageVariableState(v)
+		if err != nil {
+			return nil, err
+		}
+		out.Cells = append(out.Cells, &cellState{
+			variableState: state,
+			Cell:          v.Name,
+		})
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		StatusCode:      http.StatusOK,
+		Headers:         determineCorsHeaders(event),
+		Body:            b,
+		IsBase64Encoded: false,
+	}, nil
+}
+
+func (h *Handler) doSetCell(ctx context.Context, event *Event) (*Response, error) {
+	var setRequest setCellRequest
+	err := json.Unmarshal([]byte(event.Body), &setRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := rePathSetCell.FindStringSubmatch(event.Path)
+	if len(matches) != 3 {
+		// Panic because the router should have verified this previously.
+		panic("Expected ID and cell in path.")
+	}
+
+	cell := strings.ToUpper(matches[2])
+	if _, _, ok := parsing.ParseCell(cell); !ok {
+		return &Response{
+			StatusCode:      http.StatusBadRequest,
+			Headers:         determineCorsHeaders(event),
+			Body:            []byte("invalid cell address"),
+			IsBase64Encoded: false,
+		}, nil
+	}
+
+	resp, err := h.variablesSvc.SetCell(ctx, &monolith.SetCellRequest{
+		PageId:  matches[1],
+		Cell:    cell,
+		Formula: setRequest.Formula,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out setCellResponse
+	if resp.Error != nil {
+		out.Error = &resp.Error.Message
+	}
+
+	if resp.Variable != nil {
+		state, err := h.buildVariableState(ctx, resp.Variable)
+		if err != nil {
+			return nil, err
+		}
+		out.State = &cellState{
+			variableState: state,
+			Cell:          cell,
+		}
 	}
 
 	b, err := json.Marshal(out)
@@ -414,6 +865,11 @@ func normaliseHeaders(in map[string]string) map[string]string {
 	return out
 }
 
+// CorsHeaders returns the headers which allow the request's origin to read the response.
+func CorsHeaders(req *Event) map[string]string {
+	return determineCorsHeaders(req)
+}
+
 func determineCorsHeaders(req *Event) map[string]string {
 	origin, ok := normaliseHeaders(req.Headers)[headerOrigin]
 	if !ok || origin == "" {
@@ -0,0 +1,357 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tobyjsullivan/chalk/monolith"
+	"github.com/tobyjsullivan/chalk/resolver"
+)
+
+const (
+	// Number of recent events kept for each page so reconnecting listeners can catch up.
+	pageEventHistorySize = 256
+	// Number of undelivered events a listener may fall behind by before it's disconnected.
+	listenerBufferSize = 64
+	// How long a page keeps watching for changes after its last listener leaves, so a quick reconnect can resume.
+	pageFeedIdleTimeout = 30 * time.Second
+)
+
+const (
+	EventVariableCreated = "created"
+	EventVariableUpdated = "updated"
+	EventVariableRenamed = "renamed"
+	EventResults         = "results"
+	// Sent in place of missed events which are no longer available. Listeners should reload the whole page.
+	EventReset = "reset"
+)
+
+type PageEvent struct {
+	Id   string
+	Type string
+	Data []byte
+}
+
+// PageEvents relays changes to the variables on a page, and their recomputed results, to any number of listeners.
+// Each page is watched only while somebody is listening to it.
+type PageEvents struct {
+	resolverSvc  resolver.ResolverClient
+	variablesSvc monolith.VariablesClient
+
+	mx    sync.Mutex
+	feeds map[string]*pageFeed
+}
+
+func NewPageEvents(resolverSvc resolver.ResolverClient, variablesSvc monolith.VariablesClient) *PageEvents {
+	return &PageEvents{
+		resolverSvc:  resolverSvc,
+		variablesSvc: variablesSvc,
+		feeds:        make(map[string]*pageFeed),
+	}
+}
+
+// Subscribe returns the events for a page until ctx is done. When lastEventId names an event which is still
+// available, every event after it is delivered first; when it names one which isn't, a reset event is delivered
+// first. The channel is closed if the listener falls too far behind or the page can no longer be watched, after
+// which the listener should subscribe again with the id of the last event it received.
+func (p *PageEvents) Subscribe(ctx context.Context, pageId string, lastEventId string) <-chan *PageEvent {
+	p.mx.Lock()
+	feed, ok := p.feeds[pageId]
+	if !ok || feed.isClosed() {
+		feed = p.startFeed(pageId)
+		p.feeds[pageId] = feed
+	}
+	l := feed.addListener(lastEventId)
+	p.mx.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		feed.removeListener(l)
+	}()
+
+	return l.events
+}
+
+func (p *PageEvents) startFeed(pageId string) *pageFeed {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &pageFeed{
+		pageId:    pageId,
+		epoch:     strconv.FormatInt(time.Now().UnixNano(), 36),
+		cancel:    cancel,
+		listeners: make(map[*listener]bool),
+	}
+	f.onClose = func() {
+		p.mx.Lock()
+		defer p.mx.Unlock()
+		if p.feeds[pageId] == f {
+			delete(p.feeds, pageId)
+		}
+	}
+
+	go f.watchVariables(ctx, p.variablesSvc)
+	go f.watchResults(ctx, p.resolverSvc)
+
+	return f
+}
+
+// pageFeed fans out the events of a single page.
+type pageFeed struct {
+	pageId string
+	// Distinguishes the event ids of this feed from those of any earlier feed for the same page.
+	epoch   string
+	cancel  context.CancelFunc
+	onClose func()
+
+	mx        sync.Mutex
+	closed    bool
+	seq       int64
+	history   []*PageEvent
+	listeners map[*listener]bool
+	idle      *time.Timer
+}
+
+type listener struct {
+	events chan *PageEvent
+}
+
+func (f *pageFeed) isClosed() bool {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+	return f.closed
+}
+
+func (f *pageFeed) addListener(lastEventId string) *listener {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if f.idle != nil {
+		f.idle.Stop()
+		f.idle = nil
+	}
+
+	var backlog []*PageEvent
+	if lastEventId != "" {
+		var ok bool
+		backlog, ok = f.eventsSince(lastEventId)
+		if !ok {
+			backlog = []*PageEvent{{
+				Id:   f.eventId(f.seq),
+				Type: EventReset,
+				Data: []byte("{}"),
+			}}
+		}
+	}
+
+	l := &listener{
+		events: make(chan *PageEvent, len(backlog)+listenerBufferSize),
+	}
+	for _, e := range backlog {
+		l.events <- e
+	}
+
+	if f.closed {
+		close(l.events)
+		return l
+	}
+
+	f.listeners[l] = true
+	return l
+}
+
+// eventsSince returns the events following the one with the given id, or false if some of them are unavailable.
+func (f *pageFeed) eventsSince(id string) ([]*PageEvent, bool) {
+	i := strings.LastIndex(id, "-")
+	if i < 0 || id[:i] != f.epoch {
+		return nil, false
+	}
+	seq, err := strconv.ParseInt(id[i+1:], 10, 64)
+	if err != nil || seq > f.seq {
+		return nil, false
+	}
+
+	// History holds the events numbered from f.seq-len(f.history)+1 up to f.seq.
+	missed := int(f.seq - seq)
+	if missed > len(f.history) {
+		return nil, false
+	}
+
+	return append([]*PageEvent(nil), f.history[len(f.history)-missed:]...), true
+}
+
+func (f *pageFeed) removeListener(l *listener) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if f.listeners[l] {
+		f.dropListener(l)
+	}
+}
+
+// dropListener disconnects a listener. The caller must hold f.mx.
+func (f *pageFeed) dropListener(l *listener) {
+	delete(f.listeners, l)
+	close(l.events)
+
+	if len(f.listeners) == 0 && !f.closed && f.idle == nil {
+		f.idle = time.AfterFunc(pageFeedIdleTimeout, f.closeIfIdle)
+	}
+}
+
+func (f *pageFeed) closeIfIdle() {
+	f.mx.Lock()
+	idle := len(f.listeners) == 0
+	f.mx.Unlock()
+
+	if idle {
+		f.close()
+	}
+}
+
+// close stops watching the page and disconnects every listener.
+func (f *pageFeed) close() {
+	f.mx.Lock()
+	if f.closed {
+		f.mx.Unlock()
+		return
+	}
+	f.closed = true
+	for l := range f.listeners {
+		delete(f.listeners, l)
+		close(l.events)
+	}
+	f.mx.Unlock()
+
+	f.cancel()
+	f.onClose()
+}
+
+func (f *pageFeed) publish(eventType string, data []byte) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if f.closed {
+		return
+	}
+
+	f.seq++
+	e := &PageEvent{
+		Id:   f.eventId(f.seq),
+		Type: eventType,
+		Data: data,
+	}
+
+	f.history = append(f.history, e)
+	if len(f.history) > pageEventHistorySize {
+		f.history = f.history[len(f.history)-pageEventHistorySize:]
+	}
+
+	for l := range f.listeners {
+		select {
+		case l.events <- e:
+		default:
+			// The listener can catch up from the history once it reconnects.
+			f.dropListener(l)
+		}
+	}
+}
+
+func (f *pageFeed) eventId(seq int64) string {
+	return fmt.Sprintf("%s-%d", f.epoch, seq)
+}
+
+func (f *pageFeed) watchVariables(ctx context.Context, variablesSvc monolith.VariablesClient) {
+	defer f.close()
+
+	stream, err := variablesSvc.WatchVariables(ctx, &monolith.WatchVariablesRequest{
+		PageId: f.pageId,
+	})
+	if err != nil {
+		log.Printf("Error watching variables on page %s: %v", f.pageId, err)
+		return
+	}
+
+	for {
+		e, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Error receiving variable events for page %s: %v", f.pageId, err)
+			}
+			return
+		}
+		if e.Variable == nil {
+			continue
+		}
+
+		var eventType string
+		out := &variableEvent{
+			Variable: &variableSummary{
+				Id:      e.Variable.VariableId,
+				Name:    e.Variable.Name,
+				Formula: e.Variable.Formula,
+			},
+		}
+		switch e.Type {
+		case monolith.VariableEventType_CREATED:
+			eventType = EventVariableCreated
+		case monolith.VariableEventType_UPDATED:
+			eventType = EventVariableUpdated
+		case monolith.VariableEventType_RENAMED:
+			eventType = EventVariableRenamed
+			out.PreviousName = &e.PreviousName
+		default:
+			continue
+		}
+
+		b, err := json.Marshal(out)
+		if err != nil {
+			log.Printf("Error encoding variable event: %v", err)
+			return
+		}
+		f.publish(eventType, b)
+	}
+}
+
+func (f *pageFeed) watchResults(ctx context.Context, resolverSvc resolver.ResolverClient) {
+	defer f.close()
+
+	stream, err := resolverSvc.WatchPage(ctx, &resolver.WatchPageRequest{
+		PageId: f.pageId,
+	})
+	if err != nil {
+		log.Printf("Error watching results on page %s: %v", f.pageId, err)
+		return
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Error receiving results for page %s: %v", f.pageId, err)
+			}
+			return
+		}
+
+		var out resultsEvent
+		out.Variables = make([]*variableState, len(update.Results))
+		for i, v := range update.Results {
+			out.Variables[i], err = buildPageVariableState(v)
+			if err != nil {
+				log.Printf("Error mapping result for variable %s: %v", v.VariableId, err)
+				return
+			}
+		}
+
+		b, err := json.Marshal(out)
+		if err != nil {
+			log.Printf("Error encoding results event: %v", err)
+			return
+		}
+		f.publish(EventResults, b)
+	}
+}
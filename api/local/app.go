@@ -33,8 +33,8 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Body:                            string(body),
 		HttpMethod:                      r.Method,
 		MultiValueQueryStringParameters: q,
-		Path:    r.URL.Path,
-		Headers: headers,
+		Path:                            r.URL.Path,
+		Headers:                         headers,
 	}
 
 	ctx := context.Background()
@@ -53,6 +53,21 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Write(resp.Body)
 }
 
+// router sends requests for page events to the streaming handler and everything else to the API handler.
+type router struct {
+	api    http.Handler
+	events http.Handler
+}
+
+func (rt *router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && rePathPageEvents.MatchString(r.URL.Path) {
+		rt.events.ServeHTTP(w, r)
+		return
+	}
+
+	rt.api.ServeHTTP(w, r)
+}
+
 func main() {
 	port := "8080"
 
@@ -76,14 +91,20 @@ func main() {
 	sessionsSvc := monolith.NewSessionsClient(monolithConn)
 	variablesSvc := monolith.NewVariablesClient(monolithConn)
 	executionHandler := api.NewHandler(pagesSvc, resolverSvc, sessionsSvc, variablesSvc)
+	pageEvents := api.NewPageEvents(resolverSvc, variablesSvc)
 
 	s := &http.Server{
 		Addr: ":" + port,
-		Handler: &handler{
-			executionHandler: executionHandler,
+		Handler: &router{
+			// Event streams stay open indefinitely so the write timeout only applies to ordinary requests.
+			api: http.TimeoutHandler(&handler{
+				executionHandler: executionHandler,
+			}, 2*time.Second, "503 Service Unavailable"),
+			events: &eventsHandler{
+				pageEvents: pageEvents,
+			},
 		},
 		ReadTimeout:    2 * time.Second,
-		WriteTimeout:   2 * time.Second,
 		MaxHeaderBytes: 1 << 20,
 	}
 	log.Println("Starting on", port)
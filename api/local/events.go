@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/tobyjsullivan/chalk/api"
+)
+
+var rePathPageEvents = regexp.MustCompile("^/pages/([a-zA-Z0-9-_]+)/events$")
+
+// Comments are sent this often to stop idle connections being closed by proxies along the way.
+const keepAliveInterval = 15 * time.Second
+
+// eventsHandler streams the events of a page as Server-Sent Events.
+type eventsHandler struct {
+	pageEvents *api.PageEvents
+}
+
+func (h *eventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	matches := rePathPageEvents.FindStringSubmatch(r.URL.Path)
+	if len(matches) != 2 {
+		// Panic because the router should have verified this previously.
+		panic("Expected ID in path.")
+	}
+	pageId := matches[1]
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	// Browsers resend the id of the last event they saw when reconnecting. Clients which manage their own
+	// connections may pass it as a query parameter instead.
+	lastEventId := r.Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = r.URL.Query().Get("lastEventId")
+	}
+
+	log.Println("Streaming events for page", pageId)
+	events := h.pageEvents.Subscribe(r.Context(), pageId, lastEventId)
+
+	headers := api.CorsHeaders(&api.Event{
+		HttpMethod: r.Method,
+		Headers: map[string]string{
+			"Origin": r.Header.Get("Origin"),
+		},
+	})
+	for k, v := range headers {
+		w.Header().Set(k, v)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-events:
+			if !ok {
+				// The client will reconnect and resume from the last event it received.
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.Id, e.Type, e.Data); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
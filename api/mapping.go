@@ -1,6 +1,8 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 
 	"github.com/tobyjsullivan/chalk/resolver"
@@ -12,29 +14,86 @@ type executionResult struct {
 }
 
 type executionResultObject struct {
-	Type         *executionResultObjectType `json:"type"`
-	BooleanValue *bool                      `json:"booleanValue,omitempty"`
-	LambdaValue  *executionResultLambda     `json:"lambdaValue,omitempty"`
-	ListValue    *executionResultList       `json:"listValue,omitempty"`
-	NumberValue  *float64                   `json:"numberValue,omitempty"`
-	RecordValue  *executionResultRecord     `json:"recordValue,omitempty"`
-	StringValue  *string                    `json:"stringValue,omitempty"`
+	Type          *executionResultObjectType `json:"type"`
+	BooleanValue  *bool                      `json:"booleanValue,omitempty"`
+	FunctionValue *executionResultFunction   `json:"functionValue,omitempty"`
+	LambdaValue   *executionResultLambda     `json:"lambdaValue,omitempty"`
+	ListValue     *executionResultList       `json:"listValue,omitempty"`
+	MoneyValue    *executionResultMoney      `json:"moneyValue,omitempty"`
+	NumberValue   *float64                   `json:"numberValue,omitempty"`
+	RecordValue   *executionResultRecord     `json:"recordValue,omitempty"`
+	StringValue   *string                    `json:"stringValue,omitempty"`
+	TableValue    *executionResultTable      `json:"tableValue,omitempty"`
+	Unit          string                     `json:"unit,omitempty"`
 }
 
 type executionResultObjectType struct {
 	Class string `json:"class"`
 }
 
+type executionResultFunction struct {
+	Name string `json:"name"`
+}
+
 type executionResultLambda struct {
-	FreeVariables []string `json:"freeVariables"`
+	FreeVariables []string                          `json:"freeVariables"`
+	RestVariable  string                            `json:"restVariable,omitempty"`
+	Body          string                            `json:"body"`
+	Bindings      map[string]*executionResultObject `json:"bindings,omitempty"`
+	Defaults      map[string]string                 `json:"defaults,omitempty"`
 }
 
 type executionResultList struct {
 	Elements []*executionResultObject `json:"elements"`
 }
 
+// executionResultMoney keeps the amount as a decimal string so it isn't rounded to a float.
+type executionResultMoney struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type executionResultTable struct {
+	Columns []string                   `json:"columns"`
+	Rows    [][]*executionResultObject `json:"rows"`
+}
+
 type executionResultRecord struct {
-	Properties map[string]*executionResultObject `json:"properties"`
+	Properties executionResultProperties `json:"properties"`
+}
+
+// executionResultProperties is encoded as a JSON object with its properties in order.
+type executionResultProperties []*executionResultProperty
+
+type executionResultProperty struct {
+	Name  string
+	Value *executionResultObject
+}
+
+func (p executionResultProperties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, prop := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		name, err := json.Marshal(prop.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(prop.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
 }
 
 func mapResolveResponse(resp *resolver.ResolveResponse) (*executionResult, error) {
@@ -73,8 +132,31 @@ func mapResolveResponseObject(object *resolver.Object) (*executionResultObject,
 		}
 
 		copy(result.LambdaValue.FreeVariables, freeVars)
+		result.LambdaValue.RestVariable = object.LambdaValue.RestVariable
+		result.LambdaValue.Body = object.LambdaValue.Body
+		result.LambdaValue.Defaults = object.LambdaValue.Defaults
+
+		if bindings := object.LambdaValue.Bindings; len(bindings) > 0 {
+			result.LambdaValue.Bindings = make(map[string]*executionResultObject)
+			var err error
+			for _, b := range bindings {
+				result.LambdaValue.Bindings[b.Name], err = mapResolveResponseObject(b.Value)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
 
 		return result, nil
+	case resolver.ObjectType_FUNCTION:
+		return &executionResultObject{
+			Type: &executionResultObjectType{
+				Class: "function",
+			},
+			FunctionValue: &executionResultFunction{
+				Name: object.FunctionValue.Name,
+			},
+		}, nil
 	case resolver.ObjectType_LIST:
 		elements := object.ListValue.Elements
 		listObj := &executionResultObject{
@@ -93,13 +175,44 @@ func mapResolveResponseObject(object *resolver.Object) (*executionResultObject,
 			}
 		}
 
+		if table := object.TableValue; table != nil {
+			listObj.TableValue = &executionResultTable{
+				Columns: table.Columns,
+				Rows:    make([][]*executionResultObject, len(table.Rows)),
+			}
+			for i, row := range table.Rows {
+				cells := make([]*executionResultObject, len(row.Cells))
+				for j, c := range row.Cells {
+					cells[j], err = mapResolveResponseObject(c)
+					if err != nil {
+						return nil, err
+					}
+				}
+				listObj.TableValue.Rows[i] = cells
+			}
+		}
+
 		return listObj, nil
+	case resolver.ObjectType_MONEY:
+		if object.MoneyValue == nil {
+			return nil, fmt.Errorf("money object has no value")
+		}
+		return &executionResultObject{
+			Type: &executionResultObjectType{
+				Class: "money",
+			},
+			MoneyValue: &executionResultMoney{
+				Amount:   object.MoneyValue.Amount,
+				Currency: object.MoneyValue.Currency,
+			},
+		}, nil
 	case resolver.ObjectType_NUMBER:
 		return &executionResultObject{
 			Type: &executionResultObjectType{
 				Class: "number",
 			},
 			NumberValue: &object.NumberValue,
+			Unit:        object.Unit,
 		}, nil
 	case resolver.ObjectType_RECORD:
 		recordObj := &executionResultObject{
@@ -107,16 +220,20 @@ func mapResolveResponseObject(object *resolver.Object) (*executionResultObject,
 				Class: "record",
 			},
 			RecordValue: &executionResultRecord{
-				Properties: make(map[string]*executionResultObject),
+				Properties: make(executionResultProperties, len(object.RecordValue.Properties)),
 			},
 		}
 
-		var err error
-		for _, prop := range object.RecordValue.Properties {
-			recordObj.RecordValue.Properties[prop.Name], err = mapResolveResponseObject(prop.Value)
+		for i, prop := range object.RecordValue.Properties {
+			value, err := mapResolveResponseObject(prop.Value)
 			if err != nil {
 				return nil, err
 			}
+
+			recordObj.RecordValue.Properties[i] = &executionResultProperty{
+				Name:  prop.Name,
+				Value: value,
+			}
 		}
 
 		return recordObj, nil
@@ -32,14 +32,111 @@ type updateVariableRequest struct {
 }
 
 type updateVariableResponse struct {
-	Error *string        `json:"error,omitempty"`
-	State *variableState `json:"state,omitempty"`
+	Error     *string            `json:"error,omitempty"`
+	State     *variableState     `json:"state,omitempty"`
+	Rewritten []*variableSummary `json:"rewritten,omitempty"`
+}
+
+type formatFormulaRequest struct {
+	Formula string `json:"formula"`
+}
+
+type formatFormulaResponse struct {
+	Error   *string `json:"error,omitempty"`
+	Formula *string `json:"formula,omitempty"`
+}
+
+type translateFormulaRequest struct {
+	Formula string `json:"formula"`
+}
+
+type translateFormulaResponse struct {
+	Formula     *string                  `json:"formula,omitempty"`
+	Diagnostics []*translationDiagnostic `json:"diagnostics"`
+}
+
+type translationDiagnostic struct {
+	Column  int32  `json:"column"`
+	Length  int32  `json:"length"`
+	Message string `json:"message"`
 }
 
 type getPageVariablesResponse struct {
 	Variables []*variableState `json:"variables"`
 }
 
+type saveScenarioRequest struct {
+	Name      string            `json:"name"`
+	Overrides map[string]string `json:"overrides"`
+}
+
+type saveScenarioResponse struct {
+	Error    *string        `json:"error,omitempty"`
+	Scenario *scenarioState `json:"scenario,omitempty"`
+}
+
+type getPageScenariosResponse struct {
+	Scenarios []*scenarioState         `json:"scenarios"`
+	Variables []*scenarioVariableState `json:"variables"`
+}
+
+type scenarioState struct {
+	Name      string            `json:"name"`
+	Overrides map[string]string `json:"overrides"`
+}
+
+// scenarioVariableState holds a variable's result as the page stands along with its result in each scenario, in
+// the same order as the scenarios. Variables which a scenario's results didn't include have nil there.
+type scenarioVariableState struct {
+	*variableState
+	Scenarios []*scenarioResult `json:"scenarios"`
+}
+
+type scenarioResult struct {
+	Formula string           `json:"formula"`
+	Result  *executionResult `json:"result"`
+}
+
+type goalSeekRequest struct {
+	Target        string  `json:"target"`
+	Value         float64 `json:"value"`
+	Input         string  `json:"input"`
+	Low           float64 `json:"low"`
+	High          float64 `json:"high"`
+	MaxIterations int32   `json:"maxIterations"`
+}
+
+type goalSeekResponse struct {
+	Error  *string         `json:"error,omitempty"`
+	Result *goalSeekResult `json:"result,omitempty"`
+}
+
+type goalSeekResult struct {
+	Solution   float64 `json:"solution"`
+	Value      float64 `json:"value"`
+	Iterations int32   `json:"iterations"`
+	Converged  bool    `json:"converged"`
+}
+
+type getPageGridResponse struct {
+	Cells []*cellState `json:"cells"`
+}
+
+type setCellRequest struct {
+	Formula string `json:"formula"`
+}
+
+type setCellResponse struct {
+	Error *string    `json:"error,omitempty"`
+	State *cellState `json:"state,omitempty"`
+}
+
+// cellState is the state of a grid cell along with its address, such as `B4`.
+type cellState struct {
+	*variableState
+	Cell string `json:"cell"`
+}
+
 type sessionState struct {
 	Id    string   `json:"id"`
 	Pages []string `json:"pages"`
@@ -57,3 +154,18 @@ type variableState struct {
 	Result       *executionResult `json:"result"`
 	Dependencies []string         `json:"dependencies"`
 }
+
+type variableEvent struct {
+	Variable     *variableSummary `json:"variable"`
+	PreviousName *string          `json:"previousName,omitempty"`
+}
+
+type variableSummary struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Formula string `json:"formula"`
+}
+
+type resultsEvent struct {
+	Variables []*variableState `json:"variables"`
+}
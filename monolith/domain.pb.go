@@ -145,6 +145,62 @@ func (m *Page) GetSession() string {
 	return ""
 }
 
+// A named set of formulas which replace those of the page's variables when the scenario is evaluated.
+type Scenario struct {
+	Page                 string            `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	Name                 string            `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Overrides            map[string]string `protobuf:"bytes,3,rep,name=overrides,proto3" json:"overrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Scenario) Reset()         { *m = Scenario{} }
+func (m *Scenario) String() string { return proto.CompactTextString(m) }
+func (*Scenario) ProtoMessage()    {}
+func (*Scenario) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73e6234e76dbdb84, []int{3}
+}
+
+func (m *Scenario) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Scenario.Unmarshal(m, b)
+}
+func (m *Scenario) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Scenario.Marshal(b, m, deterministic)
+}
+func (m *Scenario) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Scenario.Merge(m, src)
+}
+func (m *Scenario) XXX_Size() int {
+	return xxx_messageInfo_Scenario.Size(m)
+}
+func (m *Scenario) XXX_DiscardUnknown() {
+	xxx_messageInfo_Scenario.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Scenario proto.InternalMessageInfo
+
+func (m *Scenario) GetPage() string {
+	if m != nil {
+		return m.Page
+	}
+	return ""
+}
+
+func (m *Scenario) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Scenario) GetOverrides() map[string]string {
+	if m != nil {
+		return m.Overrides
+	}
+	return nil
+}
+
 type Variable struct {
 	VariableId           string   `protobuf:"bytes,1,opt,name=variable_id,json=variableId,proto3" json:"variable_id,omitempty"`
 	Page                 string   `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
@@ -159,7 +215,7 @@ func (m *Variable) Reset()         { *m = Variable{} }
 func (m *Variable) String() string { return proto.CompactTextString(m) }
 func (*Variable) ProtoMessage()    {}
 func (*Variable) Descriptor() ([]byte, []int) {
-	return fileDescriptor_73e6234e76dbdb84, []int{3}
+	return fileDescriptor_73e6234e76dbdb84, []int{4}
 }
 
 func (m *Variable) XXX_Unmarshal(b []byte) error {
@@ -212,24 +268,31 @@ func init() {
 	proto.RegisterType((*Error)(nil), "monolith.Error")
 	proto.RegisterType((*Session)(nil), "monolith.Session")
 	proto.RegisterType((*Page)(nil), "monolith.Page")
+	proto.RegisterType((*Scenario)(nil), "monolith.Scenario")
+	proto.RegisterMapType((map[string]string)(nil), "monolith.Scenario.OverridesEntry")
 	proto.RegisterType((*Variable)(nil), "monolith.Variable")
 }
 
 func init() { proto.RegisterFile("domain.proto", fileDescriptor_73e6234e76dbdb84) }
 
 var fileDescriptor_73e6234e76dbdb84 = []byte{
-	// 199 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x44, 0x90, 0x3f, 0x6f, 0xc2, 0x30,
-	0x10, 0xc5, 0x95, 0x36, 0xcd, 0x9f, 0x6b, 0x27, 0x2f, 0xf5, 0x52, 0x15, 0x3c, 0x65, 0x62, 0x61,
-	0xe2, 0x03, 0x30, 0x64, 0x43, 0x20, 0xb1, 0xa2, 0x8b, 0x6c, 0x82, 0xa5, 0xd8, 0x17, 0xd9, 0x81,
-	0xcf, 0x8f, 0x1c, 0x3b, 0xca, 0xf6, 0xde, 0x4f, 0xa7, 0xf7, 0x93, 0x0e, 0x7e, 0x24, 0x19, 0xd4,
-	0x76, 0x37, 0x3a, 0x9a, 0x88, 0x55, 0x86, 0x2c, 0x0d, 0x7a, 0x7a, 0x88, 0x2d, 0x7c, 0x1d, 0x9d,
-	0x23, 0xc7, 0x38, 0x94, 0x46, 0x79, 0x8f, 0xbd, 0xe2, 0xd9, 0x26, 0x6b, 0xea, 0xf3, 0x52, 0x45,
-	0x03, 0xe5, 0x45, 0x79, 0xaf, 0xc9, 0xb2, 0x3f, 0x00, 0x1f, 0xe3, 0x4d, 0xcb, 0x74, 0x57, 0x27,
-	0xd2, 0x4a, 0x71, 0x80, 0xfc, 0x84, 0xbd, 0x62, 0xbf, 0x50, 0x8e, 0xd8, 0xab, 0xf5, 0xa6, 0x08,
-	0xb5, 0x95, 0x41, 0x92, 0xae, 0xf9, 0x47, 0x94, 0xa4, 0x2a, 0x0c, 0x54, 0x57, 0x74, 0x1a, 0xbb,
-	0x41, 0xb1, 0x7f, 0xf8, 0x7e, 0xa5, 0xbc, 0x4e, 0xc0, 0x82, 0x5a, 0xc9, 0x18, 0xe4, 0x61, 0x30,
-	0x6d, 0xcc, 0x39, 0x30, 0x8b, 0x46, 0xf1, 0xcf, 0xc8, 0x42, 0x0e, 0xba, 0x3b, 0x39, 0xf3, 0x1c,
-	0x90, 0xe7, 0x51, 0x97, 0x6a, 0x57, 0xcc, 0x7f, 0xd8, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0xcf,
-	0x5e, 0x33, 0x14, 0x17, 0x01, 0x00, 0x00,
+	// 281 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x51, 0x41, 0x4b, 0xf4, 0x30,
+	0x14, 0xa4, 0xdb, 0xee, 0xb6, 0x7d, 0xfb, 0xf1, 0x21, 0x41, 0x30, 0x08, 0xe2, 0x6e, 0x4f, 0x3d,
+	0xf5, 0xa0, 0x17, 0x15, 0xc1, 0xd3, 0x1e, 0x7a, 0x52, 0x76, 0xc1, 0xab, 0x64, 0xcd, 0xb3, 0x06,
+	0x9b, 0xa4, 0x24, 0xdd, 0xc2, 0xfe, 0x2c, 0xff, 0xa1, 0xb4, 0x4d, 0xac, 0x82, 0xb7, 0x99, 0xc9,
+	0xbc, 0x4c, 0x26, 0x0f, 0xfe, 0x71, 0x2d, 0x99, 0x50, 0x45, 0x63, 0x74, 0xab, 0x49, 0x22, 0xb5,
+	0xd2, 0xb5, 0x68, 0xdf, 0xb3, 0x35, 0xcc, 0x37, 0xc6, 0x68, 0x43, 0x28, 0xc4, 0x12, 0xad, 0x65,
+	0x15, 0xd2, 0x60, 0x15, 0xe4, 0xe9, 0xd6, 0xd3, 0x2c, 0x87, 0x78, 0x87, 0xd6, 0x0a, 0xad, 0xc8,
+	0x05, 0x80, 0x1d, 0xe1, 0x8b, 0xe0, 0xce, 0x97, 0x3a, 0xa5, 0xe4, 0xd9, 0x2d, 0x44, 0x4f, 0xac,
+	0x42, 0x72, 0x06, 0x71, 0xc3, 0x2a, 0x9c, 0x3c, 0x8b, 0x9e, 0x96, 0xbc, 0x0f, 0x71, 0x6e, 0x3a,
+	0x1b, 0x43, 0x1c, 0xcd, 0x3e, 0x03, 0x48, 0x76, 0xaf, 0xa8, 0x98, 0x11, 0x9a, 0x10, 0x88, 0x9a,
+	0xe9, 0x21, 0x03, 0xee, 0x35, 0xc5, 0x24, 0xba, 0xb9, 0x01, 0x93, 0x07, 0x48, 0x75, 0x87, 0xc6,
+	0x08, 0x8e, 0x96, 0x86, 0xab, 0x30, 0x5f, 0x5e, 0xad, 0x0b, 0x5f, 0xad, 0xf0, 0xd7, 0x15, 0x8f,
+	0xde, 0xb3, 0x51, 0xad, 0x39, 0x6e, 0xa7, 0x99, 0xf3, 0x7b, 0xf8, 0xff, 0xfb, 0x90, 0x9c, 0x40,
+	0xf8, 0x81, 0x47, 0x97, 0xdc, 0x43, 0x72, 0x0a, 0xf3, 0x8e, 0xd5, 0x07, 0x9f, 0x3c, 0x92, 0xbb,
+	0xd9, 0x4d, 0x90, 0x49, 0x48, 0x9e, 0x99, 0x11, 0x6c, 0x5f, 0x23, 0xb9, 0x84, 0x65, 0xe7, 0xf0,
+	0x54, 0x1b, 0xbc, 0x54, 0xf2, 0xef, 0x4e, 0xb3, 0x3f, 0x3a, 0x85, 0x3f, 0x3a, 0x51, 0x88, 0xdf,
+	0xb4, 0x91, 0x87, 0x9a, 0xd1, 0x68, 0xfc, 0x22, 0x47, 0xf7, 0x8b, 0x61, 0x77, 0xd7, 0x5f, 0x03,
+	0x00, 0x39, 0x3b, 0x16, 0xe1, 0xcb, 0x01, 0x00, 0x00,
 }
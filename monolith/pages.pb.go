@@ -280,6 +280,180 @@ func (m *FindPagesResponse) GetPages() []*Page {
 	return nil
 }
 
+// Replaces any scenario on the page with the same name.
+type SaveScenarioRequest struct {
+	Scenario             *Scenario `protobuf:"bytes,1,opt,name=scenario,proto3" json:"scenario,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SaveScenarioRequest) Reset()         { *m = SaveScenarioRequest{} }
+func (m *SaveScenarioRequest) String() string { return proto.CompactTextString(m) }
+func (*SaveScenarioRequest) ProtoMessage()    {}
+func (*SaveScenarioRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20be0c8f23cb364f, []int{6}
+}
+
+func (m *SaveScenarioRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SaveScenarioRequest.Unmarshal(m, b)
+}
+func (m *SaveScenarioRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SaveScenarioRequest.Marshal(b, m, deterministic)
+}
+func (m *SaveScenarioRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveScenarioRequest.Merge(m, src)
+}
+func (m *SaveScenarioRequest) XXX_Size() int {
+	return xxx_messageInfo_SaveScenarioRequest.Size(m)
+}
+func (m *SaveScenarioRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveScenarioRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SaveScenarioRequest proto.InternalMessageInfo
+
+func (m *SaveScenarioRequest) GetScenario() *Scenario {
+	if m != nil {
+		return m.Scenario
+	}
+	return nil
+}
+
+type SaveScenarioResponse struct {
+	Error                *Error    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Scenario             *Scenario `protobuf:"bytes,2,opt,name=scenario,proto3" json:"scenario,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SaveScenarioResponse) Reset()         { *m = SaveScenarioResponse{} }
+func (m *SaveScenarioResponse) String() string { return proto.CompactTextString(m) }
+func (*SaveScenarioResponse) ProtoMessage()    {}
+func (*SaveScenarioResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20be0c8f23cb364f, []int{7}
+}
+
+func (m *SaveScenarioResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SaveScenarioResponse.Unmarshal(m, b)
+}
+func (m *SaveScenarioResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SaveScenarioResponse.Marshal(b, m, deterministic)
+}
+func (m *SaveScenarioResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveScenarioResponse.Merge(m, src)
+}
+func (m *SaveScenarioResponse) XXX_Size() int {
+	return xxx_messageInfo_SaveScenarioResponse.Size(m)
+}
+func (m *SaveScenarioResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveScenarioResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SaveScenarioResponse proto.InternalMessageInfo
+
+func (m *SaveScenarioResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *SaveScenarioResponse) GetScenario() *Scenario {
+	if m != nil {
+		return m.Scenario
+	}
+	return nil
+}
+
+type FindScenariosRequest struct {
+	PageId               string   `protobuf:"bytes,1,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindScenariosRequest) Reset()         { *m = FindScenariosRequest{} }
+func (m *FindScenariosRequest) String() string { return proto.CompactTextString(m) }
+func (*FindScenariosRequest) ProtoMessage()    {}
+func (*FindScenariosRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20be0c8f23cb364f, []int{8}
+}
+
+func (m *FindScenariosRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindScenariosRequest.Unmarshal(m, b)
+}
+func (m *FindScenariosRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindScenariosRequest.Marshal(b, m, deterministic)
+}
+func (m *FindScenariosRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindScenariosRequest.Merge(m, src)
+}
+func (m *FindScenariosRequest) XXX_Size() int {
+	return xxx_messageInfo_FindScenariosRequest.Size(m)
+}
+func (m *FindScenariosRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindScenariosRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindScenariosRequest proto.InternalMessageInfo
+
+func (m *FindScenariosRequest) GetPageId() string {
+	if m != nil {
+		return m.PageId
+	}
+	return ""
+}
+
+type FindScenariosResponse struct {
+	Error *Error `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	// In the order they were first saved.
+	Scenarios            []*Scenario `protobuf:"bytes,2,rep,name=scenarios,proto3" json:"scenarios,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *FindScenariosResponse) Reset()         { *m = FindScenariosResponse{} }
+func (m *FindScenariosResponse) String() string { return proto.CompactTextString(m) }
+func (*FindScenariosResponse) ProtoMessage()    {}
+func (*FindScenariosResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20be0c8f23cb364f, []int{9}
+}
+
+func (m *FindScenariosResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindScenariosResponse.Unmarshal(m, b)
+}
+func (m *FindScenariosResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindScenariosResponse.Marshal(b, m, deterministic)
+}
+func (m *FindScenariosResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindScenariosResponse.Merge(m, src)
+}
+func (m *FindScenariosResponse) XXX_Size() int {
+	return xxx_messageInfo_FindScenariosResponse.Size(m)
+}
+func (m *FindScenariosResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindScenariosResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindScenariosResponse proto.InternalMessageInfo
+
+func (m *FindScenariosResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *FindScenariosResponse) GetScenarios() []*Scenario {
+	if m != nil {
+		return m.Scenarios
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*CreatePageRequest)(nil), "monolith.CreatePageRequest")
 	proto.RegisterType((*CreatePageResponse)(nil), "monolith.CreatePageResponse")
@@ -287,30 +461,41 @@ func init() {
 	proto.RegisterType((*GetPagesResponse)(nil), "monolith.GetPagesResponse")
 	proto.RegisterType((*FindPagesRequest)(nil), "monolith.FindPagesRequest")
 	proto.RegisterType((*FindPagesResponse)(nil), "monolith.FindPagesResponse")
+	proto.RegisterType((*SaveScenarioRequest)(nil), "monolith.SaveScenarioRequest")
+	proto.RegisterType((*SaveScenarioResponse)(nil), "monolith.SaveScenarioResponse")
+	proto.RegisterType((*FindScenariosRequest)(nil), "monolith.FindScenariosRequest")
+	proto.RegisterType((*FindScenariosResponse)(nil), "monolith.FindScenariosResponse")
 }
 
 func init() { proto.RegisterFile("pages.proto", fileDescriptor_20be0c8f23cb364f) }
 
 var fileDescriptor_20be0c8f23cb364f = []byte{
-	// 286 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x92, 0xc1, 0x4e, 0x83, 0x40,
-	0x10, 0x86, 0xa5, 0x15, 0xa5, 0x53, 0x63, 0xdb, 0x39, 0x51, 0xea, 0x81, 0x6c, 0x34, 0xf1, 0x50,
-	0x39, 0xd4, 0x47, 0x68, 0xac, 0xe9, 0xcd, 0xf0, 0x02, 0x88, 0x61, 0x52, 0x49, 0x2c, 0x8b, 0xcc,
-	0xfa, 0xbc, 0xbe, 0x8a, 0xd9, 0x25, 0xdb, 0xc5, 0x8a, 0x07, 0x0f, 0x1e, 0x67, 0xfe, 0x2f, 0xc3,
-	0xc7, 0xcc, 0xc2, 0xb8, 0xce, 0x77, 0xc4, 0x49, 0xdd, 0x48, 0x25, 0x31, 0xd8, 0xcb, 0x4a, 0xbe,
-	0x95, 0xea, 0x35, 0xba, 0x28, 0xe4, 0x3e, 0x2f, 0xab, 0xb6, 0x2f, 0xee, 0x60, 0xb6, 0x6e, 0x28,
-	0x57, 0xf4, 0x94, 0xef, 0x28, 0xa5, 0xf7, 0x0f, 0x62, 0x85, 0x21, 0x9c, 0x33, 0x31, 0x97, 0xb2,
-	0x0a, 0xbd, 0xd8, 0xbb, 0x1d, 0xa5, 0xb6, 0x14, 0x19, 0x60, 0x17, 0xe7, 0x5a, 0x56, 0x4c, 0x78,
-	0x03, 0x3e, 0x35, 0x8d, 0x6c, 0x0c, 0x3d, 0x5e, 0x4d, 0x12, 0xfb, 0xb1, 0xe4, 0x41, 0xb7, 0xd3,
-	0x36, 0x45, 0x01, 0xa7, 0x5a, 0x29, 0x1c, 0x18, 0xea, 0xd2, 0x51, 0x66, 0x98, 0xc9, 0xc4, 0x12,
-	0x26, 0x8f, 0xa4, 0x74, 0x83, 0xad, 0xcd, 0x1c, 0x02, 0x1d, 0x65, 0x65, 0xc1, 0xa1, 0x17, 0x0f,
-	0xb5, 0x8e, 0xae, 0xb7, 0x05, 0x8b, 0x0c, 0xa6, 0x8e, 0xfe, 0x9b, 0xcc, 0x35, 0xf8, 0x66, 0x3f,
-	0xe1, 0x20, 0x1e, 0xf6, 0xd8, 0xb4, 0xa1, 0x58, 0xc2, 0x74, 0x53, 0x56, 0xc5, 0x37, 0x9f, 0xdf,
-	0xb7, 0xf3, 0x0c, 0xb3, 0x0e, 0xfd, 0x0f, 0x3e, 0xab, 0x4f, 0x0f, 0x7c, 0x33, 0x1e, 0xb7, 0x00,
-	0xee, 0x12, 0xb8, 0x70, 0xf8, 0x8f, 0x73, 0x46, 0x57, 0xfd, 0x61, 0xeb, 0x27, 0x4e, 0x70, 0x0d,
-	0x81, 0xdd, 0x22, 0xce, 0x1d, 0x7b, 0x74, 0x87, 0x28, 0xea, 0x8b, 0x0e, 0x43, 0x36, 0x30, 0x3a,
-	0xfc, 0x3b, 0x76, 0xd0, 0xe3, 0xf5, 0x45, 0x8b, 0xde, 0xcc, 0xce, 0x79, 0x39, 0x33, 0xef, 0xf2,
-	0xfe, 0x2b, 0x00, 0x00, 0xff, 0xff, 0x5e, 0xdd, 0x06, 0xe3, 0xbe, 0x02, 0x00, 0x00,
+	// 396 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0xd1, 0x4e, 0xc2, 0x30,
+	0x14, 0x86, 0x05, 0x04, 0xc6, 0x01, 0x05, 0x2a, 0xc6, 0x31, 0x14, 0x49, 0xa3, 0x89, 0x17, 0x38,
+	0x0d, 0x3e, 0x02, 0x01, 0xc3, 0x95, 0x66, 0x3c, 0x00, 0x4e, 0xd7, 0xe0, 0x12, 0x59, 0xe7, 0x3a,
+	0x7d, 0x02, 0x1f, 0xdc, 0xac, 0x5b, 0xdb, 0x6d, 0x8c, 0x44, 0x2e, 0xbc, 0xdc, 0xf9, 0xff, 0x9e,
+	0xf3, 0xe5, 0x3f, 0x07, 0xa0, 0xe9, 0xdb, 0x6b, 0xc2, 0x4c, 0x3f, 0xa0, 0x21, 0x45, 0xda, 0x86,
+	0x7a, 0xf4, 0xc3, 0x0d, 0xdf, 0x8d, 0x96, 0x43, 0x37, 0xb6, 0xeb, 0xc5, 0x75, 0x7c, 0x0b, 0xdd,
+	0x69, 0x40, 0xec, 0x90, 0x3c, 0xdb, 0x6b, 0x62, 0x91, 0xcf, 0x2f, 0xc2, 0x42, 0xa4, 0x43, 0x9d,
+	0x11, 0xc6, 0x5c, 0xea, 0xe9, 0xa5, 0x51, 0xe9, 0xa6, 0x61, 0x89, 0x4f, 0xbc, 0x02, 0x94, 0xb6,
+	0x33, 0x9f, 0x7a, 0x8c, 0xa0, 0x6b, 0xa8, 0x92, 0x20, 0xa0, 0x01, 0x77, 0x37, 0x27, 0x6d, 0x53,
+	0x0c, 0x33, 0x67, 0x51, 0xd9, 0x8a, 0x55, 0x84, 0xe1, 0x30, 0x42, 0xd2, 0xcb, 0xdc, 0x75, 0xac,
+	0x5c, 0xbc, 0x19, 0xd7, 0xf0, 0x18, 0xda, 0x8f, 0x24, 0x8c, 0x0a, 0x4c, 0xd0, 0xf4, 0x41, 0x8b,
+	0xa4, 0x95, 0xeb, 0x30, 0xbd, 0x34, 0xaa, 0x44, 0x38, 0xd1, 0xf7, 0xc2, 0x61, 0x78, 0x05, 0x1d,
+	0xe5, 0xde, 0x0f, 0xe6, 0x0a, 0xaa, 0x3c, 0x1f, 0xbd, 0x3c, 0xaa, 0x14, 0xd0, 0xc4, 0x22, 0x1e,
+	0x43, 0x67, 0xee, 0x7a, 0x4e, 0x86, 0x67, 0x77, 0x3a, 0x2f, 0xd0, 0x4d, 0xb9, 0xff, 0x83, 0x67,
+	0x06, 0x27, 0x4b, 0xfb, 0x9b, 0x2c, 0xdf, 0x88, 0x67, 0x07, 0x2e, 0x15, 0x48, 0x26, 0x68, 0x2c,
+	0x29, 0x25, 0x63, 0x90, 0x7a, 0x2f, 0xcd, 0xd2, 0x83, 0x37, 0xd0, 0xcb, 0xb6, 0xd9, 0x8f, 0x35,
+	0x3d, 0xae, 0xfc, 0x87, 0x71, 0x77, 0xd0, 0x8b, 0x72, 0x11, 0x8a, 0x4c, 0xf2, 0x0c, 0xea, 0xc9,
+	0x66, 0x93, 0x24, 0x6b, 0xf1, 0x62, 0xb1, 0x0f, 0xa7, 0xb9, 0x07, 0xfb, 0x01, 0xde, 0x43, 0x43,
+	0x0c, 0x17, 0x81, 0x16, 0x11, 0x2a, 0xd3, 0xe4, 0xa7, 0x02, 0x55, 0xbe, 0x37, 0xb4, 0x00, 0x50,
+	0x27, 0x8e, 0x06, 0xea, 0xd9, 0xd6, 0xef, 0xc4, 0x38, 0x2f, 0x16, 0x63, 0x56, 0x7c, 0x80, 0xa6,
+	0xa0, 0x89, 0xf3, 0x44, 0x7d, 0xe5, 0xcd, 0x1d, 0xb8, 0x61, 0x14, 0x49, 0xb2, 0xc9, 0x1c, 0x1a,
+	0xf2, 0xa8, 0x50, 0xca, 0x9a, 0xbf, 0x4b, 0x63, 0x50, 0xa8, 0xc9, 0x3e, 0x4f, 0xd0, 0x4a, 0xef,
+	0x1c, 0x5d, 0xa4, 0x02, 0xd9, 0x3e, 0x29, 0x63, 0xb8, 0x4b, 0x96, 0x0d, 0x2d, 0x38, 0xca, 0x2c,
+	0x09, 0x0d, 0xb3, 0x00, 0xf9, 0x75, 0x1b, 0x97, 0x3b, 0x75, 0xd1, 0xf3, 0xb5, 0xc6, 0xff, 0x95,
+	0x1e, 0x7e, 0x07, 0x00, 0x99, 0xfc, 0xd7, 0x37, 0xbc, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -328,6 +513,8 @@ type PagesClient interface {
 	CreatePage(ctx context.Context, in *CreatePageRequest, opts ...grpc.CallOption) (*CreatePageResponse, error)
 	GetPages(ctx context.Context, in *GetPagesRequest, opts ...grpc.CallOption) (*GetPagesResponse, error)
 	FindPages(ctx context.Context, in *FindPagesRequest, opts ...grpc.CallOption) (*FindPagesResponse, error)
+	SaveScenario(ctx context.Context, in *SaveScenarioRequest, opts ...grpc.CallOption) (*SaveScenarioResponse, error)
+	FindScenarios(ctx context.Context, in *FindScenariosRequest, opts ...grpc.CallOption) (*FindScenariosResponse, error)
 }
 
 type pagesClient struct {
@@ -365,11 +552,31 @@ func (c *pagesClient) FindPages(ctx context.Context, in *FindPagesRequest, opts
 	return out, nil
 }
 
+func (c *pagesClient) SaveScenario(ctx context.Context, in *SaveScenarioRequest, opts ...grpc.CallOption) (*SaveScenarioResponse, error) {
+	out := new(SaveScenarioResponse)
+	err := c.cc.Invoke(ctx, "/monolith.Pages/SaveScenario", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pagesClient) FindScenarios(ctx context.Context, in *FindScenariosRequest, opts ...grpc.CallOption) (*FindScenariosResponse, error) {
+	out := new(FindScenariosResponse)
+	err := c.cc.Invoke(ctx, "/monolith.Pages/FindScenarios", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PagesServer is the server API for Pages service.
 type PagesServer interface {
 	CreatePage(context.Context, *CreatePageRequest) (*CreatePageResponse, error)
 	GetPages(context.Context, *GetPagesRequest) (*GetPagesResponse, error)
 	FindPages(context.Context, *FindPagesRequest) (*FindPagesResponse, error)
+	SaveScenario(context.Context, *SaveScenarioRequest) (*SaveScenarioResponse, error)
+	FindScenarios(context.Context, *FindScenariosRequest) (*FindScenariosResponse, error)
 }
 
 func RegisterPagesServer(s *grpc.Server, srv PagesServer) {
@@ -430,6 +637,42 @@ func _Pages_FindPages_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Pages_SaveScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveScenarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PagesServer).SaveScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/monolith.Pages/SaveScenario",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PagesServer).SaveScenario(ctx, req.(*SaveScenarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pages_FindScenarios_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindScenariosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PagesServer).FindScenarios(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/monolith.Pages/FindScenarios",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PagesServer).FindScenarios(ctx, req.(*FindScenariosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Pages_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "monolith.Pages",
 	HandlerType: (*PagesServer)(nil),
@@ -446,6 +689,14 @@ var _Pages_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FindPages",
 			Handler:    _Pages_FindPages_Handler,
 		},
+		{
+			MethodName: "SaveScenario",
+			Handler:    _Pages_SaveScenario_Handler,
+		},
+		{
+			MethodName: "FindScenarios",
+			Handler:    _Pages_FindScenarios_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pages.proto",
@@ -5,7 +5,9 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"log"
+	"strings"
 	"sync"
 
 	"github.com/tobyjsullivan/chalk/monolith"
@@ -21,7 +23,8 @@ type pagesServer struct {
 }
 
 type pageState struct {
-	session string
+	session   string
+	scenarios []*monolith.Scenario
 }
 
 func newPagesServer() *pagesServer {
@@ -113,6 +116,81 @@ func (s *pagesServer) FindPages(ctx context.Context, req *monolith.FindPagesRequ
 	}, nil
 }
 
+func (s *pagesServer) SaveScenario(ctx context.Context, req *monolith.SaveScenarioRequest) (*monolith.SaveScenarioResponse, error) {
+	log.Println("SaveScenario")
+	in := req.Scenario
+	if in == nil || in.Page == "" {
+		return nil, errors.New("pageId cannot be empty")
+	}
+	if in.Name == "" {
+		return nil, errors.New("scenario name cannot be empty")
+	}
+
+	overrides := make(map[string]string, len(in.Overrides))
+	for name, formula := range in.Overrides {
+		if name == "" {
+			return nil, errors.New("variable name cannot be empty")
+		}
+		name = normalizeVarName(name)
+		if err := validateName(name); err != nil {
+			return nil, err
+		}
+		overrides[name] = formula
+	}
+
+	scenario := &monolith.Scenario{
+		Page:      in.Page,
+		Name:      in.Name,
+		Overrides: overrides,
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	state, ok := s.pages[in.Page]
+	if !ok {
+		return nil, fmt.Errorf("page `%s` does not exist", in.Page)
+	}
+
+	replaced := false
+	for i, existing := range state.scenarios {
+		if strings.EqualFold(existing.Name, in.Name) {
+			state.scenarios[i] = scenario
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		state.scenarios = append(state.scenarios, scenario)
+	}
+
+	return &monolith.SaveScenarioResponse{
+		Scenario: scenario,
+	}, nil
+}
+
+func (s *pagesServer) FindScenarios(ctx context.Context, req *monolith.FindScenariosRequest) (*monolith.FindScenariosResponse, error) {
+	log.Println("FindScenarios")
+	if req.PageId == "" {
+		return nil, errors.New("pageId cannot be empty")
+	}
+
+	s.mx.RLock()
+	defer s.mx.RUnlock()
+	state, ok := s.pages[req.PageId]
+	if !ok {
+		return &monolith.FindScenariosResponse{
+			Scenarios: []*monolith.Scenario{},
+		}, nil
+	}
+
+	scenarios := make([]*monolith.Scenario, len(state.scenarios))
+	copy(scenarios, state.scenarios)
+
+	return &monolith.FindScenariosResponse{
+		Scenarios: scenarios,
+	}, nil
+}
+
 func generatePageId() (string, error) {
 	pid := [pageIdSizeBytes]byte{}
 	if _, err := rand.Read(pid[:]); err != nil {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/tobyjsullivan/chalk/monolith/server/variables"
+	"github.com/tobyjsullivan/chalk/parsing"
 
 	"github.com/tobyjsullivan/chalk/monolith"
 )
@@ -126,6 +127,7 @@ func (s *variablesServer) UpdateVariable(ctx context.Context, in *monolith.Updat
 
 	var err error
 	var state *variables.VariableState
+	var rewritten []*variables.VariableState
 	if in.Name != "" {
 		name := normalizeVarName(in.Name)
 		err = validateName(name)
@@ -133,7 +135,15 @@ func (s *variablesServer) UpdateVariable(ctx context.Context, in *monolith.Updat
 			return nil, err
 		}
 
-		state, err = s.repo.RenameVariable(id, name)
+		current, err := s.repo.GetVariables([]string{id})
+		if err != nil {
+			return nil, err
+		}
+		if isCell(current[0].Name) {
+			return nil, fmt.Errorf("cell `%s` cannot be renamed", current[0].Name)
+		}
+
+		state, rewritten, err = s.repo.RenameVariable(id, name, parsing.RewriteReferences)
 		if err != nil {
 			return nil, err
 		}
@@ -146,6 +156,16 @@ func (s *variablesServer) UpdateVariable(ctx context.Context, in *monolith.Updat
 		}
 	}
 
+	out := make([]*monolith.Variable, len(rewritten))
+	for i, s := range rewritten {
+		out[i] = &monolith.Variable{
+			VariableId: s.Id,
+			Page:       s.Page,
+			Name:       s.Name,
+			Formula:    s.Formula,
+		}
+	}
+
 	return &monolith.UpdateVariableResponse{
 		Variable: &monolith.Variable{
 			VariableId: state.Id,
@@ -153,11 +173,86 @@ func (s *variablesServer) UpdateVariable(ctx context.Context, in *monolith.Updat
 			Name:       state.Name,
 			Formula:    state.Formula,
 		},
+		Rewritten: out,
+	}, nil
+}
+
+func (s *variablesServer) SetCell(ctx context.Context, in *monolith.SetCellRequest) (*monolith.SetCellResponse, error) {
+	log.Println("SetCell")
+	pageId := in.PageId
+	if pageId == "" {
+		return nil, errors.New("pageId cannot be empty")
+	}
+
+	if !isCell(in.Cell) {
+		return nil, fmt.Errorf("`%s` is not a cell address", in.Cell)
+	}
+
+	state, err := s.repo.SetCell(pageId, in.Cell, in.Formula)
+	if err != nil {
+		return nil, err
+	}
+
+	return &monolith.SetCellResponse{
+		Variable: &monolith.Variable{
+			VariableId: state.Id,
+			Page:       state.Page,
+			Name:       state.Name,
+			Formula:    state.Formula,
+		},
 	}, nil
 }
 
+func (s *variablesServer) WatchVariables(in *monolith.WatchVariablesRequest, stream monolith.Variables_WatchVariablesServer) error {
+	log.Println("WatchVariables")
+	pageId := in.PageId
+	if pageId == "" {
+		return errors.New("pageId cannot be empty")
+	}
+
+	events, cancel := s.repo.Subscribe(pageId)
+	defer cancel()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return errors.New("subscriber fell too far behind")
+			}
+
+			err := stream.Send(&monolith.VariableEvent{
+				Type: toEventType(event.Type),
+				Variable: &monolith.Variable{
+					VariableId: event.State.Id,
+					Page:       event.State.Page,
+					Name:       event.State.Name,
+					Formula:    event.State.Formula,
+				},
+				PreviousName: event.PreviousName,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toEventType(t variables.EventType) monolith.VariableEventType {
+	switch t {
+	case variables.EventRenamed:
+		return monolith.VariableEventType_RENAMED
+	case variables.EventUpdated:
+		return monolith.VariableEventType_UPDATED
+	default:
+		return monolith.VariableEventType_CREATED
+	}
+}
+
 func normalizeVarName(name string) string {
-	return strings.ToLower(name)
+	return parsing.NormaliseName(name)
 }
 
 func validateName(name string) error {
@@ -169,5 +264,16 @@ func validateName(name string) error {
 		return fmt.Errorf("variable name `%s` must not start with a number", name)
 	}
 
+	if isCell(name) {
+		return fmt.Errorf("variable name `%s` is a cell address", name)
+	}
+
 	return nil
 }
+
+// isCell returns whether a variable name is the address of a grid cell. Addresses are uppercase, so names such as
+// `q1` are ordinary variables.
+func isCell(name string) bool {
+	_, _, ok := parsing.ParseCell(name)
+	return ok
+}
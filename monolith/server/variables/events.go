@@ -0,0 +1,74 @@
+package variables
+
+// How many events may be waiting for a subscriber before it's considered too far behind.
+const subscriberBufferSize = 100
+
+type EventType int
+
+const (
+	EventCreated EventType = iota
+	EventUpdated
+	EventRenamed
+)
+
+type VariableEvent struct {
+	Type  EventType
+	State *VariableState
+	// Only set for EventRenamed.
+	PreviousName string
+}
+
+type subscriber struct {
+	events chan *VariableEvent
+}
+
+func (r *variablesRepo) Subscribe(pageId string) (<-chan *VariableEvent, func()) {
+	sub := &subscriber{
+		events: make(chan *VariableEvent, subscriberBufferSize),
+	}
+
+	r.subsMx.Lock()
+	defer r.subsMx.Unlock()
+	if r.subscribers[pageId] == nil {
+		r.subscribers[pageId] = make(map[*subscriber]bool)
+	}
+	r.subscribers[pageId][sub] = true
+
+	return sub.events, func() {
+		r.unsubscribe(pageId, sub)
+	}
+}
+
+func (r *variablesRepo) unsubscribe(pageId string, sub *subscriber) {
+	r.subsMx.Lock()
+	defer r.subsMx.Unlock()
+	if !r.subscribers[pageId][sub] {
+		// Already dropped
+		return
+	}
+
+	delete(r.subscribers[pageId], sub)
+	if len(r.subscribers[pageId]) == 0 {
+		delete(r.subscribers, pageId)
+	}
+	close(sub.events)
+}
+
+func (r *variablesRepo) publish(event *VariableEvent) {
+	pageId := event.State.Page
+
+	r.subsMx.Lock()
+	defer r.subsMx.Unlock()
+	for sub := range r.subscribers[pageId] {
+		select {
+		case sub.events <- event:
+		default:
+			// Rather than silently losing events, end the subscription so the subscriber knows to start over.
+			delete(r.subscribers[pageId], sub)
+			close(sub.events)
+		}
+	}
+	if len(r.subscribers[pageId]) == 0 {
+		delete(r.subscribers, pageId)
+	}
+}
@@ -29,13 +29,25 @@ type Repository interface {
 	FindVariablesByName(pageId string, names []string) []*VariableState
 	CreateVariable(pageId, name, formula string) (*VariableState, error)
 	UpdateVariable(variableId, formula string) (*VariableState, error)
-	RenameVariable(variableId, name string) (*VariableState, error)
+	// SetCell sets the formula of the named grid cell, creating the cell if it doesn't yet exist.
+	SetCell(pageId, name, formula string) (*VariableState, error)
+	// RenameVariable renames a variable and passes the formula of every variable on the page through rewrite, if
+	// given, so that references to the old name can be updated. Either every change is applied or none are. It
+	// returns the renamed variable along with any other variables whose formulas changed.
+	RenameVariable(variableId, name string, rewrite RewriteFunc) (*VariableState, []*VariableState, error)
+	// Subscribe delivers an event for every change to a variable on the page until cancel is called. If the
+	// subscriber falls too far behind, the channel is closed.
+	Subscribe(pageId string) (events <-chan *VariableEvent, cancel func())
 }
 
+// RewriteFunc returns the formula updated to account for a variable being renamed.
+type RewriteFunc func(formula, oldName, newName string) (string, error)
+
 func NewVariablesRepo() Repository {
 	return &variablesRepo{
-		varMap:    make(map[string]*VariableState),
-		pageIndex: make(map[string][]string),
+		varMap:      make(map[string]*VariableState),
+		pageIndex:   make(map[string][]string),
+		subscribers: make(map[string]map[*subscriber]bool),
 	}
 }
 
@@ -43,6 +55,9 @@ type variablesRepo struct {
 	mx        sync.RWMutex
 	varMap    map[string]*VariableState
 	pageIndex map[string][]string
+
+	subsMx      sync.Mutex
+	subscribers map[string]map[*subscriber]bool
 }
 
 func (r *variablesRepo) getVariableState(variableId string) *VariableState {
@@ -112,6 +127,10 @@ func (r *variablesRepo) CreateVariable(pageId, name, formula string) (*VariableS
 
 	state := buildVariableState(id, pageId, name, formula)
 	r.addVariable(state)
+	r.publish(&VariableEvent{
+		Type:  EventCreated,
+		State: state,
+	})
 
 	return state, nil
 }
@@ -132,23 +151,108 @@ func (r *variablesRepo) UpdateVariable(variableId, formula string) (*VariableSta
 
 	newState := buildVariableState(variableId, state.Page, state.Name, formula)
 	r.mx.Lock()
-	defer r.mx.Unlock()
 	r.varMap[variableId] = newState
+	r.mx.Unlock()
+
+	r.publish(&VariableEvent{
+		Type:  EventUpdated,
+		State: newState,
+	})
 
 	return newState, nil
 }
-func (r *variablesRepo) RenameVariable(variableId, name string) (*VariableState, error) {
-	state := r.getVariableState(variableId)
+
+func (r *variablesRepo) SetCell(pageId, name, formula string) (*VariableState, error) {
+	r.mx.Lock()
+	var existing *VariableState
+	for _, id := range r.pageIndex[pageId] {
+		if r.varMap[id].Name == name {
+			existing = r.varMap[id]
+			break
+		}
+	}
+
+	event := &VariableEvent{Type: EventUpdated}
+	if existing != nil {
+		event.State = buildVariableState(existing.Id, pageId, name, formula)
+		r.varMap[existing.Id] = event.State
+	} else {
+		id, err := generateVariableId()
+		if err != nil {
+			r.mx.Unlock()
+			return nil, err
+		}
+
+		event.Type = EventCreated
+		event.State = buildVariableState(id, pageId, name, formula)
+		r.varMap[id] = event.State
+		r.pageIndex[pageId] = append(r.pageIndex[pageId], id)
+	}
+	r.mx.Unlock()
+
+	r.publish(event)
+
+	return event.State, nil
+}
+
+func (r *variablesRepo) RenameVariable(variableId, name string, rewrite RewriteFunc) (*VariableState, []*VariableState, error) {
+	r.mx.Lock()
+	state := r.varMap[variableId]
 	if state == nil {
-		return nil, fmt.Errorf("variable %s does not exist", variableId)
+		r.mx.Unlock()
+		return nil, nil, fmt.Errorf("variable %s does not exist", variableId)
+	}
+
+	pageVars := r.pageIndex[state.Page]
+	for _, id := range pageVars {
+		if id != variableId && r.varMap[id].Name == name {
+			r.mx.Unlock()
+			return nil, nil, fmt.Errorf("variable `%s` already exists", name)
+		}
 	}
 
 	newState := buildVariableState(variableId, state.Page, name, state.Formula)
-	r.mx.Lock()
-	defer r.mx.Unlock()
+	var rewritten []*VariableState
+	if rewrite != nil {
+		// Work out every change before applying any of them.
+		for _, id := range pageVars {
+			current := r.varMap[id]
+			formula, err := rewrite(current.Formula, state.Name, name)
+			if err != nil {
+				r.mx.Unlock()
+				return nil, nil, fmt.Errorf("failed to rewrite variable `%s`: %v", current.Name, err)
+			}
+			if formula == current.Formula {
+				continue
+			}
+
+			if id == variableId {
+				newState.Formula = formula
+			} else {
+				rewritten = append(rewritten, buildVariableState(id, current.Page, current.Name, formula))
+			}
+		}
+	}
+
 	r.varMap[variableId] = newState
+	for _, s := range rewritten {
+		r.varMap[s.Id] = s
+	}
+	r.mx.Unlock()
 
-	return newState, nil
+	r.publish(&VariableEvent{
+		Type:         EventRenamed,
+		State:        newState,
+		PreviousName: state.Name,
+	})
+	for _, s := range rewritten {
+		r.publish(&VariableEvent{
+			Type:  EventUpdated,
+			State: s,
+		})
+	}
+
+	return newState, rewritten, nil
 }
 
 func generateVariableId() (string, error) {
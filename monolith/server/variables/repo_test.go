@@ -1,6 +1,8 @@
 package variables
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/satori/go.uuid"
@@ -8,7 +10,7 @@ import (
 
 func TestVariablesRepo_CreateVariable(t *testing.T) {
 	repo := NewVariablesRepo()
-	pageId, _ := uuid.FromString("5d71c23d-bef4-4ccc-bbbb-12fcf6563dc5")
+	pageId := uuid.Must(uuid.FromString("5d71c23d-bef4-4ccc-bbbb-12fcf6563dc5")).String()
 	state, err := repo.CreateVariable(pageId, "var1", "1234")
 
 	if err != nil {
@@ -26,7 +28,7 @@ func TestVariablesRepo_CreateVariable(t *testing.T) {
 
 func TestVariablesRepo_FindPageVariables(t *testing.T) {
 	repo := NewVariablesRepo()
-	pageId, _ := uuid.FromString("5d71c23d-bef4-4ccc-bbbb-12fcf6563dc5")
+	pageId := uuid.Must(uuid.FromString("5d71c23d-bef4-4ccc-bbbb-12fcf6563dc5")).String()
 	_, err := repo.CreateVariable(pageId, "var1", "22")
 	if err != nil {
 		t.Fatal("unexpected error:", err)
@@ -59,3 +61,205 @@ func TestVariablesRepo_FindPageVariables(t *testing.T) {
 		t.Errorf("expected formula `33`; found `%s`", f)
 	}
 }
+
+func TestVariablesRepo_Subscribe(t *testing.T) {
+	repo := NewVariablesRepo()
+	events, cancel := repo.Subscribe("page1")
+	defer cancel()
+
+	state, err := repo.CreateVariable("page1", "var1", "1")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := repo.UpdateVariable(state.Id, "2"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, _, err := repo.RenameVariable(state.Id, "var2", nil); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	// Changes to other pages aren't delivered.
+	if _, err := repo.CreateVariable("page2", "var1", "3"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expected := []EventType{EventCreated, EventUpdated, EventRenamed}
+	for i, want := range expected {
+		event := <-events
+		if event.Type != want {
+			t.Errorf("event %d: expected type %d; found %d", i, want, event.Type)
+		}
+		if event.State.Id != state.Id {
+			t.Errorf("event %d: unexpected variable %s", i, event.State.Id)
+		}
+	}
+
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event: %+v", event)
+	default:
+	}
+}
+
+func TestVariablesRepo_SubscribeRename(t *testing.T) {
+	repo := NewVariablesRepo()
+	state, err := repo.CreateVariable("page1", "var1", "1")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	events, cancel := repo.Subscribe("page1")
+	defer cancel()
+	if _, _, err := repo.RenameVariable(state.Id, "var2", nil); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	event := <-events
+	if event.PreviousName != "var1" {
+		t.Errorf("expected previous name `var1`; found `%s`", event.PreviousName)
+	}
+	if event.State.Name != "var2" {
+		t.Errorf("expected name `var2`; found `%s`", event.State.Name)
+	}
+}
+
+func TestVariablesRepo_SubscriberFallsBehind(t *testing.T) {
+	repo := NewVariablesRepo()
+	events, cancel := repo.Subscribe("page1")
+	defer cancel()
+
+	state, err := repo.CreateVariable("page1", "var1", "0")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	for i := 0; i < subscriberBufferSize; i++ {
+		if _, err := repo.UpdateVariable(state.Id, "1"); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	count := 0
+	for range events {
+		count++
+	}
+	if count != subscriberBufferSize {
+		t.Errorf("expected %d events before close; found %d", subscriberBufferSize, count)
+	}
+}
+
+func TestVariablesRepo_RenameRewritesReferences(t *testing.T) {
+	repo := NewVariablesRepo()
+	state, err := repo.CreateVariable("page1", "var1", "1")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	dependent, err := repo.CreateVariable("page1", "var2", "SUM(var1, 2)")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := repo.CreateVariable("page1", "var3", "3"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	rewrite := func(formula, oldName, newName string) (string, error) {
+		return strings.Replace(formula, oldName, newName, -1), nil
+	}
+	renamed, rewritten, err := repo.RenameVariable(state.Id, "total", rewrite)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if renamed.Name != "total" {
+		t.Errorf("expected name `total`; found `%s`", renamed.Name)
+	}
+	if n := len(rewritten); n != 1 {
+		t.Fatalf("expected 1 rewritten var; found %d", n)
+	}
+	if rewritten[0].Id != dependent.Id || rewritten[0].Formula != "SUM(total, 2)" {
+		t.Errorf("expected var2 to be rewritten as `SUM(total, 2)`; found %+v", rewritten[0])
+	}
+
+	stored, err := repo.GetVariables([]string{dependent.Id})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if f := stored[0].Formula; f != "SUM(total, 2)" {
+		t.Errorf("expected stored formula `SUM(total, 2)`; found `%s`", f)
+	}
+}
+
+func TestVariablesRepo_RenameIsAtomic(t *testing.T) {
+	repo := NewVariablesRepo()
+	state, err := repo.CreateVariable("page1", "var1", "1")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := repo.CreateVariable("page1", "var2", "var1"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := repo.CreateVariable("page1", "var3", "bad"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	rewrite := func(formula, oldName, newName string) (string, error) {
+		if formula == "bad" {
+			return "", errors.New("cannot rewrite")
+		}
+		return strings.Replace(formula, oldName, newName, -1), nil
+	}
+	if _, _, err := repo.RenameVariable(state.Id, "total", rewrite); err == nil {
+		t.Fatal("expected error")
+	}
+
+	for _, v := range repo.FindPageVariables("page1") {
+		if v.Name == "total" || v.Formula == "total" {
+			t.Errorf("expected no changes to be applied; found %+v", v)
+		}
+	}
+}
+
+func TestVariablesRepo_RenameToExistingName(t *testing.T) {
+	repo := NewVariablesRepo()
+	state, err := repo.CreateVariable("page1", "var1", "1")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if _, err := repo.CreateVariable("page1", "var2", "2"); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if _, _, err := repo.RenameVariable(state.Id, "var2", nil); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestVariablesRepo_SetCell(t *testing.T) {
+	repo := NewVariablesRepo()
+	pageId := uuid.Must(uuid.FromString("5d71c23d-bef4-4ccc-bbbb-12fcf6563dc5")).String()
+	events, cancel := repo.Subscribe(pageId)
+	defer cancel()
+
+	created, err := repo.SetCell(pageId, "b4", "12")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	updated, err := repo.SetCell(pageId, "b4", "SUM(A1:A3)")
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if updated.Id != created.Id {
+		t.Errorf("expected cell to keep id %s; found %s", created.Id, updated.Id)
+	}
+	if f := updated.Formula; f != "SUM(A1:A3)" {
+		t.Errorf("wrong formula: %s; expected: SUM(A1:A3)", f)
+	}
+	if n := len(repo.FindPageVariables(pageId)); n != 1 {
+		t.Errorf("expected 1 var; found %d", n)
+	}
+
+	for _, expected := range []EventType{EventCreated, EventUpdated} {
+		if e := <-events; e.Type != expected {
+			t.Errorf("wrong event type: %v; expected: %v", e.Type, expected)
+		}
+	}
+}
@@ -22,6 +22,34 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type VariableEventType int32
+
+const (
+	VariableEventType_CREATED VariableEventType = 0
+	VariableEventType_UPDATED VariableEventType = 1
+	VariableEventType_RENAMED VariableEventType = 2
+)
+
+var VariableEventType_name = map[int32]string{
+	0: "CREATED",
+	1: "UPDATED",
+	2: "RENAMED",
+}
+
+var VariableEventType_value = map[string]int32{
+	"CREATED": 0,
+	"UPDATED": 1,
+	"RENAMED": 2,
+}
+
+func (x VariableEventType) String() string {
+	return proto.EnumName(VariableEventType_name, int32(x))
+}
+
+func (VariableEventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3b8b958d8129f2ed, []int{0}
+}
+
 type GetVariablesRequest struct {
 	Ids                  []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -349,11 +377,13 @@ func (m *UpdateVariableRequest) GetFormula() string {
 }
 
 type UpdateVariableResponse struct {
-	Error                *Error    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
-	Variable             *Variable `protobuf:"bytes,2,opt,name=variable,proto3" json:"variable,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Error    *Error    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Variable *Variable `protobuf:"bytes,2,opt,name=variable,proto3" json:"variable,omitempty"`
+	// Other variables whose formulas were rewritten to refer to the variable's new name.
+	Rewritten            []*Variable `protobuf:"bytes,3,rep,name=rewritten,proto3" json:"rewritten,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *UpdateVariableResponse) Reset()         { *m = UpdateVariableResponse{} }
@@ -395,7 +425,213 @@ func (m *UpdateVariableResponse) GetVariable() *Variable {
 	return nil
 }
 
+func (m *UpdateVariableResponse) GetRewritten() []*Variable {
+	if m != nil {
+		return m.Rewritten
+	}
+	return nil
+}
+
+type SetCellRequest struct {
+	PageId string `protobuf:"bytes,1,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	// The cell's address, such as `B4`.
+	Cell                 string   `protobuf:"bytes,2,opt,name=cell,proto3" json:"cell,omitempty"`
+	Formula              string   `protobuf:"bytes,3,opt,name=formula,proto3" json:"formula,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetCellRequest) Reset()         { *m = SetCellRequest{} }
+func (m *SetCellRequest) String() string { return proto.CompactTextString(m) }
+func (*SetCellRequest) ProtoMessage()    {}
+func (*SetCellRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b8b958d8129f2ed, []int{8}
+}
+
+func (m *SetCellRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCellRequest.Unmarshal(m, b)
+}
+func (m *SetCellRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCellRequest.Marshal(b, m, deterministic)
+}
+func (m *SetCellRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCellRequest.Merge(m, src)
+}
+func (m *SetCellRequest) XXX_Size() int {
+	return xxx_messageInfo_SetCellRequest.Size(m)
+}
+func (m *SetCellRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCellRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCellRequest proto.InternalMessageInfo
+
+func (m *SetCellRequest) GetPageId() string {
+	if m != nil {
+		return m.PageId
+	}
+	return ""
+}
+
+func (m *SetCellRequest) GetCell() string {
+	if m != nil {
+		return m.Cell
+	}
+	return ""
+}
+
+func (m *SetCellRequest) GetFormula() string {
+	if m != nil {
+		return m.Formula
+	}
+	return ""
+}
+
+type SetCellResponse struct {
+	Error                *Error    `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+	Variable             *Variable `protobuf:"bytes,2,opt,name=variable,proto3" json:"variable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SetCellResponse) Reset()         { *m = SetCellResponse{} }
+func (m *SetCellResponse) String() string { return proto.CompactTextString(m) }
+func (*SetCellResponse) ProtoMessage()    {}
+func (*SetCellResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b8b958d8129f2ed, []int{9}
+}
+
+func (m *SetCellResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCellResponse.Unmarshal(m, b)
+}
+func (m *SetCellResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCellResponse.Marshal(b, m, deterministic)
+}
+func (m *SetCellResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCellResponse.Merge(m, src)
+}
+func (m *SetCellResponse) XXX_Size() int {
+	return xxx_messageInfo_SetCellResponse.Size(m)
+}
+func (m *SetCellResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCellResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCellResponse proto.InternalMessageInfo
+
+func (m *SetCellResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *SetCellResponse) GetVariable() *Variable {
+	if m != nil {
+		return m.Variable
+	}
+	return nil
+}
+
+type WatchVariablesRequest struct {
+	PageId               string   `protobuf:"bytes,1,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchVariablesRequest) Reset()         { *m = WatchVariablesRequest{} }
+func (m *WatchVariablesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchVariablesRequest) ProtoMessage()    {}
+func (*WatchVariablesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b8b958d8129f2ed, []int{10}
+}
+
+func (m *WatchVariablesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchVariablesRequest.Unmarshal(m, b)
+}
+func (m *WatchVariablesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchVariablesRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchVariablesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchVariablesRequest.Merge(m, src)
+}
+func (m *WatchVariablesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchVariablesRequest.Size(m)
+}
+func (m *WatchVariablesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchVariablesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchVariablesRequest proto.InternalMessageInfo
+
+func (m *WatchVariablesRequest) GetPageId() string {
+	if m != nil {
+		return m.PageId
+	}
+	return ""
+}
+
+type VariableEvent struct {
+	Type     VariableEventType `protobuf:"varint,1,opt,name=type,proto3,enum=monolith.VariableEventType" json:"type,omitempty"`
+	Variable *Variable         `protobuf:"bytes,2,opt,name=variable,proto3" json:"variable,omitempty"`
+	// Only set for RENAMED events.
+	PreviousName         string   `protobuf:"bytes,3,opt,name=previous_name,json=previousName,proto3" json:"previous_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VariableEvent) Reset()         { *m = VariableEvent{} }
+func (m *VariableEvent) String() string { return proto.CompactTextString(m) }
+func (*VariableEvent) ProtoMessage()    {}
+func (*VariableEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3b8b958d8129f2ed, []int{11}
+}
+
+func (m *VariableEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VariableEvent.Unmarshal(m, b)
+}
+func (m *VariableEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VariableEvent.Marshal(b, m, deterministic)
+}
+func (m *VariableEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VariableEvent.Merge(m, src)
+}
+func (m *VariableEvent) XXX_Size() int {
+	return xxx_messageInfo_VariableEvent.Size(m)
+}
+func (m *VariableEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_VariableEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VariableEvent proto.InternalMessageInfo
+
+func (m *VariableEvent) GetType() VariableEventType {
+	if m != nil {
+		return m.Type
+	}
+	return VariableEventType_CREATED
+}
+
+func (m *VariableEvent) GetVariable() *Variable {
+	if m != nil {
+		return m.Variable
+	}
+	return nil
+}
+
+func (m *VariableEvent) GetPreviousName() string {
+	if m != nil {
+		return m.PreviousName
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterEnum("monolith.VariableEventType", VariableEventType_name, VariableEventType_value)
 	proto.RegisterType((*GetVariablesRequest)(nil), "monolith.GetVariablesRequest")
 	proto.RegisterType((*GetVariablesResponse)(nil), "monolith.GetVariablesResponse")
 	proto.RegisterType((*FindVariablesRequest)(nil), "monolith.FindVariablesRequest")
@@ -404,36 +640,51 @@ func init() {
 	proto.RegisterType((*CreateVariableResponse)(nil), "monolith.CreateVariableResponse")
 	proto.RegisterType((*UpdateVariableRequest)(nil), "monolith.UpdateVariableRequest")
 	proto.RegisterType((*UpdateVariableResponse)(nil), "monolith.UpdateVariableResponse")
+	proto.RegisterType((*SetCellRequest)(nil), "monolith.SetCellRequest")
+	proto.RegisterType((*SetCellResponse)(nil), "monolith.SetCellResponse")
+	proto.RegisterType((*WatchVariablesRequest)(nil), "monolith.WatchVariablesRequest")
+	proto.RegisterType((*VariableEvent)(nil), "monolith.VariableEvent")
 }
 
 func init() { proto.RegisterFile("variables.proto", fileDescriptor_3b8b958d8129f2ed) }
 
 var fileDescriptor_3b8b958d8129f2ed = []byte{
-	// 371 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x53, 0xdd, 0x4a, 0xf3, 0x40,
-	0x10, 0x6d, 0x93, 0xfe, 0x4e, 0xfb, 0xb5, 0x1f, 0x63, 0xab, 0x21, 0xa0, 0x96, 0x05, 0xb1, 0x78,
-	0x91, 0x8b, 0xfa, 0x06, 0x96, 0x2a, 0x5e, 0x09, 0x81, 0x7a, 0xa9, 0x6c, 0xc9, 0xaa, 0x0b, 0x49,
-	0x36, 0x6e, 0xd2, 0x3e, 0xae, 0xcf, 0x22, 0xd9, 0x34, 0x69, 0xd2, 0x6e, 0x11, 0x04, 0xef, 0x76,
-	0x66, 0xce, 0x9c, 0x39, 0x27, 0x33, 0x81, 0xe1, 0x86, 0x4a, 0x4e, 0x57, 0x3e, 0x8b, 0x9d, 0x48,
-	0x8a, 0x44, 0x60, 0x27, 0x10, 0xa1, 0xf0, 0x79, 0xf2, 0x61, 0xf7, 0x3d, 0x11, 0x50, 0x1e, 0x66,
-	0x79, 0x72, 0x0d, 0x27, 0x0f, 0x2c, 0x79, 0xce, 0xd1, 0x2e, 0xfb, 0x5c, 0xb3, 0x38, 0xc1, 0xff,
-	0x60, 0x72, 0x2f, 0xb6, 0xea, 0x13, 0x73, 0xda, 0x75, 0xd3, 0x27, 0xb9, 0x83, 0x51, 0x15, 0x18,
-	0x47, 0x22, 0x8c, 0x19, 0xde, 0x40, 0x6b, 0x43, 0xfd, 0x35, 0xcb, 0xc0, 0xbd, 0x19, 0x3a, 0xf9,
-	0x24, 0x27, 0x07, 0xbb, 0x5b, 0x04, 0x59, 0xc0, 0xe8, 0x9e, 0x87, 0xde, 0xc1, 0xb4, 0x33, 0x68,
-	0x47, 0xf4, 0x9d, 0xbd, 0x72, 0xcf, 0xaa, 0x4f, 0xea, 0xd3, 0xae, 0xdb, 0x4a, 0xc3, 0x47, 0x0f,
-	0x47, 0xd0, 0x0c, 0x69, 0xc0, 0x62, 0xcb, 0x50, 0x42, 0xb2, 0x80, 0xcc, 0x61, 0xbc, 0x47, 0xf3,
-	0x0b, 0x2d, 0x2f, 0x30, 0x9e, 0x4b, 0x46, 0x13, 0x56, 0x54, 0x7e, 0x12, 0x83, 0xd0, 0x48, 0xe7,
-	0x5b, 0xa6, 0xca, 0xaa, 0x37, 0x5a, 0xd0, 0x7e, 0x13, 0x32, 0x58, 0xfb, 0xd4, 0x6a, 0xa8, 0x74,
-	0x1e, 0x12, 0x01, 0xa7, 0xfb, 0xfc, 0x5b, 0x95, 0x57, 0xd0, 0x64, 0x52, 0x0a, 0xa9, 0xe8, 0x7b,
-	0xb3, 0xe1, 0x4e, 0xe4, 0x22, 0x4d, 0xbb, 0x59, 0x15, 0x1d, 0xe8, 0xe4, 0x4b, 0xb4, 0x0c, 0x85,
-	0xd4, 0xd9, 0x29, 0x30, 0x64, 0x09, 0xe3, 0x65, 0xe4, 0x69, 0x0c, 0x0d, 0xc0, 0x28, 0xbc, 0x18,
-	0x7c, 0xe7, 0xc3, 0xd0, 0xfb, 0x30, 0x0f, 0x7c, 0xec, 0xd3, 0xfe, 0xa9, 0x8f, 0xd9, 0x97, 0x01,
-	0xdd, 0x62, 0xb5, 0xf8, 0x04, 0xfd, 0xf2, 0xd9, 0xe1, 0xf9, 0xae, 0x57, 0x73, 0xb7, 0xf6, 0xc5,
-	0xb1, 0x72, 0xa6, 0x99, 0xd4, 0xd0, 0x85, 0x7f, 0x95, 0xe3, 0xc1, 0x52, 0x8b, 0xee, 0x38, 0xed,
-	0xcb, 0xa3, 0xf5, 0x82, 0x73, 0x09, 0x83, 0xea, 0xae, 0xb1, 0xd4, 0xa4, 0xbd, 0x32, 0x7b, 0x72,
-	0x1c, 0x50, 0xa6, 0xad, 0x7e, 0xfa, 0x32, 0xad, 0x76, 0xd7, 0x65, 0x5a, 0xfd, 0xd6, 0x48, 0x6d,
-	0xd5, 0x52, 0x7f, 0xfe, 0xed, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x63, 0x14, 0x9c, 0xfb, 0x24,
-	0x04, 0x00, 0x00,
+	// 552 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xae, 0xed, 0x34, 0x69, 0x26, 0xbf, 0x2c, 0x49, 0x6b, 0x8c, 0x80, 0xc8, 0x08, 0x51, 0xf5,
+	0x10, 0xa2, 0x70, 0xe3, 0x44, 0x49, 0x0c, 0xe2, 0x40, 0x40, 0xa6, 0xa1, 0x37, 0xaa, 0x6d, 0x3d,
+	0x10, 0x4b, 0x8e, 0xd7, 0xac, 0x37, 0x41, 0x7d, 0x10, 0x1e, 0x80, 0x37, 0xe4, 0x11, 0x90, 0x37,
+	0xb1, 0x63, 0x27, 0x36, 0x14, 0xa4, 0xde, 0x76, 0x66, 0x3e, 0x7f, 0x33, 0x5f, 0xe6, 0x27, 0xd0,
+	0x5a, 0x52, 0xee, 0xd2, 0x4b, 0x0f, 0xc3, 0x7e, 0xc0, 0x99, 0x60, 0xe4, 0x60, 0xce, 0x7c, 0xe6,
+	0xb9, 0x62, 0x66, 0xd4, 0x1d, 0x36, 0xa7, 0xae, 0xbf, 0xf2, 0x9b, 0x4f, 0xe1, 0xee, 0x1b, 0x14,
+	0x9f, 0x62, 0xb4, 0x8d, 0xdf, 0x16, 0x18, 0x0a, 0xd2, 0x06, 0xcd, 0x75, 0x42, 0x5d, 0xe9, 0x69,
+	0xc7, 0x55, 0x3b, 0x7a, 0x9a, 0xaf, 0xa0, 0x93, 0x05, 0x86, 0x01, 0xf3, 0x43, 0x24, 0x27, 0x50,
+	0x5e, 0x52, 0x6f, 0x81, 0x2b, 0x70, 0x6d, 0x48, 0xfa, 0x71, 0xa6, 0x7e, 0x0c, 0xb6, 0xd7, 0x08,
+	0xd3, 0x82, 0xce, 0x6b, 0xd7, 0x77, 0x76, 0xb2, 0x1d, 0x41, 0x25, 0xa0, 0x5f, 0xf1, 0xc2, 0x75,
+	0x74, 0xa5, 0xa7, 0x1c, 0x57, 0xed, 0x72, 0x64, 0xbe, 0x75, 0x48, 0x07, 0xf6, 0x7d, 0x3a, 0xc7,
+	0x50, 0x57, 0x65, 0x21, 0x2b, 0xc3, 0x1c, 0x41, 0x77, 0x8b, 0xe6, 0x3f, 0x6a, 0xf9, 0x0c, 0xdd,
+	0x11, 0x47, 0x2a, 0x30, 0x89, 0xfc, 0xad, 0x18, 0x02, 0xa5, 0x28, 0xbf, 0xae, 0x49, 0xaf, 0x7c,
+	0x13, 0x1d, 0x2a, 0x5f, 0x18, 0x9f, 0x2f, 0x3c, 0xaa, 0x97, 0xa4, 0x3b, 0x36, 0x4d, 0x06, 0x87,
+	0xdb, 0xfc, 0xeb, 0x2a, 0x9f, 0xc0, 0x3e, 0x72, 0xce, 0xb8, 0xa4, 0xaf, 0x0d, 0x5b, 0x9b, 0x22,
+	0xad, 0xc8, 0x6d, 0xaf, 0xa2, 0xa4, 0x0f, 0x07, 0x71, 0x13, 0x75, 0xb5, 0xa7, 0x14, 0xc8, 0x49,
+	0x30, 0xe6, 0x14, 0xba, 0xd3, 0xc0, 0xc9, 0x11, 0xd4, 0x04, 0x35, 0xd1, 0xa2, 0xba, 0x1b, 0x1d,
+	0x6a, 0xbe, 0x0e, 0x2d, 0xab, 0xe3, 0xa7, 0x02, 0x87, 0xdb, 0xbc, 0xb7, 0x2a, 0x84, 0x0c, 0xa0,
+	0xca, 0xf1, 0x3b, 0x77, 0x85, 0x40, 0x5f, 0xd7, 0x0a, 0x1b, 0xb9, 0x01, 0x99, 0xe7, 0xd0, 0xfc,
+	0x88, 0x62, 0x84, 0x9e, 0x77, 0x93, 0x26, 0x5e, 0xa1, 0xe7, 0xc5, 0xe2, 0xa3, 0xf7, 0x1f, 0xc4,
+	0xcf, 0xa0, 0x95, 0x10, 0xdf, 0x6e, 0xf7, 0x06, 0xd0, 0x3d, 0xa7, 0xe2, 0x6a, 0x76, 0xe3, 0xdd,
+	0x30, 0x7f, 0x28, 0xd0, 0x88, 0xd1, 0xd6, 0x12, 0x7d, 0x41, 0x9e, 0x41, 0x49, 0x5c, 0x07, 0x28,
+	0x71, 0xcd, 0xe1, 0xfd, 0xdd, 0x7c, 0x12, 0x76, 0x76, 0x1d, 0xa0, 0x2d, 0x81, 0xff, 0xdc, 0x99,
+	0xc7, 0xd0, 0x08, 0x38, 0x2e, 0x5d, 0xb6, 0x08, 0x2f, 0x52, 0xab, 0x50, 0x8f, 0x9d, 0x13, 0x3a,
+	0xc7, 0x93, 0x17, 0x70, 0x67, 0x27, 0x1f, 0xa9, 0x41, 0x65, 0x64, 0x5b, 0xa7, 0x67, 0xd6, 0xb8,
+	0xbd, 0x17, 0x19, 0xd3, 0x0f, 0x63, 0x69, 0x28, 0x91, 0x61, 0x5b, 0x93, 0xd3, 0x77, 0xd6, 0xb8,
+	0xad, 0x0e, 0x7f, 0x69, 0x50, 0x4d, 0x7e, 0x01, 0xf2, 0x1e, 0xea, 0xe9, 0x93, 0x43, 0x1e, 0x6c,
+	0x8a, 0xcb, 0xb9, 0x59, 0xc6, 0xc3, 0xa2, 0xf0, 0xaa, 0x73, 0xe6, 0x1e, 0xb1, 0xa1, 0x91, 0x39,
+	0x1c, 0x24, 0xf5, 0x49, 0xde, 0x61, 0x32, 0x1e, 0x15, 0xc6, 0x13, 0xce, 0x29, 0x34, 0xb3, 0x7b,
+	0x4e, 0x52, 0x1f, 0xe5, 0x5e, 0x18, 0xa3, 0x57, 0x0c, 0x48, 0xd3, 0x66, 0xb7, 0x2e, 0x4d, 0x9b,
+	0xbb, 0xe7, 0x46, 0xaf, 0x18, 0x90, 0xd0, 0xbe, 0x84, 0xca, 0x7a, 0xa0, 0x89, 0xbe, 0x81, 0x67,
+	0x97, 0xc7, 0xb8, 0x97, 0x13, 0x49, 0x18, 0x26, 0xd0, 0xcc, 0x0e, 0x6a, 0xba, 0xb0, 0xdc, 0x11,
+	0x36, 0x8e, 0x0a, 0x26, 0xd1, 0xdc, 0x1b, 0x28, 0x97, 0x65, 0xf9, 0x3f, 0xf4, 0xfc, 0xf7, 0x00,
+	0x7d, 0x93, 0x93, 0xd5, 0xb2, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -452,6 +703,8 @@ type VariablesClient interface {
 	FindVariables(ctx context.Context, in *FindVariablesRequest, opts ...grpc.CallOption) (*FindVariablesResponse, error)
 	CreateVariable(ctx context.Context, in *CreateVariableRequest, opts ...grpc.CallOption) (*CreateVariableResponse, error)
 	UpdateVariable(ctx context.Context, in *UpdateVariableRequest, opts ...grpc.CallOption) (*UpdateVariableResponse, error)
+	SetCell(ctx context.Context, in *SetCellRequest, opts ...grpc.CallOption) (*SetCellResponse, error)
+	WatchVariables(ctx context.Context, in *WatchVariablesRequest, opts ...grpc.CallOption) (Variables_WatchVariablesClient, error)
 }
 
 type variablesClient struct {
@@ -498,12 +751,55 @@ func (c *variablesClient) UpdateVariable(ctx context.Context, in *UpdateVariable
 	return out, nil
 }
 
+func (c *variablesClient) SetCell(ctx context.Context, in *SetCellRequest, opts ...grpc.CallOption) (*SetCellResponse, error) {
+	out := new(SetCellResponse)
+	err := c.cc.Invoke(ctx, "/monolith.Variables/SetCell", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *variablesClient) WatchVariables(ctx context.Context, in *WatchVariablesRequest, opts ...grpc.CallOption) (Variables_WatchVariablesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Variables_serviceDesc.Streams[0], "/monolith.Variables/WatchVariables", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &variablesWatchVariablesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Variables_WatchVariablesClient interface {
+	Recv() (*VariableEvent, error)
+	grpc.ClientStream
+}
+
+type variablesWatchVariablesClient struct {
+	grpc.ClientStream
+}
+
+func (x *variablesWatchVariablesClient) Recv() (*VariableEvent, error) {
+	m := new(VariableEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // VariablesServer is the server API for Variables service.
 type VariablesServer interface {
 	GetVariables(context.Context, *GetVariablesRequest) (*GetVariablesResponse, error)
 	FindVariables(context.Context, *FindVariablesRequest) (*FindVariablesResponse, error)
 	CreateVariable(context.Context, *CreateVariableRequest) (*CreateVariableResponse, error)
 	UpdateVariable(context.Context, *UpdateVariableRequest) (*UpdateVariableResponse, error)
+	SetCell(context.Context, *SetCellRequest) (*SetCellResponse, error)
+	WatchVariables(*WatchVariablesRequest, Variables_WatchVariablesServer) error
 }
 
 func RegisterVariablesServer(s *grpc.Server, srv VariablesServer) {
@@ -582,6 +878,45 @@ func _Variables_UpdateVariable_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Variables_SetCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCellRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VariablesServer).SetCell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/monolith.Variables/SetCell",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VariablesServer).SetCell(ctx, req.(*SetCellRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Variables_WatchVariables_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchVariablesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VariablesServer).WatchVariables(m, &variablesWatchVariablesServer{stream})
+}
+
+type Variables_WatchVariablesServer interface {
+	Send(*VariableEvent) error
+	grpc.ServerStream
+}
+
+type variablesWatchVariablesServer struct {
+	grpc.ServerStream
+}
+
+func (x *variablesWatchVariablesServer) Send(m *VariableEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Variables_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "monolith.Variables",
 	HandlerType: (*VariablesServer)(nil),
@@ -602,7 +937,17 @@ var _Variables_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateVariable",
 			Handler:    _Variables_UpdateVariable_Handler,
 		},
+		{
+			MethodName: "SetCell",
+			Handler:    _Variables_SetCell_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchVariables",
+			Handler:       _Variables_WatchVariables_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "variables.proto",
 }
@@ -0,0 +1,92 @@
+package parsing
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Grids are limited to the same size as most spreadsheets: columns A to XFD and a million or so rows.
+	maxGridColumns = 16384
+	maxGridRows    = 1048576
+	// Larger ranges would mean looking up an unreasonable number of cells.
+	MaxRangeCells = 10000
+)
+
+// Cell addresses are uppercase so that lowercase names like `var1` remain ordinary identifiers.
+var reCell = regexp.MustCompile("^([A-Z]{1,3})([1-9][0-9]*)$")
+
+// ParseCell returns the zero-based column and row of a cell address such as `B12`. It reports false for anything
+// which isn't an address within the grid.
+func ParseCell(address string) (int, int, bool) {
+	m := reCell.FindStringSubmatch(address)
+	if m == nil {
+		return 0, 0, false
+	}
+
+	col := 0
+	for _, ch := range m[1] {
+		col = col*26 + int(ch-'A') + 1
+	}
+	row, err := strconv.Atoi(m[2])
+	if err != nil || col > maxGridColumns || row > maxGridRows {
+		return 0, 0, false
+	}
+
+	return col - 1, row - 1, true
+}
+
+// NormaliseName returns the name a variable is stored and looked up by. Other names are case-insensitive and
+// lowercased, but cell addresses are kept in uppercase so that a cell such as `Q1` and a variable named `q1` remain
+// distinct.
+func NormaliseName(name string) string {
+	if _, _, ok := ParseCell(name); ok {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// CellName returns the address of the cell at a zero-based column and row.
+func CellName(col, row int) string {
+	var letters []byte
+	for n := col + 1; n > 0; n = (n - 1) / 26 {
+		letters = append([]byte{byte('A' + (n-1)%26)}, letters...)
+	}
+
+	return string(letters) + strconv.Itoa(row+1)
+}
+
+// ExpandRange lists the addresses of the cells in a range, row by row. The corners may be given in any order.
+func ExpandRange(from, to string) ([][]string, error) {
+	fromCol, fromRow, ok := ParseCell(from)
+	if !ok {
+		return nil, fmt.Errorf("`%s` is not a cell address", from)
+	}
+	toCol, toRow, ok := ParseCell(to)
+	if !ok {
+		return nil, fmt.Errorf("`%s` is not a cell address", to)
+	}
+	if fromCol > toCol {
+		fromCol, toCol = toCol, fromCol
+	}
+	if fromRow > toRow {
+		fromRow, toRow = toRow, fromRow
+	}
+
+	width, height := toCol-fromCol+1, toRow-fromRow+1
+	if width*height > MaxRangeCells {
+		return nil, fmt.Errorf("range %s:%s has %d cells; ranges are limited to %d", from, to, width*height, MaxRangeCells)
+	}
+
+	rows := make([][]string, height)
+	for r := range rows {
+		rows[r] = make([]string, width)
+		for c := range rows[r] {
+			rows[r][c] = CellName(fromCol+c, fromRow+r)
+		}
+	}
+
+	return rows, nil
+}
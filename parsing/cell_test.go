@@ -0,0 +1,65 @@
+package parsing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCell(t *testing.T) {
+	cases := map[string][2]int{
+		"A1":         {0, 0},
+		"B12":        {1, 11},
+		"Z3":         {25, 2},
+		"AA1":        {26, 0},
+		"XFD1048576": {16383, 1048575},
+	}
+
+	for address, expected := range cases {
+		col, row, ok := ParseCell(address)
+		if !ok || col != expected[0] || row != expected[1] {
+			t.Errorf("Expected %s to be column %d, row %d; got %d, %d, %v", address, expected[0], expected[1], col, row, ok)
+		}
+		if name := CellName(col, row); name != address {
+			t.Errorf("Expected column %d, row %d to be named %s; got %s", col, row, address, name)
+		}
+	}
+
+	for _, address := range []string{"a1", "A0", "A", "1", "XFE1", "A1048577", "A1B"} {
+		if _, _, ok := ParseCell(address); ok {
+			t.Errorf("Expected %q not to be a cell address", address)
+		}
+	}
+}
+
+func TestNormaliseName(t *testing.T) {
+	cases := map[string]string{
+		"Total":  "total",
+		"B12":    "B12",
+		"q1":     "q1",
+		"Fy2024": "fy2024",
+		"FY2024": "FY2024",
+		"abc123": "abc123",
+	}
+
+	for name, expected := range cases {
+		if actual := NormaliseName(name); actual != expected {
+			t.Errorf("Expected %q to be normalised to %q; got %q", name, expected, actual)
+		}
+	}
+}
+
+func TestExpandRange(t *testing.T) {
+	rows, err := ExpandRange("C3", "B1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := [][]string{{"B1", "C1"}, {"B2", "C2"}, {"B3", "C3"}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected %v; got %v", expected, rows)
+	}
+
+	if _, err := ExpandRange("A1", "Z1000"); err == nil {
+		t.Error("Expected an error for a range over the size limit")
+	}
+}
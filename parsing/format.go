@@ -0,0 +1,209 @@
+package parsing
+
+import (
+	"strings"
+)
+
+const (
+	// Lines are wrapped once they would grow longer than this.
+	formatLineWidth = 80
+	formatIndent    = "  "
+)
+
+// Format prints the AST as canonical source. Anything which doesn't fit on a single line is broken up, one element
+// per line. Parsing the output produces an AST equivalent to the one formatted.
+func Format(n *ASTNode) string {
+	if n == nil {
+		return ""
+	}
+
+	return format(n, 0, 0)
+}
+
+// format prints n with wrapped lines indented by the given depth. column is the position on the current line at
+// which n starts.
+func format(n *ASTNode, depth int, column int) string {
+	flat := formatFlat(n)
+	if column+len(flat) <= formatLineWidth && !strings.Contains(flat, "\n") {
+		return flat
+	}
+
+	switch {
+	case n.ApplicationVal != nil:
+		exp := format(n.ApplicationVal.Expression, depth, column)
+		return exp + formatGroup("(", ")", formatElements(n.ApplicationVal.Argument), depth)
+	case n.LambdaVal != nil:
+		// Only the body is wrapped unless the parameters don't fit on the line by themselves.
+		params := formatFlatTuple(n.LambdaVal.FreeVariables) + " => "
+		if column+len(params) > formatLineWidth || strings.Contains(params, "\n") {
+			params = formatGroup("(", ")", formatElements(n.LambdaVal.FreeVariables), depth) + " => "
+		}
+		return params + format(n.LambdaVal.Expression, depth, lastLineWidth(params, column))
+	case n.ListVal != nil:
+		return formatGroup("[", "]", n.ListVal.Elements, depth)
+	case n.NamedVal != nil:
+		prefix := n.NamedVal.Name + " = "
+		return prefix + format(n.NamedVal.Value, depth, column+len(prefix))
+	case n.PipeVal != nil:
+		return formatPipe(n.PipeVal, depth, column)
+	case n.RecordVal != nil:
+		return formatRecord(n.RecordVal, depth)
+	case n.SpreadVal != nil:
+		return "..." + format(n.SpreadVal, depth, column+3)
+	case n.TupleVal != nil:
+		return formatGroup("(", ")", n.TupleVal.Elements, depth)
+	default:
+		return flat
+	}
+}
+
+// formatGroup prints the elements between open and close, each on its own line.
+func formatGroup(open, close string, elements []*ASTNode, depth int) string {
+	if len(elements) == 0 {
+		return open + close
+	}
+
+	indent := strings.Repeat(formatIndent, depth+1)
+	lines := make([]string, len(elements))
+	for i, el := range elements {
+		lines[i] = indent + format(el, depth+1, len(indent))
+	}
+
+	return open + "\n" + strings.Join(lines, ",\n") + "\n" + strings.Repeat(formatIndent, depth) + close
+}
+
+// formatPipe prints each stage of a pipeline on its own line.
+func formatPipe(pipe *Pipe, depth int, column int) string {
+	stages := []*ASTNode{pipe.Function}
+	first := pipe.Value
+	for first.PipeVal != nil {
+		stages = append([]*ASTNode{first.PipeVal.Function}, stages...)
+		first = first.PipeVal.Value
+	}
+
+	prefix := "\n" + strings.Repeat(formatIndent, depth+1) + "|> "
+	out := format(pipeOperand(first), depth, column)
+	for _, stage := range stages {
+		out += prefix + format(pipeOperand(stage), depth+1, len(prefix)-1)
+	}
+
+	return out
+}
+
+// pipeOperand wraps lambdas in parentheses since their bodies would otherwise take in the rest of the pipeline.
+func pipeOperand(n *ASTNode) *ASTNode {
+	if n.LambdaVal == nil {
+		return n
+	}
+
+	return &ASTNode{
+		TupleVal: &Tuple{
+			Elements: []*ASTNode{n},
+		},
+	}
+}
+
+func formatRecord(r *Record, depth int) string {
+	if len(r.Properties) == 0 {
+		return "{}"
+	}
+
+	indent := strings.Repeat(formatIndent, depth+1)
+	lines := make([]string, len(r.Properties))
+	for i, prop := range r.Properties {
+		prefix := indent + propertyPrefix(prop)
+		lines[i] = prefix + format(prop.Value, depth+1, len(prefix))
+	}
+
+	return "{\n" + strings.Join(lines, ",\n") + "\n" + strings.Repeat(formatIndent, depth) + "}"
+}
+
+func formatFlat(n *ASTNode) string {
+	switch {
+	case n == nil:
+		return ""
+	case n.ApplicationVal != nil:
+		return formatFlat(n.ApplicationVal.Expression) + formatFlatTuple(n.ApplicationVal.Argument)
+	case n.BooleanVal != nil:
+		if *n.BooleanVal {
+			return "true"
+		}
+		return "false"
+	case n.LambdaVal != nil:
+		return formatFlatTuple(n.LambdaVal.FreeVariables) + " => " + formatFlat(n.LambdaVal.Expression)
+	case n.ListVal != nil:
+		return "[" + formatFlatElements(n.ListVal.Elements) + "]"
+	case n.NamedVal != nil:
+		return n.NamedVal.Name + " = " + formatFlat(n.NamedVal.Value)
+	case n.NumberVal != nil:
+		return *n.NumberVal
+	case n.PipeVal != nil:
+		return formatFlat(pipeOperand(n.PipeVal.Value)) + " |> " + formatFlat(pipeOperand(n.PipeVal.Function))
+	case n.QuantityVal != nil:
+		return n.QuantityVal.Number + " " + n.QuantityVal.Unit
+	case n.RangeVal != nil:
+		return n.RangeVal.From + ":" + n.RangeVal.To
+	case n.RecordVal != nil:
+		props := make([]string, len(n.RecordVal.Properties))
+		for i, prop := range n.RecordVal.Properties {
+			props[i] = propertyPrefix(prop) + formatFlat(prop.Value)
+		}
+		return "{" + strings.Join(props, ", ") + "}"
+	case n.SpreadVal != nil:
+		return "..." + formatFlat(n.SpreadVal)
+	case n.StringVal != nil:
+		return quoteString(*n.StringVal)
+	case n.TupleVal != nil:
+		return formatFlatTuple(n.TupleVal)
+	case n.VariableVal != nil:
+		return *n.VariableVal
+	default:
+		return ""
+	}
+}
+
+func formatFlatTuple(t *Tuple) string {
+	return "(" + formatFlatElements(formatElements(t)) + ")"
+}
+
+func formatFlatElements(elements []*ASTNode) string {
+	out := make([]string, len(elements))
+	for i, el := range elements {
+		out[i] = formatFlat(el)
+	}
+
+	return strings.Join(out, ", ")
+}
+
+func formatElements(t *Tuple) []*ASTNode {
+	if t == nil {
+		return nil
+	}
+
+	return t.Elements
+}
+
+func propertyPrefix(prop *RecordProperty) string {
+	if prop.Name == "" {
+		// Spread properties print as their value alone.
+		return ""
+	}
+
+	return prop.Name + " = "
+}
+
+// quoteString escapes the characters which the lexer would otherwise treat specially.
+func quoteString(s string) string {
+	s = strings.Replace(s, "\\", "\\\\", -1)
+	s = strings.Replace(s, "\"", "\\\"", -1)
+	return "\"" + s + "\""
+}
+
+// lastLineWidth returns the column reached after printing s from the given starting column.
+func lastLineWidth(s string, column int) int {
+	if i := strings.LastIndex(s, "\n"); i >= 0 {
+		return len(s) - i - 1
+	}
+
+	return column + len(s)
+}
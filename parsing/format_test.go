@@ -0,0 +1,141 @@
+package parsing
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormat_Canonical(t *testing.T) {
+	cases := map[string]string{
+		"":                                  "",
+		"SUM( 4,PRODUCT(3 ,2) )":            "SUM(4, PRODUCT(3, 2))",
+		"-33.392":                           "-33.392",
+		"TRUE":                              "true",
+		`"say \"hi\" \\ bye"`:               `"say \"hi\" \\ bye"`,
+		"[ ]":                               "[]",
+		"{a=1,b=[2,3]}":                     "{a = 1, b = [2, 3]}",
+		"(x,y)=>SUM(x,y)":                   "(x, y) => SUM(x, y)",
+		"MAP([1, 2], (x) => x)(1)":          "MAP([1, 2], (x) => x)(1)",
+		"((x) => x)(1)":                     "((x) => x)(1)",
+		"{ outer = { inner = \"value\" } }": "{outer = {inner = \"value\"}}",
+		"xs|>F( 1 )|>G":                     "xs |> F(1) |> G",
+		"xs |> (x) => x |> G":               "xs |> ((x) => x |> G)",
+		"[5km, 9.8 m / s^2]":                "[5 km, 9.8 m/s^2]",
+		"SUM( A1 ,B2:D10 )":                 "SUM(A1, B2:D10)",
+	}
+
+	for input, expected := range cases {
+		ast, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %s", input, err)
+		}
+
+		if actual := Format(ast); actual != expected {
+			t.Errorf("Expected %q to format as %q; got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestFormat_Wrapping(t *testing.T) {
+	input := `CONCATENATE("the quick brown fox", "jumps over the lazy dog", LIST({name = "a very long record property"}))`
+	expected := `CONCATENATE(
+  "the quick brown fox",
+  "jumps over the lazy dog",
+  LIST({name = "a very long record property"})
+)`
+
+	ast, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	actual := Format(ast)
+	if actual != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, actual)
+	}
+
+	for _, line := range strings.Split(actual, "\n") {
+		if len(line) > formatLineWidth {
+			t.Errorf("Line exceeds %d characters: %q", formatLineWidth, line)
+		}
+	}
+}
+
+func TestFormat_WrappingPipe(t *testing.T) {
+	input := `prices |> MAP((price) => ROUND(price, digits = 2)) |> FILTER((price) => GREATER(price, 100)) |> SUM`
+	expected := `prices
+  |> MAP((price) => ROUND(price, digits = 2))
+  |> FILTER((price) => GREATER(price, 100))
+  |> SUM`
+
+	ast, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if actual := Format(ast); actual != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+func TestFormat_WrappingLambda(t *testing.T) {
+	input := `{total = (x) => SUM(x, "the quick brown fox jumps", "over the lazy dog", "and runs away again")}`
+	expected := `{
+  total = (x) => SUM(
+    x,
+    "the quick brown fox jumps",
+    "over the lazy dog",
+    "and runs away again"
+  )
+}`
+
+	ast, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if actual := Format(ast); actual != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	inputs := []string{
+		"SUM(4, PRODUCT(3, 2))",
+		`IF(EQUAL(x, 1), "one", "other")`,
+		`{name = "Toby", tags = ["a", "b"], nested = {deeper = {deepest = [1, 2, 3, 4, 5, 6, 7, 8, 9, 10]}}}`,
+		`MAP(LIST(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), (element, index) => CONCATENATE("element number ", index))`,
+		`((a, b) => {first = a, second = b, description = "a record built by a lambda with a long body"})(1, 2)`,
+		`["a string with \"quotes\" and a \\ backslash", -0.5, true, false, ()]`,
+		"F(1)(2)(3)",
+		"[...xs, 4, ...LIST(5, 6)]",
+		"{...base, rate = 0.2, ...{nested = [...a]}}",
+		"(first, ...rest) => CONCATENATE(first, ...rest)",
+		"(x, digits = SUM(1, 1)) => ROUND(x, digits = digits)",
+		`prices |> MAP((price) => ROUND(price, digits = 2)) |> FILTER((price) => GREATER(price, 100)) |> SUM`,
+		"xs |> (F(1)) |> ((x) => x)(2)",
+		"CONVERT(UNIT(3, \"h\"), \"min\") |> ADD(20 kg*m/s^2)",
+	}
+
+	for _, input := range inputs {
+		expected, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %s", input, err)
+		}
+
+		formatted := Format(expected)
+		actual, err := Parse(formatted)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing formatted %q: %s", formatted, err)
+		}
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf("Formatting %q changed its meaning; formatted as:\n%s", input, formatted)
+		}
+
+		if again := Format(actual); again != formatted {
+			t.Errorf("Expected formatting to be stable; got:\n%s\nthen:\n%s", formatted, again)
+		}
+	}
+}
@@ -32,6 +32,29 @@ func (is *InputStream) peek() rune {
 	return is.input[is.pos]
 }
 
+// peekAt returns the rune n places past the current one, or 0 past the end of the input.
+func (is *InputStream) peekAt(n int) rune {
+	if is.pos+n >= len(is.input) {
+		return 0
+	}
+	return is.input[is.pos+n]
+}
+
 func (is *InputStream) eof() bool {
 	return is.pos >= len(is.input)
 }
+
+// lookahead reports whether the input continues with s.
+func (is *InputStream) lookahead(s string) bool {
+	rs := []rune(s)
+	if is.pos+len(rs) > len(is.input) {
+		return false
+	}
+	for i, r := range rs {
+		if is.input[is.pos+i] != r {
+			return false
+		}
+	}
+
+	return true
+}
@@ -13,6 +13,10 @@ const (
 	//tokenOperator
 	tokenKeyword
 	tokenIdentifier
+	// A grid cell address, such as `B2`.
+	tokenCell
+	// A rectangular range of grid cells, such as `B2:D10`.
+	tokenRange
 	tokenInvalid
 )
 
@@ -27,15 +31,36 @@ type Lexer struct {
 	input   *InputStream
 	pos     int
 	current *Token
+	// Where each token read so far appears in the input.
+	spans map[*Token]span
+}
+
+// span is the position of a token in the input, in runes, from start up to but not including end.
+type span struct {
+	start int
+	end   int
 }
 
 func NewLexer(input *InputStream) *Lexer {
 	return &Lexer{
 		input: input,
 		pos:   0,
+		spans: make(map[*Token]span),
 	}
 }
 
+// scan reads the next token and records where it appears in the input.
+func (l *Lexer) scan() *Token {
+	l.readWhile(isWhitespace)
+	start := l.input.pos
+	tok := l.readNext()
+	if tok != nil {
+		l.spans[tok] = span{start: start, end: l.input.pos}
+	}
+
+	return tok
+}
+
 func (l *Lexer) readNext() *Token {
 	// Consume and ignore whitespace
 	l.readWhile(func(r rune) bool {
@@ -52,11 +77,24 @@ func (l *Lexer) readNext() *Token {
 	if ch == '-' || isDigit(ch) {
 		return l.readNumber()
 	}
+	// Special cases, detect `...` and `|>`
+	for _, symbol := range []string{"...", "|>"} {
+		if !l.input.lookahead(symbol) {
+			continue
+		}
+		for range symbol {
+			l.input.next()
+		}
+		return &Token{
+			Type:  tokenPunctuation,
+			Value: symbol,
+		}
+	}
 	if isPunctuation(ch) {
 		symbol := l.input.next()
 
 		// Special case, detect `=>`
-		if symbol == '=' && l.input.peek() == '>' {
+		if symbol == '=' && l.input.lookahead(">") {
 			l.input.next()
 			return &Token{
 				Type:  tokenPunctuation,
@@ -138,6 +176,17 @@ func (l *Lexer) readNumber() *Token {
 			break
 		}
 	}
+	// An exponent, such as in `1e5`, is part of the number rather than a unit. Anything else after an `e` is left for
+	// a unit.
+	if ch := l.input.peekAt(0); ch == 'e' || ch == 'E' {
+		sign := l.input.peekAt(1)
+		if isDigit(sign) || ((sign == '+' || sign == '-') && isDigit(l.input.peekAt(2))) {
+			str = append(str, l.input.next(), l.input.next())
+			for !l.input.eof() && isDigit(l.input.peek()) {
+				str = append(str, l.input.next())
+			}
+		}
+	}
 
 	return &Token{
 		Type:  tokenNumber,
@@ -165,10 +214,33 @@ func (l *Lexer) readIdentifierOrKeyword() *Token {
 	if isKeyword(value) {
 		tok.Type = tokenKeyword
 	}
+	if _, _, ok := ParseCell(value); ok {
+		tok.Type = tokenCell
+		if !l.input.eof() && l.input.peek() == ':' {
+			return l.readRange(value)
+		}
+	}
 
 	return tok
 }
 
+// readRange reads the rest of a range whose first corner has already been read.
+func (l *Lexer) readRange(from string) *Token {
+	l.input.next()
+	to := l.readWhile(isIdent)
+	if _, _, ok := ParseCell(to); !ok {
+		return &Token{
+			Type:  tokenInvalid,
+			Value: from + ":" + to,
+		}
+	}
+
+	return &Token{
+		Type:  tokenRange,
+		Value: from + ":" + to,
+	}
+}
+
 func isKeyword(identifier string) bool {
 	normal := strings.ToLower(identifier)
 	return strings.Contains(keywords, " "+normal+" ")
@@ -181,7 +253,7 @@ func (l *Lexer) Next() *Token {
 		return tmp
 	}
 
-	return l.readNext()
+	return l.scan()
 }
 
 func (l *Lexer) Peek() *Token {
@@ -189,7 +261,7 @@ func (l *Lexer) Peek() *Token {
 		return l.current
 	}
 
-	l.current = l.readNext()
+	l.current = l.scan()
 	return l.current
 }
 
@@ -206,7 +278,7 @@ func isDigit(ch rune) bool {
 }
 
 func isPunctuation(ch rune) bool {
-	return strings.ContainsRune("(){}[],=>", ch)
+	return strings.ContainsRune("(){}[],=>*/^", ch)
 }
 
 func isIdentStart(ch rune) bool {
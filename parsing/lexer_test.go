@@ -64,6 +64,26 @@ func TestLexer_negativeNumber(t *testing.T) {
 	}
 }
 
+func TestLexer_exponent(t *testing.T) {
+	cases := map[string]string{
+		"1e5":     "1e5",
+		"2.5E-3":  "2.5E-3",
+		"-4e+2":   "-4e+2",
+		"3 em":    "3",
+		"7eggs":   "7",
+		"8e-kg":   "8",
+		"1e5 km":  "1e5",
+		"6.02e23": "6.02e23",
+	}
+
+	for input, expected := range cases {
+		lex := NewLexer(NewInputStream(input))
+		if tok := lex.Next(); tok.Type != tokenNumber || tok.Value != expected {
+			t.Errorf("Expected %q to start with the number %q; got %v %q", input, expected, tok.Type, tok.Value)
+		}
+	}
+}
+
 func TestLexer_keywordTrue(t *testing.T) {
 	input := "True"
 	lex := NewLexer(NewInputStream(input))
@@ -155,3 +175,26 @@ func TestLexer(t *testing.T) {
 		t.Error("Expected ", nil, "; got", tok.Type, tok.Value)
 	}
 }
+
+func TestLexer_cells(t *testing.T) {
+	cases := []struct {
+		input     string
+		tokenType TokenType
+		value     string
+	}{
+		{"A1", tokenCell, "A1"},
+		{"XFD1048576", tokenCell, "XFD1048576"},
+		{"B2:D10", tokenRange, "B2:D10"},
+		{"var1", tokenIdentifier, "var1"},
+		{"A01", tokenIdentifier, "A01"},
+		{"ABCD1", tokenIdentifier, "ABCD1"},
+		{"B2:total", tokenInvalid, "B2:total"},
+	}
+
+	for _, c := range cases {
+		lex := NewLexer(NewInputStream(c.input))
+		if tok := lex.Next(); tok.Type != c.tokenType || tok.Value != c.value {
+			t.Error("Expected ", c.tokenType, c.value, "; got", tok.Type, tok.Value)
+		}
+	}
+}
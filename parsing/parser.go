@@ -9,6 +9,8 @@ import (
 // Reference: http://lisperator.net/pltut/parser/token-stream
 type Parser struct {
 	l *Lexer
+	// Where each variable reference appears in the input, keyed by the node's VariableVal.
+	refs map[*string]span
 }
 
 func NewParser(l *Lexer) *Parser {
@@ -41,7 +43,43 @@ func (p *Parser) parseEntity() (*ASTNode, error) {
 	return p.maybeParseApplication(entity)
 }
 
+// maybeParseApplication checks for calls to entity, along with any pipelines which feed it into later calls.
 func (p *Parser) maybeParseApplication(entity *ASTNode) (*ASTNode, error) {
+	entity, err := p.parseCalls(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		n := p.l.Peek()
+		if n == nil || n.Type != tokenPunctuation || n.Value != "|>" {
+			return entity, nil
+		}
+		p.l.Next()
+
+		// Pipelines are left associative so only calls bind more tightly than the next `|>`.
+		target, err := p.parseImmediateEntity()
+		if err != nil {
+			return nil, err
+		}
+		if target == nil {
+			return nil, errors.New("unexpected end of input")
+		}
+		target, err = p.parseCalls(target)
+		if err != nil {
+			return nil, err
+		}
+
+		entity = &ASTNode{
+			PipeVal: &Pipe{
+				Value:    entity,
+				Function: target,
+			},
+		}
+	}
+}
+
+func (p *Parser) parseCalls(entity *ASTNode) (*ASTNode, error) {
 	n := p.l.Peek()
 	if n == nil || n.Type != tokenPunctuation || n.Value != "(" {
 		return entity, nil
@@ -52,7 +90,7 @@ func (p *Parser) maybeParseApplication(entity *ASTNode) (*ASTNode, error) {
 		return nil, err
 	}
 
-	return p.maybeParseApplication(&ASTNode{
+	return p.parseCalls(&ASTNode{
 		ApplicationVal: &Application{
 			Expression: entity,
 			Argument:   t,
@@ -60,6 +98,34 @@ func (p *Parser) maybeParseApplication(entity *ASTNode) (*ASTNode, error) {
 	})
 }
 
+// parseUnit reads the unit following a number, such as `km` or `kg*m/s^2`.
+func (p *Parser) parseUnit() (string, error) {
+	var unit string
+	for {
+		symbol := p.l.Next()
+		if symbol == nil || symbol.Type != tokenIdentifier {
+			return "", fmt.Errorf("expected a unit; got %+v", symbol)
+		}
+		unit += symbol.Value
+
+		next := p.l.Peek()
+		if next != nil && next.Type == tokenPunctuation && next.Value == "^" {
+			p.l.Next()
+			power := p.l.Next()
+			if power == nil || power.Type != tokenNumber {
+				return "", fmt.Errorf("expected a power; got %+v", power)
+			}
+			unit += "^" + power.Value
+			next = p.l.Peek()
+		}
+
+		if next == nil || next.Type != tokenPunctuation || (next.Value != "*" && next.Value != "/") {
+			return unit, nil
+		}
+		unit += p.l.Next().Value
+	}
+}
+
 func (p *Parser) parseImmediateEntity() (*ASTNode, error) {
 	tok := p.l.Peek()
 	if tok == nil {
@@ -69,6 +135,18 @@ func (p *Parser) parseImmediateEntity() (*ASTNode, error) {
 	switch tok.Type {
 	case tokenNumber:
 		p.l.Next()
+		if next := p.l.Peek(); next != nil && next.Type == tokenIdentifier {
+			unit, err := p.parseUnit()
+			if err != nil {
+				return nil, err
+			}
+			return &ASTNode{
+				QuantityVal: &Quantity{
+					Number: tok.Value,
+					Unit:   unit,
+				},
+			}, nil
+		}
 		return &ASTNode{
 			NumberVal: &tok.Value,
 		}, nil
@@ -87,6 +165,18 @@ func (p *Parser) parseImmediateEntity() (*ASTNode, error) {
 			return &ASTNode{
 				RecordVal: rec,
 			}, nil
+		case "...":
+			p.l.Next()
+			value, err := p.parseEntity()
+			if err != nil {
+				return nil, err
+			}
+			if value == nil {
+				return nil, errors.New("unexpected end of input")
+			}
+			return &ASTNode{
+				SpreadVal: value,
+			}, nil
 		case "[":
 			list, err := p.parseList()
 			if err != nil {
@@ -146,14 +236,26 @@ func (p *Parser) parseImmediateEntity() (*ASTNode, error) {
 		default:
 			return nil, fmt.Errorf("unexpected keyword: `%s`", tok.Value)
 		}
-	case tokenIdentifier:
+	case tokenIdentifier, tokenCell:
 		p.l.Next()
 		fName := tok.Value
+		if p.refs != nil {
+			p.refs[&fName] = p.l.spans[tok]
+		}
 
-		// Must be a variable
+		// Must be a variable. Grid cells are variables named by their address.
 		return &ASTNode{
 			VariableVal: &fName,
 		}, nil
+	case tokenRange:
+		p.l.Next()
+		corners := strings.SplitN(tok.Value, ":", 2)
+		return &ASTNode{
+			RangeVal: &Range{
+				From: corners[0],
+				To:   corners[1],
+			},
+		}, nil
 	default:
 		return nil, fmt.Errorf("expected Number, String, Identifier, `{`, or `[`; got: %+v", tok)
 	}
@@ -213,8 +315,16 @@ func (p *Parser) parseRecord() (*Record, error) {
 		if tok.Type == tokenPunctuation && tok.Value == "}" {
 			p.l.Next()
 			break
-		} else if tok.Type == tokenIdentifier {
-			prop, err := p.parseRecordProperty()
+		} else if isName(tok) || (tok.Type == tokenPunctuation && tok.Value == "...") {
+			var prop *RecordProperty
+			var err error
+			if isName(tok) {
+				prop, err = p.parseRecordProperty()
+			} else {
+				// Spread properties have no name of their own.
+				prop = &RecordProperty{}
+				prop.Value, err = p.parseImmediateEntity()
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -231,7 +341,7 @@ func (p *Parser) parseRecord() (*Record, error) {
 				return nil, fmt.Errorf("expected `,` or `}`; got %+v", nTok)
 			}
 		} else {
-			return nil, fmt.Errorf("expected identifier or `}`; got %+v", tok)
+			return nil, fmt.Errorf("expected identifier, `...` or `}`; got %+v", tok)
 		}
 	}
 
@@ -246,7 +356,7 @@ func (p *Parser) parseRecordProperty() (*RecordProperty, error) {
 		return nil, errors.New("unexpected end of input")
 	}
 
-	if tok.Type != tokenIdentifier {
+	if !isName(tok) {
 		return nil, fmt.Errorf("expected identifier; got %+v", tok)
 	}
 
@@ -267,6 +377,12 @@ func (p *Parser) parseRecordProperty() (*RecordProperty, error) {
 	}, nil
 }
 
+// isName reports whether the token can name a record property. Names which look like cell addresses are allowed
+// since they were valid before grids existed.
+func isName(tok *Token) bool {
+	return tok.Type == tokenIdentifier || tok.Type == tokenCell
+}
+
 func (p *Parser) parseTuple() (*Tuple, error) {
 	if open := p.l.Next(); open == nil || open.Type != tokenPunctuation || open.Value != "(" {
 		return nil, fmt.Errorf("expected `(`; got %+v", open)
@@ -285,6 +401,12 @@ func (p *Parser) parseTuple() (*Tuple, error) {
 			if err != nil {
 				return nil, err
 			}
+			if arg != nil && arg.VariableVal != nil {
+				arg, err = p.maybeParseNamedArgument(*arg.VariableVal, arg)
+				if err != nil {
+					return nil, err
+				}
+			}
 
 			elements = append(elements, arg)
 
@@ -305,13 +427,43 @@ func (p *Parser) parseTuple() (*Tuple, error) {
 	}, nil
 }
 
+// maybeParseNamedArgument checks whether the identifier just parsed is followed by `=`, in which case it names an
+// argument or, within lambda params, a param with a default value.
+func (p *Parser) maybeParseNamedArgument(name string, entity *ASTNode) (*ASTNode, error) {
+	n := p.l.Peek()
+	if n == nil || n.Type != tokenPunctuation || n.Value != "=" {
+		return entity, nil
+	}
+	p.l.Next()
+
+	value, err := p.parseEntity()
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, errors.New("unexpected end of input")
+	}
+
+	return &ASTNode{
+		NamedVal: &NamedArgument{
+			Name:  name,
+			Value: value,
+		},
+	}, nil
+}
+
 type ASTNode struct {
 	ApplicationVal *Application
 	BooleanVal     *bool
 	LambdaVal      *Lambda
 	ListVal        *List
+	NamedVal       *NamedArgument
 	NumberVal      *string
+	PipeVal        *Pipe
+	QuantityVal    *Quantity
+	RangeVal       *Range
 	RecordVal      *Record
+	SpreadVal      *ASTNode
 	StringVal      *string
 	TupleVal       *Tuple
 	VariableVal    *string
@@ -328,12 +480,27 @@ func (n *ASTNode) String() string {
 	if n.ListVal != nil {
 		return fmt.Sprintf("List{Elements:%v}", n.ListVal.Elements)
 	}
+	if n.NamedVal != nil {
+		return fmt.Sprintf("Named{%s = %v}", n.NamedVal.Name, n.NamedVal.Value)
+	}
 	if n.NumberVal != nil {
 		return fmt.Sprintf("Number{%v}", n.NumberVal)
 	}
+	if n.PipeVal != nil {
+		return fmt.Sprintf("Pipe{Value:%v, Function:%v}", n.PipeVal.Value, n.PipeVal.Function)
+	}
+	if n.QuantityVal != nil {
+		return fmt.Sprintf("Quantity{%s %s}", n.QuantityVal.Number, n.QuantityVal.Unit)
+	}
+	if n.RangeVal != nil {
+		return fmt.Sprintf("Range{%s:%s}", n.RangeVal.From, n.RangeVal.To)
+	}
 	if n.RecordVal != nil {
 		return fmt.Sprintf("Record{%v}", n.RecordVal.Properties)
 	}
+	if n.SpreadVal != nil {
+		return fmt.Sprintf("Spread{%v}", n.SpreadVal)
+	}
 	if n.StringVal != nil {
 		return fmt.Sprintf("String{%v}", n.StringVal)
 	}
@@ -368,10 +535,36 @@ type List struct {
 	Elements []*ASTNode
 }
 
+// NamedArgument is an argument passed by name or, within lambda params, a param with a default value.
+type NamedArgument struct {
+	Name  string
+	Value *ASTNode
+}
+
+// Pipe passes Value as the first argument of Function. Function is either a call, to which Value is added, or an
+// expression which is called with Value alone.
+type Pipe struct {
+	Value    *ASTNode
+	Function *ASTNode
+}
+
+// Quantity is a number followed by the unit it's measured in.
+type Quantity struct {
+	Number string
+	Unit   string
+}
+
+// Range is a rectangular block of grid cells between two corners, such as `B2:D10`.
+type Range struct {
+	From string
+	To   string
+}
+
 type Record struct {
 	Properties []*RecordProperty
 }
 
+// RecordProperty is either a named property or, when Name is empty, a spread of another record.
 type RecordProperty struct {
 	Name  string
 	Value *ASTNode
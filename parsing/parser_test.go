@@ -243,3 +243,155 @@ func TestParser_Currying(t *testing.T) {
 		t.Errorf("Expected arg to be variable; got %+v", arg)
 	}
 }
+
+func TestParse_Spread(t *testing.T) {
+	ast, err := Parse("[...xs, 4]")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if ast.ListVal == nil || len(ast.ListVal.Elements) != 2 {
+		t.Fatalf("Expected list of 2 elements; got %v", ast)
+	}
+	spread := ast.ListVal.Elements[0].SpreadVal
+	if spread == nil || spread.VariableVal == nil || *spread.VariableVal != "xs" {
+		t.Errorf("Expected spread of `xs`; got %v", ast.ListVal.Elements[0])
+	}
+}
+
+func TestParse_RecordSpread(t *testing.T) {
+	ast, err := Parse("{...base, rate = 0.2}")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if ast.RecordVal == nil || len(ast.RecordVal.Properties) != 2 {
+		t.Fatalf("Expected record of 2 properties; got %v", ast)
+	}
+	if prop := ast.RecordVal.Properties[0]; prop.Name != "" || prop.Value.SpreadVal == nil {
+		t.Errorf("Expected unnamed spread property; got %+v", prop)
+	}
+	if prop := ast.RecordVal.Properties[1]; prop.Name != "rate" {
+		t.Errorf("Expected property `rate`; got %+v", prop)
+	}
+}
+
+func TestParse_RestParam(t *testing.T) {
+	ast, err := Parse("(first, ...rest) => rest")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if ast.LambdaVal == nil {
+		t.Fatalf("Expected lambda; got %v", ast)
+	}
+	params := ast.LambdaVal.FreeVariables.Elements
+	if len(params) != 2 || params[1].SpreadVal == nil {
+		t.Errorf("Expected rest param; got %v", params)
+	}
+}
+
+func TestParse_NamedArgument(t *testing.T) {
+	ast, err := Parse("ROUND(x, digits = 2)")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	args := ast.ApplicationVal.Argument.Elements
+	if len(args) != 2 || args[1].NamedVal == nil {
+		t.Fatalf("Expected a named second argument; got %v", args)
+	}
+	if named := args[1].NamedVal; named.Name != "digits" || named.Value.NumberVal == nil || *named.Value.NumberVal != "2" {
+		t.Errorf("Unexpected named argument: %v", args[1])
+	}
+}
+
+func TestParse_Pipe(t *testing.T) {
+	ast, err := Parse("xs |> F(1) |> G")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	outer := ast.PipeVal
+	if outer == nil || outer.Function.VariableVal == nil || *outer.Function.VariableVal != "G" {
+		t.Fatalf("Expected the last stage to be `G`; got %v", ast)
+	}
+	inner := outer.Value.PipeVal
+	if inner == nil || inner.Function.ApplicationVal == nil || inner.Value.VariableVal == nil {
+		t.Errorf("Expected `xs` to be piped into `F(1)` first; got %v", outer.Value)
+	}
+}
+
+func TestParse_Quantity(t *testing.T) {
+	cases := map[string]string{
+		"5 km":          "km",
+		"9.8m/s^2":      "m/s^2",
+		"2 kg * m/s^-1": "kg*m/s^-1",
+		"[1 h, 30 min]": "h",
+		"F(-1.5 ft, 2)": "ft",
+		"3 mi |> F":     "mi",
+	}
+
+	for input, unit := range cases {
+		ast, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %s", input, err)
+		}
+
+		var q *Quantity
+		switch {
+		case ast.QuantityVal != nil:
+			q = ast.QuantityVal
+		case ast.ListVal != nil:
+			q = ast.ListVal.Elements[0].QuantityVal
+		case ast.ApplicationVal != nil:
+			q = ast.ApplicationVal.Argument.Elements[0].QuantityVal
+		case ast.PipeVal != nil:
+			q = ast.PipeVal.Value.QuantityVal
+		}
+		if q == nil || q.Unit != unit {
+			t.Errorf("Expected %q to have unit %q; got %v", input, unit, ast)
+		}
+	}
+
+	if _, err := Parse("5 km/"); err == nil {
+		t.Error("Expected an error for an incomplete unit")
+	}
+}
+
+func TestParse_Exponent(t *testing.T) {
+	ast, err := Parse("1e5")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if ast.NumberVal == nil || *ast.NumberVal != "1e5" {
+		t.Errorf("Expected 1e5 to parse as a number; got %v", ast)
+	}
+
+	ast, err = Parse("1.5e3 km")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if q := ast.QuantityVal; q == nil || q.Number != "1.5e3" || q.Unit != "km" {
+		t.Errorf("Expected 1.5e3 km to parse as a quantity of 1.5e3 km; got %v", ast)
+	}
+}
+
+func TestParse_Cells(t *testing.T) {
+	ast, err := Parse("SUM(A1, B2:D10)")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	args := ast.ApplicationVal.Argument.Elements
+	if v := args[0].VariableVal; v == nil || *v != "A1" {
+		t.Errorf("Expected a reference to A1; got %v", args[0])
+	}
+	if r := args[1].RangeVal; r == nil || r.From != "B2" || r.To != "D10" {
+		t.Errorf("Expected the range B2:D10; got %v", args[1])
+	}
+
+	if _, err := Parse("{A1 = 5}"); err != nil {
+		t.Errorf("Expected cell addresses to name record properties; got %s", err)
+	}
+}
@@ -0,0 +1,260 @@
+package parsing
+
+import (
+	"fmt"
+	"sort"
+)
+
+// RenameVariable returns a copy of n in which every reference to the variable from refers to to instead. Names are
+// compared as NormaliseName leaves them. Inside a lambda with a parameter named from, the name refers to the
+// parameter and is left alone. The second return value reports whether any reference was renamed. It's an error for
+// a renamed reference to fall inside a lambda with a parameter named to, since it would then refer to the parameter.
+func RenameVariable(n *ASTNode, from, to string) (*ASTNode, bool, error) {
+	r := &renamer{
+		from: NormaliseName(from),
+		to:   NormaliseName(to),
+	}
+
+	out, err := r.rename(n, false)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return out, r.changed, nil
+}
+
+// RewriteReferences renames the references to a variable within a formula's source. Only the references
+// themselves are rewritten so the rest of the formula keeps the layout it was written with. Formulas which can't be
+// parsed are returned as they are.
+func RewriteReferences(formula, from, to string) (string, error) {
+	p := NewParser(NewLexer(NewInputStream(formula)))
+	p.refs = make(map[*string]span)
+	ast, err := p.Parse()
+	if err != nil {
+		return formula, nil
+	}
+
+	r := &renamer{
+		from: NormaliseName(from),
+		to:   NormaliseName(to),
+	}
+	if _, err := r.rename(ast, false); err != nil {
+		return "", err
+	}
+	if !r.changed {
+		return formula, nil
+	}
+
+	spans := make([]span, len(r.renamed))
+	for i, ref := range r.renamed {
+		spans[i] = p.refs[ref]
+	}
+	// Replace from the end so earlier positions remain valid.
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start > spans[j].start
+	})
+
+	src := []rune(formula)
+	for _, s := range spans {
+		src = append(src[:s.start], append([]rune(r.to), src[s.end:]...)...)
+	}
+
+	return string(src), nil
+}
+
+type renamer struct {
+	from    string
+	to      string
+	changed bool
+	// The VariableVal of every reference which was renamed, from the original AST.
+	renamed []*string
+}
+
+// rename copies n, renaming references along the way. captured records whether to is bound by an enclosing lambda.
+func (r *renamer) rename(n *ASTNode, captured bool) (*ASTNode, error) {
+	if n == nil {
+		return nil, nil
+	}
+
+	switch {
+	case n.ApplicationVal != nil:
+		exp, err := r.rename(n.ApplicationVal.Expression, captured)
+		if err != nil {
+			return nil, err
+		}
+		args, err := r.renameTuple(n.ApplicationVal.Argument, captured)
+		if err != nil {
+			return nil, err
+		}
+		return &ASTNode{
+			ApplicationVal: &Application{
+				Expression: exp,
+				Argument:   args,
+			},
+		}, nil
+	case n.LambdaVal != nil:
+		params := n.LambdaVal.FreeVariables
+		if params != nil {
+			for _, p := range params.Elements {
+				if p == nil {
+					continue
+				}
+				var name string
+				switch {
+				case p.SpreadVal != nil && p.SpreadVal.VariableVal != nil:
+					// A rest parameter.
+					name = *p.SpreadVal.VariableVal
+				case p.NamedVal != nil:
+					// A parameter with a default value.
+					name = p.NamedVal.Name
+				case p.VariableVal != nil:
+					name = *p.VariableVal
+				default:
+					continue
+				}
+				switch NormaliseName(name) {
+				case r.from:
+					// Every reference in the body is to the parameter.
+					return n, nil
+				case r.to:
+					captured = true
+				}
+			}
+		}
+
+		// Default values are evaluated alongside the body.
+		params, err := r.renameTuple(params, captured)
+		if err != nil {
+			return nil, err
+		}
+		exp, err := r.rename(n.LambdaVal.Expression, captured)
+		if err != nil {
+			return nil, err
+		}
+		return &ASTNode{
+			LambdaVal: &Lambda{
+				FreeVariables: params,
+				Expression:    exp,
+			},
+		}, nil
+	case n.ListVal != nil:
+		elements, err := r.renameAll(n.ListVal.Elements, captured)
+		if err != nil {
+			return nil, err
+		}
+		return &ASTNode{
+			ListVal: &List{
+				Elements: elements,
+			},
+		}, nil
+	case n.NamedVal != nil:
+		v, err := r.rename(n.NamedVal.Value, captured)
+		if err != nil {
+			return nil, err
+		}
+		// The name belongs to a parameter rather than referring to a variable.
+		return &ASTNode{
+			NamedVal: &NamedArgument{
+				Name:  n.NamedVal.Name,
+				Value: v,
+			},
+		}, nil
+	case n.PipeVal != nil:
+		v, err := r.rename(n.PipeVal.Value, captured)
+		if err != nil {
+			return nil, err
+		}
+		f, err := r.rename(n.PipeVal.Function, captured)
+		if err != nil {
+			return nil, err
+		}
+		return &ASTNode{
+			PipeVal: &Pipe{
+				Value:    v,
+				Function: f,
+			},
+		}, nil
+	case n.RecordVal != nil:
+		props := make([]*RecordProperty, len(n.RecordVal.Properties))
+		for i, prop := range n.RecordVal.Properties {
+			v, err := r.rename(prop.Value, captured)
+			if err != nil {
+				return nil, err
+			}
+			// Property names aren't references so they're kept as they are.
+			props[i] = &RecordProperty{
+				Name:  prop.Name,
+				Value: v,
+			}
+		}
+		return &ASTNode{
+			RecordVal: &Record{
+				Properties: props,
+			},
+		}, nil
+	case n.SpreadVal != nil:
+		v, err := r.rename(n.SpreadVal, captured)
+		if err != nil {
+			return nil, err
+		}
+		return &ASTNode{
+			SpreadVal: v,
+		}, nil
+	case n.TupleVal != nil:
+		t, err := r.renameTuple(n.TupleVal, captured)
+		if err != nil {
+			return nil, err
+		}
+		return &ASTNode{
+			TupleVal: t,
+		}, nil
+	case n.VariableVal != nil:
+		if NormaliseName(*n.VariableVal) != r.from {
+			return n, nil
+		}
+		if captured {
+			return nil, fmt.Errorf("cannot rename `%s` to `%s`; the new name is already used by a lambda parameter", r.from, r.to)
+		}
+
+		r.changed = true
+		r.renamed = append(r.renamed, n.VariableVal)
+		name := r.to
+		return &ASTNode{
+			VariableVal: &name,
+		}, nil
+	default:
+		return n, nil
+	}
+}
+
+func (r *renamer) renameTuple(t *Tuple, captured bool) (*Tuple, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	elements, err := r.renameAll(t.Elements, captured)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tuple{
+		Elements: elements,
+	}, nil
+}
+
+func (r *renamer) renameAll(nodes []*ASTNode, captured bool) ([]*ASTNode, error) {
+	if nodes == nil {
+		return nil, nil
+	}
+
+	out := make([]*ASTNode, len(nodes))
+	for i, n := range nodes {
+		var err error
+		out[i], err = r.rename(n, captured)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,99 @@
+package parsing
+
+import "testing"
+
+func TestRenameVariable(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+		changed  bool
+	}{
+		{"SUM(x, 1)", "SUM(y, 1)", true},
+		{"SUM(X, x)", "SUM(y, y)", true},
+		{`CONCATENATE("x", z)`, `CONCATENATE("x", z)`, false},
+		{"{x = x}", "{x = y}", true},
+		{"[(x) => x, (a) => SUM(a, x)]", "[(x) => x, (a) => SUM(a, y)]", true},
+		{"x(x)", "y(y)", true},
+		{"{...x, a = [...x]}", "{...y, a = [...y]}", true},
+		{"(a, ...x) => SUM(a, ...x)", "(a, ...x) => SUM(a, ...x)", false},
+		{"(a, b = x) => F(a, x = b)", "(a, b = y) => F(a, x = b)", true},
+	}
+
+	for _, c := range cases {
+		ast, err := Parse(c.input)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %s", c.input, err)
+		}
+
+		renamed, changed, err := RenameVariable(ast, "x", "y")
+		if err != nil {
+			t.Fatalf("Unexpected error renaming %q: %s", c.input, err)
+		}
+
+		if changed != c.changed {
+			t.Errorf("Expected changed to be %v for %q", c.changed, c.input)
+		}
+		if actual := Format(renamed); actual != c.expected {
+			t.Errorf("Expected %q to be renamed as %q; got %q", c.input, c.expected, actual)
+		}
+		if original := Format(ast); original != Format(mustParse(t, c.input)) {
+			t.Errorf("Expected the original AST to be left alone; got %q", original)
+		}
+	}
+}
+
+func TestRenameVariable_Captured(t *testing.T) {
+	ast := mustParse(t, "(y) => SUM(x, y)")
+
+	if _, _, err := RenameVariable(ast, "x", "y"); err == nil {
+		t.Fatal("Expected an error when the new name is bound by a lambda parameter")
+	}
+}
+
+func mustParse(t *testing.T, formula string) *ASTNode {
+	ast, err := Parse(formula)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing %q: %s", formula, err)
+	}
+
+	return ast
+}
+
+func TestRewriteReferences(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"SUM( x,1 )", "SUM( y,1 )"},
+		{"SUM(X,\n  x)", "SUM(y,\n  y)"},
+		{`CONCATENATE("x", z)`, `CONCATENATE("x", z)`},
+		{"{x = x}", "{x = y}"},
+		{"(x) => x |> F(x)", "(x) => x |> F(x)"},
+		{"[(a) => SUM(a,x), x]", "[(a) => SUM(a,y), y]"},
+		{`"é" |> F(x)`, `"é" |> F(y)`},
+		{"SUM(x,", "SUM(x,"},
+	}
+
+	for _, c := range cases {
+		actual, err := RewriteReferences(c.input, "x", "y")
+		if err != nil {
+			t.Fatalf("Unexpected error rewriting %q: %s", c.input, err)
+		}
+		if actual != c.expected {
+			t.Errorf("Expected %q to be rewritten as %q; got %q", c.input, c.expected, actual)
+		}
+	}
+
+	if _, err := RewriteReferences("(y) => SUM(x, y)", "x", "y"); err == nil {
+		t.Error("Expected an error renaming a reference captured by a lambda parameter")
+	}
+
+	// A variable named like a cell, but in lowercase, is distinct from the cell.
+	actual, err := RewriteReferences("ADD(q1, Q1)", "q1", "growth")
+	if err != nil {
+		t.Fatalf("Unexpected error rewriting q1: %s", err)
+	}
+	if expected := "ADD(growth, Q1)"; actual != expected {
+		t.Errorf("Expected q1 to be rewritten as %q; got %q", expected, actual)
+	}
+}
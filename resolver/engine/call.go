@@ -0,0 +1,223 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// call applies a function or lambda to resolved arguments. varName is the variable the callee was found in, if any,
+// and is used to describe lambdas in errors.
+func (e *Engine) call(ctx context.Context, callee *types.Object, varName string, args []*types.Object, named []*types.NamedArgument, varHistory []string) (*types.Object, error) {
+	switch callee.Type() {
+	case types.TypeFunction:
+		name, _ := callee.FunctionName()
+		if normaliseVarName(name) == partialName {
+			return partial(args, named)
+		}
+
+		values := args
+		if params, _ := callee.FunctionParams(); len(params) > 0 {
+			var err error
+			values, _, err = bindArguments(name, params, false, args, named)
+			if err != nil {
+				return nil, err
+			}
+			values, err = fillBuiltinArguments(name, params, values)
+			if err != nil {
+				return nil, err
+			}
+		} else if len(named) > 0 {
+			return nil, fmt.Errorf("%s does not accept named arguments", name)
+		}
+
+		if normaliseVarName(name) == simulateName {
+			return e.simulate(ctx, values, varHistory)
+		}
+
+		// Execute functions inline.
+		if rf, _ := callee.ToRandomFunction(); rf != nil {
+			var rng *rand.Rand
+			if qc, ok := getQueryContext(ctx); ok {
+				rng = qc.random
+			}
+			return rf(rng, values)
+		}
+		if hf, _ := callee.ToHigherOrderFunction(); hf != nil {
+			return hf(func(f *types.Object, args []*types.Object) (*types.Object, error) {
+				return e.call(ctx, f, "", args, nil, varHistory)
+			}, values)
+		}
+		f, _ := callee.ToFunction()
+		return f(values)
+	case types.TypeLambda:
+		l, _ := callee.ToLambda()
+		params := make([]types.Param, len(l.FreeVariables))
+		for i, name := range l.FreeVariables {
+			params[i] = types.Param{
+				Name:    name,
+				Default: l.Defaults[normaliseVarName(name)],
+			}
+		}
+
+		label := "lambda"
+		if varName != "" {
+			label = fmt.Sprintf("lambda `%s`", varName)
+		}
+		values, rest, err := bindArguments(label, params, l.RestVariable != "", args, named)
+		if err != nil {
+			return nil, err
+		}
+
+		// Bind any arguments for lambdas. Defaults are resolved in order so they may refer to earlier params.
+		varMap := make(map[string]*types.Object)
+		for k, v := range l.Bindings {
+			varMap[k] = v
+		}
+		for i, p := range params {
+			v := values[i]
+			if v == nil {
+				bound, err := bindVariables(p.Default, varMap)
+				if err != nil {
+					return nil, err
+				}
+				v, err = e.resolve(ctx, bound, varHistory)
+				if err != nil {
+					return nil, err
+				}
+			}
+			varMap[normaliseVarName(p.Name)] = v
+		}
+		if l.RestVariable != "" {
+			varMap[normaliseVarName(l.RestVariable)] = types.NewList(rest)
+		}
+
+		bound, err := bindVariables(l.Expression, varMap)
+		if err != nil {
+			return nil, err
+		}
+
+		return e.resolve(ctx, bound, varHistory)
+	default:
+		return nil, fmt.Errorf("attempt to call non-callable: %s", callee.Type())
+	}
+}
+
+// bindArguments matches positional and named arguments to params. The value for each param is returned in order,
+// or nil if it was omitted, along with any positional arguments left over for a variadic callee.
+func bindArguments(callee string, params []types.Param, variadic bool, args []*types.Object, named []*types.NamedArgument) ([]*types.Object, []*types.Object, error) {
+	if len(args) > len(params) && !variadic {
+		return nil, nil, arityError(callee, params, len(args))
+	}
+
+	values := make([]*types.Object, len(params))
+	rest := []*types.Object{}
+	for i, arg := range args {
+		if i < len(params) {
+			values[i] = arg
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+
+	for _, arg := range named {
+		i := paramIndex(params, arg.Name)
+		if i < 0 {
+			return nil, nil, fmt.Errorf("%s has no param named `%s`", callee, arg.Name)
+		}
+		if values[i] != nil {
+			return nil, nil, fmt.Errorf("%s received more than one value for `%s`", callee, params[i].Name)
+		}
+		values[i] = arg.Value
+	}
+
+	for i, p := range params {
+		if values[i] == nil && p.Default == nil && !p.Optional {
+			return nil, nil, fmt.Errorf("%s is missing argument `%s`", callee, p.Name)
+		}
+	}
+
+	return values, rest, nil
+}
+
+// fillBuiltinArguments replaces omitted arguments with their defaults. Optional params without a default are left
+// off the end; there's no way to pass a builtin nothing in the middle of its arguments.
+func fillBuiltinArguments(callee string, params []types.Param, values []*types.Object) ([]*types.Object, error) {
+	out := make([]*types.Object, len(values))
+	for i, v := range values {
+		if v == nil {
+			v = params[i].Default
+		}
+		out[i] = v
+	}
+
+	end := len(out)
+	for end > 0 && out[end-1] == nil {
+		end--
+	}
+	for i := 0; i < end; i++ {
+		if out[i] == nil {
+			return nil, fmt.Errorf("%s is missing argument `%s`", callee, params[i].Name)
+		}
+	}
+
+	return out[:end], nil
+}
+
+func paramIndex(params []types.Param, name string) int {
+	for i, p := range params {
+		if normaliseVarName(p.Name) == normaliseVarName(name) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func arityError(callee string, params []types.Param, received int) error {
+	required := 0
+	for _, p := range params {
+		if p.Default == nil && !p.Optional {
+			required++
+		}
+	}
+
+	if required == len(params) {
+		return fmt.Errorf("%s expects %s; received %d", callee, countArguments(len(params)), received)
+	}
+	return fmt.Errorf("%s expects at most %s; received %d", callee, countArguments(len(params)), received)
+}
+
+func countArguments(n int) string {
+	if n == 1 {
+		return "1 argument"
+	}
+	return fmt.Sprintf("%d arguments", n)
+}
+
+const partialName = "partial"
+
+// partial binds the leading arguments, along with any named arguments, of the callable given as its first argument.
+// The result is a lambda which passes its own arguments after the bound ones so the callable still checks them.
+func partial(args []*types.Object, named []*types.NamedArgument) (*types.Object, error) {
+	if len(args) == 0 {
+		return nil, errors.New("PARTIAL is missing argument `function`")
+	}
+	f := args[0]
+	if t := f.Type(); t != types.TypeFunction && t != types.TypeLambda {
+		return nil, fmt.Errorf("PARTIAL expects a function or lambda; received a %s", t)
+	}
+
+	return types.NewForwardingClosure(func(rest *types.Object) *types.Object {
+		bodyArgs := make([]*types.Object, 0, len(args)+len(named))
+		bodyArgs = append(bodyArgs, args[1:]...)
+		bodyArgs = append(bodyArgs, rest)
+		for _, arg := range named {
+			bodyArgs = append(bodyArgs, types.NewNamedArgument(arg.Name, arg.Value))
+		}
+		return types.NewApplication(f, bodyArgs)
+	}), nil
+}
@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Guards against a table tying up the resolver with more cells than anyone could read.
+const maxDataTableCells = 10000
+
+// DataTable describes a sensitivity table: the output variable is resolved with the row input set to each of the
+// row values and, for a two-variable table, the column input set to each of the column values. Values are
+// formulas so they may carry units or currencies.
+type DataTable struct {
+	Output       string
+	RowInput     string
+	RowValues    []string
+	ColumnInput  string
+	ColumnValues []string
+	// Limits how long the whole table may take. There's no limit when zero.
+	Timeout time.Duration
+}
+
+// DataTable resolves the output for every combination of the inputs' values. The result has a row for each row
+// value and, for a two-variable table, a column for each column value; one-variable tables have a single column.
+// Only the variables downstream of the inputs are resolved again for each cell. Stored formulas are never changed.
+func (e *Engine) DataTable(ctx context.Context, pageId string, table *DataTable) ([][]*Result, error) {
+	if pageId == "" {
+		return nil, errors.New("pageId must be provided")
+	}
+	if len(table.RowValues) == 0 {
+		return nil, errors.New("data table needs at least one row value")
+	}
+	inputs := []string{table.RowInput}
+	columnValues := []string{""}
+	if table.ColumnInput != "" {
+		if len(table.ColumnValues) == 0 {
+			return nil, errors.New("data table needs at least one column value")
+		}
+		if normaliseVarName(table.ColumnInput) == normaliseVarName(table.RowInput) {
+			return nil, fmt.Errorf("data table inputs must be different variables; found `%s` twice", table.RowInput)
+		}
+		inputs = append(inputs, table.ColumnInput)
+		columnValues = table.ColumnValues
+	}
+	if n := len(table.RowValues) * len(columnValues); n > maxDataTableCells {
+		return nil, fmt.Errorf("data table may have at most %d cells; found %d", maxDataTableCells, n)
+	}
+
+	if table.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, table.Timeout)
+		defer cancel()
+	}
+
+	qc := e.startQuery(pageId)
+	ctx = setQueryContext(ctx, qc)
+	vars, err := e.fetchPageVariables(ctx, qc)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range append([]string{table.Output}, inputs...) {
+		if v, _ := qc.lookupVariable(name); v == nil {
+			return nil, fmt.Errorf("`%s` is not a variable on the page", name)
+		}
+	}
+
+	// Resolving the output as the page stands leaves the values of everything upstream of the inputs to be shared
+	// by every cell. Any error is left for the cells to report.
+	output := &types.Variable{Name: table.Output}
+	_, _ = e.resolveVariable(ctx, output, []string{}, true)
+	affected := make(map[string]bool)
+	for _, name := range e.buildDependencyGraph(vars).downstream(inputs) {
+		affected[name] = true
+	}
+
+	rows := make([][]*Result, len(table.RowValues))
+	for i, rowValue := range table.RowValues {
+		rows[i] = make([]*Result, len(columnValues))
+		for j, columnValue := range columnValues {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("data table stopped after %d of %d cells: %v", i*len(columnValues)+j, len(table.RowValues)*len(columnValues), err)
+			}
+
+			overrides := Overrides{table.RowInput: rowValue}
+			if table.ColumnInput != "" {
+				overrides[table.ColumnInput] = columnValue
+			}
+			cell := qc.fork(nil)
+			cell.override(overrides)
+			cell.keepValues(qc, affected)
+
+			res := &Result{}
+			res.Value, res.Err = e.resolveVariable(setQueryContext(ctx, cell), output, []string{}, true)
+			rows[i][j] = res
+		}
+	}
+
+	return rows, nil
+}
@@ -4,49 +4,134 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/tobyjsullivan/chalk/monolith"
 
+	"github.com/tobyjsullivan/chalk/parsing"
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/finance"
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/matrix"
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/money"
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/random"
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/sql"
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/stats"
 	"github.com/tobyjsullivan/chalk/resolver/engine/lib/std"
-	"github.com/tobyjsullivan/chalk/resolver/engine/parsing"
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/units"
 	"github.com/tobyjsullivan/chalk/resolver/engine/types"
 )
 
 type Engine struct {
-	varSvc monolith.VariablesClient
+	varSvc       monolith.VariablesClient
+	parallelism  int
+	randomSource func() rand.Source
+
+	formulasMx sync.RWMutex
+	formulas   map[string]*types.Object
+}
+
+type Option func(*Engine)
+
+// WithParallelism allows up to n goroutines per query to resolve independent arguments, list elements and record
+// properties at the same time. By default everything is resolved sequentially.
+func WithParallelism(n int) Option {
+	return func(e *Engine) {
+		if n > 1 {
+			e.parallelism = n
+		}
+	}
 }
 
-func NewEngine(varSvc monolith.VariablesClient) *Engine {
-	return &Engine{
-		varSvc: varSvc,
+// WithRandomSource sets where each query gets the seed for simulations which aren't given one. It's called once per
+// query. By default every query uses the same seed so results are reproducible.
+func WithRandomSource(f func() rand.Source) Option {
+	return func(e *Engine) {
+		e.randomSource = f
 	}
 }
 
-type contextKey string
+func defaultRandomSource() rand.Source {
+	return rand.NewSource(1)
+}
 
-const contextKeyPageId = contextKey("pageId")
+func NewEngine(varSvc monolith.VariablesClient, opts ...Option) *Engine {
+	e := &Engine{
+		varSvc:       varSvc,
+		parallelism:  1,
+		randomSource: defaultRandomSource,
+		formulas:     make(map[string]*types.Object),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
 
-type queryContext struct {
-	pageId string
+	return e
 }
 
+// Overrides maps variable names to formulas which replace those of the page's variables for a single query. They
+// let a formula be resolved as if the page were different without changing it.
+type Overrides map[string]string
+
 func (e *Engine) Query(ctx context.Context, pageId string, formula string) (*types.Object, error) {
+	return e.QueryWithOverrides(ctx, pageId, formula, nil)
+}
+
+// QueryWithOverrides resolves the formula as if the page's variables were replaced by the overrides.
+func (e *Engine) QueryWithOverrides(ctx context.Context, pageId string, formula string, overrides Overrides) (*types.Object, error) {
 	if pageId == "" {
 		return nil, errors.New("pageId must be provided")
 	}
 
-	function, err := parseFormula(formula)
+	function, err := e.parseFormula(formula)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx = setContextPageId(ctx, pageId)
+	qc := e.startQuery(pageId)
+	qc.override(overrides)
+	ctx = setQueryContext(ctx, qc)
+
+	if err := e.prefetchVariables(ctx, qc, function); err != nil {
+		return nil, err
+	}
 
 	return e.resolve(ctx, function, []string{})
 }
 
+// startQuery creates the context for a new query.
+func (e *Engine) startQuery(pageId string) *queryContext {
+	qc := newQueryContext(pageId, e.parallelism)
+	qc.seed = e.randomSource().Int63()
+	return qc
+}
+
+// parseFormula returns the object for a formula, reusing the result of any previous parse of the same text.
+func (e *Engine) parseFormula(formula string) (*types.Object, error) {
+	e.formulasMx.RLock()
+	obj, ok := e.formulas[formula]
+	e.formulasMx.RUnlock()
+	if ok {
+		return obj, nil
+	}
+
+	obj, err := parseFormula(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	e.formulasMx.Lock()
+	defer e.formulasMx.Unlock()
+	if len(e.formulas) >= maxCachedFormulas {
+		// Simply start over rather than tracking usage; formulas are cheap to re-parse.
+		e.formulas = make(map[string]*types.Object)
+	}
+	e.formulas[formula] = obj
+
+	return obj, nil
+}
+
 func parseFormula(formula string) (*types.Object, error) {
 	ast, err := parsing.Parse(formula)
 	if err != nil {
@@ -67,7 +152,14 @@ func mapAst(ast *parsing.ASTNode) (*types.Object, error) {
 		}
 
 		args := make([]*types.Object, len(ast.ApplicationVal.Argument.Elements))
+		var named string
 		for i, arg := range ast.ApplicationVal.Argument.Elements {
+			if arg != nil && arg.NamedVal != nil {
+				named = arg.NamedVal.Name
+			} else if named != "" {
+				return nil, fmt.Errorf("positional argument cannot follow named argument `%s`", named)
+			}
+
 			var err error
 			args[i], err = mapAst(arg)
 			if err != nil {
@@ -80,6 +172,13 @@ func mapAst(ast *parsing.ASTNode) (*types.Object, error) {
 	if ast.BooleanVal != nil {
 		return types.NewBoolean(*ast.BooleanVal), nil
 	}
+	if ast.NamedVal != nil {
+		v, err := mapAst(ast.NamedVal.Value)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewNamedArgument(ast.NamedVal.Name, v), nil
+	}
 	if ast.NumberVal != nil {
 		n, err := strconv.ParseFloat(*ast.NumberVal, 64)
 		if err != nil {
@@ -87,15 +186,61 @@ func mapAst(ast *parsing.ASTNode) (*types.Object, error) {
 		}
 		return types.NewNumber(n), nil
 	}
+	if ast.RangeVal != nil {
+		return types.NewRange(ast.RangeVal.From, ast.RangeVal.To), nil
+	}
+	if ast.QuantityVal != nil && types.IsCurrency(ast.QuantityVal.Unit) {
+		amount, err := types.ParseDecimal(ast.QuantityVal.Number)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewMoney(amount, ast.QuantityVal.Unit)
+	}
+	if ast.QuantityVal != nil {
+		n, err := strconv.ParseFloat(ast.QuantityVal.Number, 64)
+		if err != nil {
+			return nil, err
+		}
+		unit, err := types.ParseUnit(ast.QuantityVal.Unit)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewQuantity(n, unit), nil
+	}
 	if ast.LambdaVal != nil {
 		exp, err := mapAst(ast.LambdaVal.Expression)
 		if err != nil {
 			return nil, err
 		}
 
-		// We expect each element of the free vars tuple to be simple named variables.
-		freeVars := make([]string, len(ast.LambdaVal.FreeVariables.Elements))
-		for i, element := range ast.LambdaVal.FreeVariables.Elements {
+		// We expect each element of the free vars tuple to be simple named variables, optionally with a default
+		// value. The last may instead be a rest parameter.
+		elements := ast.LambdaVal.FreeVariables.Elements
+		var freeVars []string
+		var rest string
+		var defaults map[string]*types.Object
+		for i, element := range elements {
+			var def *types.Object
+			switch {
+			case element.SpreadVal != nil:
+				if i != len(elements)-1 {
+					return nil, errors.New("rest parameter must be the last lambda param")
+				}
+				element = element.SpreadVal
+			case element.NamedVal != nil:
+				var err error
+				def, err = mapAst(element.NamedVal.Value)
+				if err != nil {
+					return nil, err
+				}
+				name := element.NamedVal.Name
+				element = &parsing.ASTNode{
+					VariableVal: &name,
+				}
+			case defaults != nil:
+				return nil, errors.New("lambda params without a default must come before those with one")
+			}
+
 			variable, err := mapAst(element)
 			if err != nil {
 				return nil, err
@@ -105,10 +250,21 @@ func mapAst(ast *parsing.ASTNode) (*types.Object, error) {
 			}
 
 			v, _ := variable.ToVariable()
-			freeVars[i] = v.Name
+			switch {
+			case elements[i].SpreadVal != nil:
+				rest = v.Name
+			case def != nil:
+				if defaults == nil {
+					defaults = make(map[string]*types.Object)
+				}
+				defaults[normaliseVarName(v.Name)] = def
+				freeVars = append(freeVars, v.Name)
+			default:
+				freeVars = append(freeVars, v.Name)
+			}
 		}
 
-		return types.NewLambda(freeVars, exp), nil
+		return types.NewClosure(freeVars, rest, defaults, exp, nil), nil
 	}
 	if ast.ListVal != nil {
 		elements := ast.ListVal.Elements
@@ -123,23 +279,40 @@ func mapAst(ast *parsing.ASTNode) (*types.Object, error) {
 
 		return types.NewList(elObjs), nil
 	}
+	if ast.PipeVal != nil {
+		return mapPipe(ast.PipeVal)
+	}
 	if ast.RecordVal != nil {
-		props := make(map[string]*types.Object)
-
-		var err error
+		rec := &types.Record{}
 		for _, prop := range ast.RecordVal.Properties {
-			props[prop.Name], err = mapAst(prop.Value)
+			v, err := mapAst(prop.Value)
 			if err != nil {
 				return nil, err
 			}
+			// Spread and repeated properties are merged in order once resolved.
+			rec.Properties = append(rec.Properties, &types.RecordProperty{
+				Name:  prop.Name,
+				Value: v,
+			})
 		}
 
-		return types.NewRecord(props), nil
+		return types.NewRecord(rec.Properties), nil
+	}
+	if ast.SpreadVal != nil {
+		v, err := mapAst(ast.SpreadVal)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewSpread(v), nil
 	}
 	if ast.StringVal != nil {
 		return types.NewString(*ast.StringVal), nil
 	}
 	if ast.TupleVal != nil {
+		// A single parenthesised entity, such as a lambda which is applied immediately.
+		if len(ast.TupleVal.Elements) == 1 {
+			return mapAst(ast.TupleVal.Elements[0])
+		}
 		return nil, errors.New("tuple not handled")
 	}
 	if ast.VariableVal != nil {
@@ -149,6 +322,26 @@ func mapAst(ast *parsing.ASTNode) (*types.Object, error) {
 	return nil, fmt.Errorf("unknown ast node: %v", ast)
 }
 
+// mapPipe rewrites a pipeline as the call it stands for.
+func mapPipe(pipe *parsing.Pipe) (*types.Object, error) {
+	value, err := mapAst(pipe.Value)
+	if err != nil {
+		return nil, err
+	}
+	f, err := mapAst(pipe.Function)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only a call written out in the pipeline takes the value as an extra argument. Anything else, including a call
+	// in parentheses, is called with the value alone.
+	if pipe.Function.ApplicationVal == nil {
+		return types.NewApplication(f, []*types.Object{value}), nil
+	}
+	a, _ := f.ToApplication()
+	return types.NewApplication(a.Expression, append([]*types.Object{value}, a.Arguments...)), nil
+}
+
 func (e *Engine) resolve(ctx context.Context, formula *types.Object, varHistory []string) (*types.Object, error) {
 	if formula == nil {
 		return nil, nil
@@ -158,6 +351,8 @@ func (e *Engine) resolve(ctx context.Context, formula *types.Object, varHistory
 	case types.TypeApplication:
 		a, _ := formula.ToApplication()
 		return e.resolveApplication(ctx, a, varHistory)
+	case types.TypeBlank:
+		return formula, nil
 	case types.TypeBoolean:
 		return formula, nil
 	case types.TypeFunction:
@@ -167,11 +362,20 @@ func (e *Engine) resolve(ctx context.Context, formula *types.Object, varHistory
 	case types.TypeList:
 		l, _ := formula.ToList()
 		return e.resolveList(ctx, l, varHistory)
+	case types.TypeMoney:
+		return formula, nil
+	case types.TypeNamedArgument:
+		return nil, errors.New("named arguments can only be used when calling a function or lambda")
 	case types.TypeNumber:
 		return formula, nil
+	case types.TypeRange:
+		r, _ := formula.ToRange()
+		return e.resolveRange(ctx, r, varHistory)
 	case types.TypeRecord:
 		r, _ := formula.ToRecord()
 		return e.resolveRecord(ctx, r, varHistory)
+	case types.TypeSpread:
+		return nil, errors.New("`...` can only be used within lists, records, arguments and lambda params")
 	case types.TypeString:
 		return formula, nil
 	case types.TypeVariable:
@@ -196,40 +400,24 @@ func (e *Engine) resolveVariable(ctx context.Context, variable *types.Variable,
 	}
 
 	// Lookup formula
-	pageId, ok := getContextPageId(ctx)
+	qc, ok := getQueryContext(ctx)
 	if !ok {
-		return nil, errors.New("could not find pageId in context")
+		return nil, errors.New("could not find query in context")
 	}
-	resp, err := e.varSvc.FindVariables(ctx, &monolith.FindVariablesRequest{
-		PageId: pageId,
-		Names:  []string{varName},
-	})
+	match, err := e.findVariable(ctx, qc, varName)
 	if err != nil {
 		return nil, err
 	}
 
-	var match *monolith.Variable
-	for _, v := range resp.Values {
-		if normaliseVarName(v.Name) == normaliseVarName(varName) {
-			match = v
-			break
-		}
-	}
-
 	if match != nil {
-		f := match.Formula
-
-		// get object
-		o, err := parseFormula(f)
-		if err != nil {
-			return nil, err
+		// Each variable only needs to be resolved once per query.
+		if r, ok := qc.lookupValue(varName); ok {
+			return r.value, r.err
 		}
 
-		// resolve
-		newHist := make([]string, len(varHistory)+1)
-		copy(newHist, varHistory)
-		newHist[len(varHistory)] = normaliseVarName(varName)
-		return e.resolve(ctx, o, newHist)
+		result, err := e.resolveFormula(ctx, match.Formula, varHistory, varName)
+		qc.storeValue(varName, result, err)
+		return result, err
 	}
 
 	// Try to find a built-in value
@@ -239,79 +427,140 @@ func (e *Engine) resolveVariable(ctx context.Context, variable *types.Variable,
 	}
 
 	if required {
+		// Uppercase names like `Q1` referred to the variable `q1` until cells were added, so point out the mix up.
+		if lower := strings.ToLower(varName); lower != normaliseVarName(varName) {
+			if v, _ := e.findVariable(ctx, qc, lower); v != nil {
+				return nil, fmt.Errorf("variable `%s` is not defined; `%s` refers to a cell so write `%s` for the variable", varName, varName, lower)
+			}
+		}
 		return nil, fmt.Errorf("variable `%s` is not defined", varName)
 	}
 	return nil, nil
 }
 
-func (e *Engine) resolveList(ctx context.Context, list *types.List, varHistory []string) (*types.Object, error) {
-	resolvedElements := make([]*types.Object, len(list.Elements))
-	var err error
-	for i, element := range list.Elements {
-		resolvedElements[i], err = e.resolve(ctx, element, varHistory)
-		if err != nil {
+// findVariable returns the page variable with the given name, or nil if there isn't one.
+func (e *Engine) findVariable(ctx context.Context, qc *queryContext, name string) (*monolith.Variable, error) {
+	match, ok := qc.lookupVariable(name)
+	if !ok {
+		// Not covered by the prefetch so fall back to fetching it alone.
+		if err := e.fetchVariables(ctx, qc, []string{normaliseVarName(name)}); err != nil {
 			return nil, err
 		}
+		match, _ = qc.lookupVariable(name)
+	}
+	return match, nil
+}
+
+func (e *Engine) resolveFormula(ctx context.Context, formula string, varHistory []string, varName string) (*types.Object, error) {
+	// get object
+	o, err := e.parseFormula(formula)
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve
+	newHist := make([]string, len(varHistory)+1)
+	copy(newHist, varHistory)
+	newHist[len(varHistory)] = normaliseVarName(varName)
+	return e.resolve(ctx, o, newHist)
+}
+
+func (e *Engine) resolveList(ctx context.Context, list *types.List, varHistory []string) (*types.Object, error) {
+	resolvedElements, err := e.resolveSpreadable(ctx, list.Elements, varHistory)
+	if err != nil {
+		return nil, err
 	}
 
-	return types.NewList(resolvedElements), err
+	return types.NewList(resolvedElements), nil
 }
 
 func (e *Engine) resolveRecord(ctx context.Context, rec *types.Record, varHistory []string) (*types.Object, error) {
-	resolvedProps := make(map[string]*types.Object)
+	values := make([]*types.Object, len(rec.Properties))
+	for i, prop := range rec.Properties {
+		values[i] = prop.Value
+	}
+
+	resolvedValues, spread, err := e.resolveElements(ctx, values, varHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	// Later properties, whether named or spread, replace earlier ones.
+	out := &types.Record{}
+	for i, prop := range rec.Properties {
+		if !spread[i] {
+			out = out.Set(prop.Name, resolvedValues[i])
+			continue
+		}
 
-	var err error
-	for key, value := range rec.Properties {
-		resolvedProps[key], err = e.resolve(ctx, value, varHistory)
+		r, err := toSpreadRecord(resolvedValues[i])
 		if err != nil {
 			return nil, err
 		}
+		for _, p := range r.Properties {
+			out = out.Set(p.Name, p.Value)
+		}
 	}
 
-	return types.NewRecord(resolvedProps), err
+	return types.NewRecord(out.Properties), nil
 }
 
 func (e *Engine) resolveApplication(ctx context.Context, app *types.Application, varHistory []string) (*types.Object, error) {
-	exp, err := e.resolve(ctx, app.Expression, varHistory)
-	if err != nil {
+	// Every loop or recursion passes through here so this is where a cancelled or timed out query gives up.
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	// Resolve all arguments
-	resolvedArgs := make([]*types.Object, len(app.Arguments))
-	for i, arg := range app.Arguments {
-		resolvedArgs[i], err = e.resolve(ctx, arg, varHistory)
-		if err != nil {
-			return nil, err
+	// Named arguments are resolved after the positional ones.
+	objs := make([]*types.Object, 1, len(app.Arguments)+1)
+	objs[0] = app.Expression
+	var names []string
+	for _, arg := range app.Arguments {
+		if arg != nil && arg.Type() == types.TypeNamedArgument {
+			continue
+		}
+		objs = append(objs, arg)
+	}
+	positional := len(objs)
+	for _, arg := range app.Arguments {
+		if arg != nil && arg.Type() == types.TypeNamedArgument {
+			n, _ := arg.ToNamedArgument()
+			names = append(names, n.Name)
+			objs = append(objs, n.Value)
 		}
 	}
 
-	if exp.Type() == types.TypeFunction {
-		// Execute functions inline.
-		f, _ := exp.ToFunction()
-		return f(resolvedArgs)
+	// Resolve the expression and all arguments
+	resolved, spread, err := e.resolveElements(ctx, objs, varHistory)
+	if err != nil {
+		return nil, err
 	}
-
-	if exp.Type() != types.TypeLambda {
-		return nil, fmt.Errorf("attempt to call non-callable: %s", exp.Type())
+	if spread[0] {
+		return nil, errors.New("cannot call a spread")
 	}
-
-	// Bind any arguments for lambdas
-	l, _ := exp.ToLambda()
-	varMap := make(map[string]*types.Object)
-	for i, varName := range l.FreeVariables {
-		if i >= len(resolvedArgs) {
-			return nil, fmt.Errorf("incomplete var set provided. missing: %v", l.FreeVariables[i:])
+	for i := range names {
+		if spread[positional+i] {
+			return nil, fmt.Errorf("cannot spread into named argument `%s`", names[i])
 		}
-		varMap[normaliseVarName(varName)] = resolvedArgs[i]
 	}
-
-	bound, err := bindVariables(l.Expression, varMap)
+	resolvedArgs, err := spreadList(resolved[1:positional], spread[1:positional])
 	if err != nil {
 		return nil, err
 	}
+	named := make([]*types.NamedArgument, len(names))
+	for i, name := range names {
+		named[i] = &types.NamedArgument{
+			Name:  name,
+			Value: resolved[positional+i],
+		}
+	}
+
+	var varName string
+	if v, err := app.Expression.ToVariable(); err == nil {
+		varName = v.Name
+	}
 
-	return e.resolve(ctx, bound, varHistory)
+	return e.call(ctx, resolved[0], varName, resolvedArgs, named, varHistory)
 }
 
 func bindVariables(obj *types.Object, varMap map[string]*types.Object) (*types.Object, error) {
@@ -333,6 +582,8 @@ func bindVariables(obj *types.Object, varMap map[string]*types.Object) (*types.O
 		}
 
 		return types.NewApplication(exp, args), nil
+	case types.TypeBlank:
+		return obj, nil
 	case types.TypeBoolean:
 		return obj, nil
 	case types.TypeFunction:
@@ -340,19 +591,22 @@ func bindVariables(obj *types.Object, varMap map[string]*types.Object) (*types.O
 	case types.TypeLambda:
 		l, _ := obj.ToLambda()
 
-		// Create a copy of the varMap but without vars defined in this lambda. This allows "shadowing".
-		subMap := make(map[string]*types.Object)
-		for k, v := range varMap {
-			subMap[k] = v
+		// The lambda captures the values of the variables it refers to rather than having them substituted into its
+		// body so it can still be displayed as it was written. Parameters of the lambda aren't captured, which allows
+		// "shadowing".
+		bindings := make(map[string]*types.Object)
+		for k, v := range l.Bindings {
+			bindings[k] = v
 		}
-		for _, v := range l.FreeVariables {
-			delete(subMap, v)
+		for _, name := range referencedNames(obj) {
+			if value, ok := varMap[name]; ok {
+				bindings[name] = value
+			}
 		}
-		exp, err := bindVariables(l.Expression, subMap)
-		if err != nil {
-			return nil, err
+		if len(bindings) == 0 {
+			return obj, nil
 		}
-		return types.NewLambda(l.FreeVariables, exp), nil
+		return types.NewClosure(l.FreeVariables, l.RestVariable, l.Defaults, l.Expression, bindings), nil
 	case types.TypeList:
 		l, _ := obj.ToList()
 		elements := make([]*types.Object, len(l.Elements))
@@ -364,20 +618,42 @@ func bindVariables(obj *types.Object, varMap map[string]*types.Object) (*types.O
 			}
 		}
 		return types.NewList(elements), nil
+	case types.TypeMoney:
+		return obj, nil
+	case types.TypeNamedArgument:
+		n, _ := obj.ToNamedArgument()
+		bound, err := bindVariables(n.Value, varMap)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewNamedArgument(n.Name, bound), nil
 	case types.TypeNumber:
 		return obj, nil
 	case types.TypeRecord:
 		r, _ := obj.ToRecord()
-		props := make(map[string]*types.Object)
-		var err error
-		for k, v := range r.Properties {
-			props[k], err = bindVariables(v, varMap)
+		props := make([]*types.RecordProperty, len(r.Properties))
+		for i, prop := range r.Properties {
+			v, err := bindVariables(prop.Value, varMap)
 			if err != nil {
 				return nil, err
 			}
+			props[i] = &types.RecordProperty{
+				Name:  prop.Name,
+				Value: v,
+			}
 		}
 
 		return types.NewRecord(props), nil
+	case types.TypeSpread:
+		v, _ := obj.ToSpread()
+		bound, err := bindVariables(v, varMap)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewSpread(bound), nil
+	case types.TypeRange:
+		// Ranges always refer to the page's grid.
+		return obj, nil
 	case types.TypeString:
 		return obj, nil
 	case types.TypeVariable:
@@ -391,36 +667,198 @@ func bindVariables(obj *types.Object, varMap map[string]*types.Object) (*types.O
 	}
 }
 
-func findBuiltinVariable(varName string) *types.Object {
-	switch normaliseVarName(varName) {
-	case "concatenate":
-		return types.NewFunction(std.Concatenate)
-	case "equal":
-		return types.NewFunction(std.Equal)
-	case "if":
-		return types.NewFunction(std.If)
-	case "list":
-		return types.NewFunction(std.List)
-	case "love":
-		return types.NewFunction(std.Love)
-	case "not":
-		return types.NewFunction(std.Not)
-	case "sum":
-		return types.NewFunction(std.Sum)
-	default:
-		return nil
-	}
+type builtin struct {
+	f  types.Function
+	hf types.HigherOrderFunction
+	rf types.RandomFunction
+	// Builtins with params accept named arguments and have their arity checked before they're called.
+	params []types.Param
 }
 
-func normaliseVarName(name string) string {
-	return strings.ToLower(name)
+// builtinFunctions maps the normalised name of each builtin to its implementation.
+var builtinFunctions = map[string]builtin{
+	"add":         {f: matrix.Add, params: params("a", "b")},
+	"amortize":    {f: finance.Amortize, params: params("rate", "nper", "pv")},
+	"average":     {f: stats.Average},
+	"column":      {f: std.Column, params: params("table", "name")},
+	"compose":     {f: std.Compose},
+	"concatenate": {f: std.Concatenate},
+	"convert":     {f: units.Convert, params: params("number", "unit")},
+	"correl":      {f: stats.Correl, params: params("xs", "ys")},
+	"count":       {f: stats.Count},
+	"countif":     {hf: stats.CountIf, params: params("values", "criterion")},
+	"covar":       {f: stats.Covar, params: params("xs", "ys")},
+	"ddb": {f: finance.Ddb, params: []types.Param{
+		{Name: "cost"},
+		{Name: "salvage"},
+		{Name: "life"},
+		{Name: "period"},
+		{Name: "factor", Default: types.NewNumber(2)},
+	}},
+	"divide": {f: matrix.Divide, params: params("a", "b")},
+	"dot":    {f: matrix.Dot, params: params("a", "b")},
+	"equal":  {f: std.Equal, params: params("a", "b")},
+	"fv": {f: finance.Fv, params: []types.Param{
+		{Name: "rate"},
+		{Name: "nper"},
+		{Name: "pmt"},
+		{Name: "pv", Default: types.NewNumber(0)},
+		{Name: "type", Default: types.NewNumber(0)},
+	}},
+	"fx": {f: money.Fx, params: params("amount", "currency", "rates")},
+	"get": {f: std.Get, params: []types.Param{
+		{Name: "record"},
+		{Name: "key"},
+		{Name: "default", Optional: true},
+	}},
+	"groupby": {hf: std.GroupBy, params: []types.Param{
+		{Name: "table"},
+		{Name: "by"},
+		{Name: "aggregates", Optional: true},
+	}},
+	"histogram": {f: stats.Histogram, params: params("values", "buckets")},
+	"if":        {f: std.If, params: params("condition", "then", "else")},
+	"irr": {f: finance.Irr, params: []types.Param{
+		{Name: "values"},
+		{Name: "guess", Default: types.NewNumber(0.1)},
+	}},
+	"join": {f: std.Join, params: []types.Param{
+		{Name: "left"},
+		{Name: "right"},
+		{Name: "on"},
+		{Name: "how", Default: types.NewString("inner")},
+	}},
+	"keys":      {f: std.Keys, params: params("record")},
+	"linest":    {f: stats.Linest, params: params("ys", "xs")},
+	"list":      {f: std.List},
+	"lognormal": {rf: random.Lognormal, params: params("mu", "sigma")},
+	"love":      {f: std.Love, params: params("name")},
+	"lookup": {f: std.Lookup, params: []types.Param{
+		{Name: "table"},
+		{Name: "column"},
+		{Name: "value"},
+		{Name: "result", Optional: true},
+	}},
+	"mdeterm":  {f: matrix.MDeterm, params: params("matrix")},
+	"median":   {f: stats.Median},
+	"merge":    {f: std.Merge},
+	"minverse": {f: matrix.MInverse, params: params("matrix")},
+	"mmult":    {f: matrix.MMult, params: params("a", "b")},
+	"money":    {f: money.Money, params: params("amount", "currency")},
+	"mode":     {f: stats.Mode},
+	"multiply": {f: matrix.Multiply, params: params("a", "b")},
+	"normal":   {rf: random.Normal, params: params("mu", "sigma")},
+	"not":      {f: std.Not, params: params("value")},
+	"nper": {f: finance.Nper, params: []types.Param{
+		{Name: "rate"},
+		{Name: "pmt"},
+		{Name: "pv"},
+		{Name: "fv", Default: types.NewNumber(0)},
+		{Name: "type", Default: types.NewNumber(0)},
+	}},
+	"npv":  {f: finance.Npv},
+	"omit": {f: std.Omit},
+	"orderby": {f: std.OrderBy, params: []types.Param{
+		{Name: "table"},
+		{Name: "by"},
+		{Name: "descending", Default: types.NewBoolean(false)},
+	}},
+	// PARTIAL is handled by the engine since it binds named arguments.
+	partialName:  {},
+	"percentile": {f: stats.Percentile, params: params("values", "k")},
+	"pick":       {f: std.Pick},
+	"pivot": {hf: std.Pivot, params: []types.Param{
+		{Name: "table"},
+		{Name: "rows"},
+		{Name: "columns"},
+		{Name: "values"},
+		{Name: "aggregate", Optional: true},
+	}},
+	"pmt": {f: finance.Pmt, params: []types.Param{
+		{Name: "rate"},
+		{Name: "nper"},
+		{Name: "pv"},
+		{Name: "fv", Default: types.NewNumber(0)},
+		{Name: "type", Default: types.NewNumber(0)},
+	}},
+	"pv": {f: finance.Pv, params: []types.Param{
+		{Name: "rate"},
+		{Name: "nper"},
+		{Name: "pmt"},
+		{Name: "fv", Default: types.NewNumber(0)},
+		{Name: "type", Default: types.NewNumber(0)},
+	}},
+	"quartile": {f: stats.Quartile, params: params("values", "quart")},
+	"query":    {f: sql.Query, params: params("data", "query")},
+	"rate": {f: finance.Rate, params: []types.Param{
+		{Name: "nper"},
+		{Name: "pmt"},
+		{Name: "pv"},
+		{Name: "fv", Default: types.NewNumber(0)},
+		{Name: "type", Default: types.NewNumber(0)},
+		{Name: "guess", Default: types.NewNumber(0.1)},
+	}},
+	"round": {f: std.Round, params: []types.Param{
+		{Name: "number"},
+		{Name: "digits", Default: types.NewNumber(0)},
+	}},
+	"select": {f: std.Select},
+	"set":    {f: std.Set, params: params("record", "key", "value")},
+	// SIMULATE is handled by the engine since each trial resolves its expression afresh.
+	simulateName: {params: []types.Param{
+		{Name: "expr"},
+		{Name: "n"},
+		{Name: "seed", Optional: true},
+	}},
+	"sln":        {f: finance.Sln, params: params("cost", "salvage", "life")},
+	"solve":      {f: matrix.Solve, params: params("a", "b")},
+	"stdev":      {f: stats.Stdev},
+	"stdevp":     {f: stats.Stdevp},
+	"subtract":   {f: matrix.Subtract, params: params("a", "b")},
+	"sum":        {f: std.Sum},
+	"transpose":  {f: matrix.Transpose, params: params("matrix")},
+	"triangular": {rf: random.Triangular, params: params("low", "mode", "high")},
+	"uniform":    {rf: random.Uniform, params: params("low", "high")},
+	"unit":       {f: units.Unit, params: params("number", "unit")},
+	"values":     {f: std.Values, params: params("record")},
+	"var":        {f: stats.Var},
+	"varp":       {f: stats.Varp},
+	"where":      {hf: std.Where, params: params("table", "predicate")},
+	"xirr": {f: finance.Xirr, params: []types.Param{
+		{Name: "values"},
+		{Name: "dates"},
+		{Name: "guess", Default: types.NewNumber(0.1)},
+	}},
+	"xlookup": {f: std.XLookup, params: params("value", "keys", "results")},
+	"xnpv":    {f: finance.Xnpv, params: params("rate", "values", "dates")},
+}
+
+// params describes required params with the given names.
+func params(names ...string) []types.Param {
+	out := make([]types.Param, len(names))
+	for i, name := range names {
+		out[i] = types.Param{Name: name}
+	}
+
+	return out
 }
 
-func setContextPageId(ctx context.Context, pageId string) context.Context {
-	return context.WithValue(ctx, contextKeyPageId, pageId)
+func findBuiltinVariable(varName string) *types.Object {
+	name := strings.ToLower(varName)
+	b, ok := builtinFunctions[name]
+	if !ok {
+		return nil
+	}
+
+	if b.hf != nil {
+		return types.NewHigherOrderFunction(strings.ToUpper(name), b.hf, b.params...)
+	}
+	if b.rf != nil {
+		return types.NewRandomFunction(strings.ToUpper(name), b.rf, b.params...)
+	}
+	return types.NewFunction(strings.ToUpper(name), b.f, b.params...)
 }
 
-func getContextPageId(ctx context.Context) (string, bool) {
-	pageId, ok := ctx.Value(contextKeyPageId).(string)
-	return pageId, ok
+func normaliseVarName(name string) string {
+	return parsing.NormaliseName(name)
 }
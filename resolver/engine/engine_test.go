@@ -2,8 +2,17 @@ package engine
 
 import (
 	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/std"
 	"github.com/tobyjsullivan/chalk/resolver/engine/types"
 
 	"github.com/tobyjsullivan/chalk/monolith"
@@ -47,6 +56,14 @@ func (*fakeVarSvc) UpdateVariable(context.Context, *monolith.UpdateVariableReque
 	return &monolith.UpdateVariableResponse{}, nil
 }
 
+func (*fakeVarSvc) SetCell(context.Context, *monolith.SetCellRequest, ...grpc.CallOption) (*monolith.SetCellResponse, error) {
+	return &monolith.SetCellResponse{}, nil
+}
+
+func (*fakeVarSvc) WatchVariables(context.Context, *monolith.WatchVariablesRequest, ...grpc.CallOption) (monolith.Variables_WatchVariablesClient, error) {
+	return nil, errors.New("not implemented")
+}
+
 func TestQuery(t *testing.T) {
 	fakeVarSvc := &fakeVarSvc{}
 	req := "SUM(1, 2, 3)"
@@ -181,3 +198,1248 @@ func TestNumberNegative(t *testing.T) {
 		t.Errorf("Expected -34.9; got %f", n)
 	}
 }
+
+type pageVarSvc struct {
+	fakeVarSvc
+
+	mx        sync.Mutex
+	variables []*monolith.Variable
+	findCalls int
+	watchers  []chan *monolith.VariableEvent
+}
+
+func newPageVarSvc(formulas map[string]string) *pageVarSvc {
+	svc := &pageVarSvc{}
+	for name, formula := range formulas {
+		svc.variables = append(svc.variables, &monolith.Variable{
+			VariableId: name,
+			Page:       "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee",
+			Name:       name,
+			Formula:    formula,
+		})
+	}
+	return svc
+}
+
+func (s *pageVarSvc) FindVariables(ctx context.Context, in *monolith.FindVariablesRequest, opts ...grpc.CallOption) (*monolith.FindVariablesResponse, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.findCalls++
+
+	if len(in.Names) == 0 {
+		return &monolith.FindVariablesResponse{Values: s.variables}, nil
+	}
+
+	var out []*monolith.Variable
+	for _, name := range in.Names {
+		for _, v := range s.variables {
+			if v.Name == name {
+				out = append(out, v)
+			}
+		}
+	}
+	return &monolith.FindVariablesResponse{Values: out}, nil
+}
+
+func (s *pageVarSvc) WatchVariables(ctx context.Context, in *monolith.WatchVariablesRequest, opts ...grpc.CallOption) (monolith.Variables_WatchVariablesClient, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	events := make(chan *monolith.VariableEvent, 10)
+	s.watchers = append(s.watchers, events)
+
+	return &fakeWatchStream{
+		ctx:    ctx,
+		events: events,
+	}, nil
+}
+
+// update replaces a variable and notifies any watchers.
+func (s *pageVarSvc) update(name, newName, formula string) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	for i, v := range s.variables {
+		if v.Name != name {
+			continue
+		}
+
+		updated := &monolith.Variable{
+			VariableId: v.VariableId,
+			Page:       v.Page,
+			Name:       newName,
+			Formula:    formula,
+		}
+		s.variables[i] = updated
+
+		event := &monolith.VariableEvent{
+			Type:     monolith.VariableEventType_UPDATED,
+			Variable: updated,
+		}
+		if newName != name {
+			event.Type = monolith.VariableEventType_RENAMED
+			event.PreviousName = name
+		}
+		for _, w := range s.watchers {
+			w <- event
+		}
+	}
+}
+
+func (s *pageVarSvc) calls() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.findCalls
+}
+
+func TestQuery_PrefetchesVariablesInBatches(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"a": "SUM(b, b, c)",
+		"b": "c",
+		"c": "2",
+	})
+
+	e := NewEngine(svc)
+	res, err := e.Query(context.Background(), "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", "LIST(a, a, b)")
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+
+	l, err := res.ToList()
+	if err != nil {
+		t.Fatal("Unexpected cast error:", err)
+	}
+	if n := len(l.Elements); n != 3 {
+		t.Fatalf("Expected 3 elements; found %d", n)
+	}
+	if n, _ := l.Elements[0].ToNumber(); n != 6 {
+		t.Errorf("Unexpected element value: %f", n)
+	}
+
+	// One round for {list, a, b} and another for {sum, c}.
+	if n := svc.calls(); n != 2 {
+		t.Errorf("Expected 2 calls to FindVariables; found %d", n)
+	}
+}
+
+func TestQuery_UndefinedVariable(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{})
+
+	e := NewEngine(svc)
+	_, err := e.Query(context.Background(), "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", "[missing, missing]")
+	if err == nil || err.Error() != "variable `missing` is not defined" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if n := svc.calls(); n != 1 {
+		t.Errorf("Expected 1 call to FindVariables; found %d", n)
+	}
+}
+
+func TestEngine_ParseFormulaIsMemoised(t *testing.T) {
+	e := NewEngine(&fakeVarSvc{})
+
+	first, err := e.parseFormula("SUM(1, 2)")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	second, err := e.parseFormula("SUM(1, 2)")
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if first != second {
+		t.Error("Expected the cached object to be reused")
+	}
+}
+
+func TestQuery_ParallelMatchesSequential(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"a":     "SUM(b, c, 1)",
+		"b":     "SUM(c, c)",
+		"c":     "3",
+		"names": "[\"x\", CONCATENATE(\"y\", \"z\"), LOVE(\"you\")]",
+	})
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+	req := "[a, b, LIST(a, b, c), { total = SUM(a, b, c), names = names, flag = NOT(EQUAL(a, b)) }]"
+
+	expected, err := NewEngine(svc).Query(context.Background(), pageId, req)
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+
+	e := NewEngine(svc, WithParallelism(8))
+	for i := 0; i < 50; i++ {
+		res, err := e.Query(context.Background(), pageId, req)
+		if err != nil {
+			t.Fatalf("Unexpected error response: %s", err)
+		}
+
+		eq, err := std.Equal([]*types.Object{expected, res})
+		if err != nil {
+			t.Fatal("Unexpected error comparing results:", err)
+		}
+		if b, _ := eq.ToBoolean(); !b {
+			t.Fatalf("Parallel result differs from sequential result on run %d", i)
+		}
+	}
+}
+
+func TestQuery_ParallelReportsFirstErrorByPosition(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"slow": "SUM(a, b, c, d, e, f, g, h)",
+		"a":    "1", "b": "2", "c": "3", "d": "4", "e": "5", "f": "6", "g": "7", "h": "8",
+		"bad": "NOT(1)",
+	})
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	e := NewEngine(svc, WithParallelism(4))
+	for i := 0; i < 50; i++ {
+		_, err := e.Query(context.Background(), pageId, "[slow, SUM(slow, first), bad, second]")
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		if msg := err.Error(); msg != "variable `first` is not defined" {
+			t.Fatalf("Unexpected error on run %d: %s", i, msg)
+		}
+	}
+}
+
+func TestQueryContext_WorkersAreBounded(t *testing.T) {
+	qc := newQueryContext("c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", 3)
+
+	if !qc.acquireWorker() || !qc.acquireWorker() {
+		t.Fatal("Expected two workers to be available")
+	}
+	if qc.acquireWorker() {
+		t.Fatal("Expected the pool to be exhausted")
+	}
+
+	qc.releaseWorker()
+	if !qc.acquireWorker() {
+		t.Error("Expected a released worker to be available again")
+	}
+
+	if newQueryContext("c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", 1).acquireWorker() {
+		t.Error("Expected sequential queries to have no workers")
+	}
+}
+
+func TestQueryPage(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"total": "SUM(a, b)",
+		"a":     "SUM(b, 1)",
+		"b":     "2",
+		"loop1": "loop2",
+		"loop2": "loop1",
+		"bad":   "SUM(",
+	})
+	// Put the page into a known order.
+	sort.Slice(svc.variables, func(i, j int) bool {
+		return svc.variables[i].Name < svc.variables[j].Name
+	})
+
+	e := NewEngine(svc)
+	results, err := e.QueryPage(context.Background(), "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee")
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+
+	if n := svc.calls(); n != 1 {
+		t.Errorf("Expected 1 call to FindVariables; found %d", n)
+	}
+
+	if n := len(results); n != 6 {
+		t.Fatalf("Expected 6 results; found %d", n)
+	}
+
+	expected := map[string]float64{"a": 3, "b": 2, "total": 5}
+	for _, res := range results {
+		name := res.Variable.Name
+		if want, ok := expected[name]; ok {
+			if res.Err != nil {
+				t.Errorf("Unexpected error for %s: %s", name, res.Err)
+				continue
+			}
+			if n, _ := res.Value.ToNumber(); n != want {
+				t.Errorf("Unexpected value for %s: %f", name, n)
+			}
+		} else if res.Err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+}
+
+func TestQuery_Overrides(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"growth":  "0.05",
+		"revenue": "100",
+		"next":    "MULTIPLY(revenue, SUM(1, growth))",
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := []struct {
+		formula   string
+		overrides Overrides
+		expected  string
+	}{
+		{"next", nil, "105"},
+		{"next", Overrides{"growth": "0.08"}, "108"},
+		{"next", Overrides{"GROWTH": "0.08", "revenue": "SUM(base, 50)", "base": "150"}, "216"},
+		{"revenue", Overrides{"growth": "0.08"}, "100"},
+	}
+
+	for _, c := range cases {
+		res, err := e.QueryWithOverrides(context.Background(), pageId, c.formula, c.overrides)
+		if err != nil {
+			t.Fatalf("Unexpected error response for %s with %v: %s", c.formula, c.overrides, err)
+		}
+		if s, _ := FormatObject(res); s != c.expected {
+			t.Errorf("Expected %s with %v to be %s; got %s", c.formula, c.overrides, c.expected, s)
+		}
+	}
+
+	// Overrides don't outlive their query.
+	res, err := e.Query(context.Background(), pageId, "next")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s, _ := FormatObject(res); s != "105" {
+		t.Errorf("Expected next to be 105 without overrides; got %s", s)
+	}
+}
+
+func TestQueryPage_Overrides(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"total": "SUM(a, b)",
+		"a":     "SUM(b, 1)",
+		"b":     "2",
+	})
+
+	e := NewEngine(svc)
+	results, err := e.QueryPageWithOverrides(context.Background(), "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", Overrides{"b": "10"})
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+
+	expected := map[string]float64{"a": 11, "b": 10, "total": 21}
+	for _, res := range results {
+		name := res.Variable.Name
+		if res.Err != nil {
+			t.Errorf("Unexpected error for %s: %s", name, res.Err)
+			continue
+		}
+		if n, _ := res.Value.ToNumber(); n != expected[name] {
+			t.Errorf("Expected %s to be %v; got %v", name, expected[name], n)
+		}
+		if name == "b" && res.Variable.Formula != "10" {
+			t.Errorf("Expected b to report its overriding formula; got %q", res.Variable.Formula)
+		}
+	}
+
+	for _, v := range svc.variables {
+		if v.Name == "b" && v.Formula != "2" {
+			t.Errorf("Expected the page's variable to be left alone; got %q", v.Formula)
+		}
+	}
+}
+
+func TestQueryMany(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"a": "SUM(b, 1)",
+		"b": "2",
+	})
+
+	e := NewEngine(svc)
+	results, err := e.QueryMany(context.Background(), "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", []string{"a", "SUM(a, b)", "[", "missing"})
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+
+	if n := len(results); n != 4 {
+		t.Fatalf("Expected 4 results; found %d", n)
+	}
+	if n, _ := results[0].Value.ToNumber(); n != 3 {
+		t.Errorf("Unexpected value for first formula: %f", n)
+	}
+	if n, _ := results[1].Value.ToNumber(); n != 5 {
+		t.Errorf("Unexpected value for second formula: %f", n)
+	}
+	if results[2].Err == nil {
+		t.Error("Expected a parse error for third formula")
+	}
+	if err := results[3].Err; err == nil || err.Error() != "variable `missing` is not defined" {
+		t.Errorf("Unexpected error for fourth formula: %v", err)
+	}
+}
+
+func TestDependencyGraph_TopologicalOrder(t *testing.T) {
+	e := NewEngine(&fakeVarSvc{})
+	g := e.buildDependencyGraph([]*monolith.Variable{
+		{Name: "total", Formula: "SUM(a, b)"},
+		{Name: "a", Formula: "SUM(b, 1)"},
+		{Name: "loop", Formula: "loop"},
+		{Name: "b", Formula: "2"},
+	})
+
+	order := g.topologicalOrder()
+	expected := []string{"b", "a", "total", "loop"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected order %v; found %v", expected, order)
+	}
+}
+
+type fakeWatchStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events chan *monolith.VariableEvent
+}
+
+func (s *fakeWatchStream) Recv() (*monolith.VariableEvent, error) {
+	select {
+	case event := <-s.events:
+		return event, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func TestWatchPage(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"a": "SUM(b, 1)",
+		"b": "2",
+		"c": "7",
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	updates := make(chan []*VariableResult)
+	done := make(chan error)
+	e := NewEngine(svc)
+	go func() {
+		done <- e.WatchPage(ctx, "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", func(results []*VariableResult) error {
+			updates <- results
+			return nil
+		})
+	}()
+
+	if initial := <-updates; len(initial) != 3 {
+		t.Fatalf("Expected 3 initial results; found %d", len(initial))
+	}
+
+	// Rapid edits are combined into one update covering the variable and its dependents only.
+	svc.update("b", "b", "10")
+	svc.update("b", "b", "20")
+	values := make(map[string]float64)
+	for _, res := range <-updates {
+		if res.Err != nil {
+			t.Fatalf("Unexpected error for %s: %s", res.Variable.Name, res.Err)
+		}
+		values[res.Variable.Name], _ = res.Value.ToNumber()
+	}
+	if expected := map[string]float64{"a": 21, "b": 20}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected update %v; found %v", expected, values)
+	}
+
+	// Renaming a variable affects everything which referred to the old name.
+	svc.update("b", "d", "20")
+	errs := make(map[string]bool)
+	for _, res := range <-updates {
+		errs[res.Variable.Name] = res.Err != nil
+	}
+	if expected := map[string]bool{"a": true, "d": false}; !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Expected update %v; found %v", expected, errs)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Expected watch to end cleanly; found %s", err)
+	}
+}
+
+func TestQuery_BuiltinIsNamedFunction(t *testing.T) {
+	e := NewEngine(&fakeVarSvc{})
+	res, err := e.Query(context.Background(), "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", "sum")
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+
+	name, err := res.FunctionName()
+	if err != nil {
+		t.Fatal("Unexpected cast error:", err)
+	}
+	if name != "SUM" {
+		t.Errorf("Expected function named SUM; got %q", name)
+	}
+}
+
+func TestQuery_LambdaCapturesBindings(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"adder": "(x) => (y) => SUM(x, y, offset)",
+		"add2":  "adder(2)",
+	})
+
+	e := NewEngine(svc)
+	res, err := e.Query(context.Background(), "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", "add2")
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+
+	l, err := res.ToLambda()
+	if err != nil {
+		t.Fatal("Unexpected cast error:", err)
+	}
+
+	body, err := FormatObject(l.Expression)
+	if err != nil {
+		t.Fatalf("Unexpected error formatting body: %s", err)
+	}
+	if body != "SUM(x, y, offset)" {
+		t.Errorf("Expected body to be printed as written; got %q", body)
+	}
+
+	if n := len(l.Bindings); n != 1 {
+		t.Fatalf("Expected exactly 1 binding; found %d", n)
+	}
+	if x, err := l.Bindings["x"].ToNumber(); err != nil || x != 2 {
+		t.Errorf("Expected x to be bound to 2; got %v", l.Bindings["x"])
+	}
+
+	// Calling the closure uses its bindings.
+	res, err = e.Query(context.Background(), "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", "add2(3)")
+	if err == nil {
+		t.Fatal("Expected error for undefined variable `offset`")
+	}
+	svc.variables = append(svc.variables, &monolith.Variable{Name: "offset", Formula: "10"})
+	res, err = e.Query(context.Background(), "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", "add2(3)")
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+	if n, _ := res.ToNumber(); n != 15 {
+		t.Errorf("Expected 15; got %v", res)
+	}
+}
+
+func TestFormatObject(t *testing.T) {
+	obj := types.NewList([]*types.Object{
+		types.NewNumber(-1.5),
+		types.NewString("a \"b\""),
+		types.NewFunction("SUM", std.Sum),
+		types.NewRecord([]*types.RecordProperty{
+			{Name: "a", Value: types.NewLambda([]string{"x"}, types.NewApplication(types.NewVariable("NOT"), []*types.Object{types.NewVariable("x")}))},
+			{Name: "b", Value: types.NewBoolean(true)},
+		}),
+	})
+
+	s, err := FormatObject(obj)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := `[-1.5, "a \"b\"", SUM, {a = (x) => NOT(x), b = true}]`
+	if s != expected {
+		t.Errorf("Expected %s; got %s", expected, s)
+	}
+}
+
+func TestQuery_RecordKeepsDefinitionOrder(t *testing.T) {
+	e := NewEngine(&fakeVarSvc{})
+	res, err := e.Query(context.Background(), "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee", `SET({zebra = 1, apple = 2, mango = var1}, "banana", 4)`)
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+
+	rec, err := res.ToRecord()
+	if err != nil {
+		t.Fatal("Unexpected cast error:", err)
+	}
+	if keys := rec.Keys(); !reflect.DeepEqual(keys, []string{"zebra", "apple", "mango", "banana"}) {
+		t.Errorf("Unexpected property order: %v", keys)
+	}
+}
+
+func TestQuery_Spread(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"xs":    "[1, 2, 3]",
+		"base":  "{name = \"base\", rate = 0.1, term = 12}",
+		"total": "(first, ...rest) => IF(EQUAL(rest, []), first, SUM(first, ...rest))",
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	res, err := e.Query(context.Background(), pageId, "[0, ...xs, 4]")
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+	if s, _ := FormatObject(res); s != "[0, 1, 2, 3, 4]" {
+		t.Errorf("Unexpected list: %s", s)
+	}
+
+	res, err = e.Query(context.Background(), pageId, "{...base, rate = 0.2}")
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+	if s, _ := FormatObject(res); s != `{name = "base", rate = 0.2, term = 12}` {
+		t.Errorf("Unexpected record: %s", s)
+	}
+
+	res, err = e.Query(context.Background(), pageId, "total(...xs, 4)")
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+	if n, _ := res.ToNumber(); n != 10 {
+		t.Errorf("Expected 10; got %v", res)
+	}
+
+	res, err = e.Query(context.Background(), pageId, "((first, ...rest) => rest)(1)")
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+	if s, _ := FormatObject(res); s != "[]" {
+		t.Errorf("Expected empty rest list; got %s", s)
+	}
+}
+
+func TestQuery_SpreadErrors(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"xs":   "[1, 2, 3]",
+		"base": "{rate = 0.1}",
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := map[string]string{
+		"[...base]":         "cannot spread a record into a list; expected a list",
+		"{...xs}":           "cannot spread a list into a record; expected a record",
+		"SUM(...5)":         "cannot spread a number into a list; expected a list",
+		"...xs":             "`...` can only be used within lists, records, arguments and lambda params",
+		"(...rest, x) => x": "rest parameter must be the last lambda param",
+	}
+	for formula, expected := range cases {
+		_, err := e.Query(context.Background(), pageId, formula)
+		if err == nil || err.Error() != expected {
+			t.Errorf("Expected error %q for %s; got %v", expected, formula, err)
+		}
+	}
+}
+
+func TestQuery_NamedArguments(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"rate":     "0.1",
+		"interest": "(principal, years = 1, r = rate) => ROUND(SUM(principal, r, years), digits = 2)",
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := map[string]float64{
+		"ROUND(3.14159, digits = 2)":             3.14,
+		"ROUND(3.5)":                             4,
+		"ROUND(digits = 1, number = 2.25)":       2.3,
+		"interest(100)":                          101.1,
+		"interest(100, 2)":                       102.1,
+		"interest(100, r = 1)":                   102,
+		"((x, y = x) => SUM(x, y))(4)":           8,
+		"PARTIAL(ROUND, digits = 1)(2.449)":      2.4,
+		"PARTIAL(interest, r = 0)(100, 3)":       103,
+		"PARTIAL(PARTIAL(SUM, 1), 2)(3, 4)":      10,
+		"GET({a = 1}, key = \"b\", default = 5)": 5,
+	}
+	for formula, expected := range cases {
+		res, err := e.Query(context.Background(), pageId, formula)
+		if err != nil {
+			t.Errorf("Unexpected error response for %s: %s", formula, err)
+			continue
+		}
+		if n, _ := res.ToNumber(); n != expected {
+			t.Errorf("Expected %s to be %v; got %v", formula, expected, n)
+		}
+	}
+	res, err := e.Query(context.Background(), pageId, "PARTIAL(ROUND, digits = 1)")
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+	if s, _ := FormatObject(res); s != "(...$args) => ROUND(...$args, digits = 1)" {
+		t.Errorf("Unexpected partial: %s", s)
+	}
+}
+
+func TestQuery_ForwardedArguments(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"args": "100",
+		"f":    "(x) => SUM(x, args)",
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	for _, formula := range []string{"f(1)", "PARTIAL(f)(1)", "COMPOSE(f)(1)"} {
+		res, err := e.Query(context.Background(), pageId, formula)
+		if err != nil {
+			t.Fatalf("Unexpected error response for %s: %s", formula, err)
+		}
+		if n, _ := res.ToNumber(); n != 101 {
+			t.Errorf("Expected %s to be 101; got %v", formula, n)
+		}
+	}
+}
+
+func TestQuery_ArgumentErrors(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"f": "(a, b = 1) => SUM(a, b)",
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := map[string]string{
+		"f()":                       "lambda `f` is missing argument `a`",
+		"f(1, 2, 3)":                "lambda `f` expects at most 2 arguments; received 3",
+		"f(1, c = 2)":               "lambda `f` has no param named `c`",
+		"f(1, a = 2)":               "lambda `f` received more than one value for `a`",
+		"((x) => x)(1, 2)":          "lambda expects 1 argument; received 2",
+		"NOT(true, false)":          "NOT expects 1 argument; received 2",
+		"SUM(1, x = 2)":             "SUM does not accept named arguments",
+		"f(a = 1, 2)":               "positional argument cannot follow named argument `a`",
+		"(a = 1, b) => a":           "lambda params without a default must come before those with one",
+		"(x = 1)":                   "named arguments can only be used when calling a function or lambda",
+		"PARTIAL(ROUND, 1.5)(2, 3)": "ROUND expects at most 2 arguments; received 3",
+	}
+	for formula, expected := range cases {
+		_, err := e.Query(context.Background(), pageId, formula)
+		if err == nil || err.Error() != expected {
+			t.Errorf("Expected error %q for %s; got %v", expected, formula, err)
+		}
+	}
+}
+
+func TestQuery_Pipe(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"double": "(x) => SUM(x, x)",
+		"add":    "(x, y) => SUM(x, y)",
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := map[string]float64{
+		"3 |> double":                             6,
+		"3 |> add(4) |> double":                   14,
+		"3.14159 |> ROUND(digits = 2)":            3.14,
+		"[1, 2] |> ((xs) => SUM(...xs))":          3,
+		"1 |> (PARTIAL(add, 10))":                 11,
+		"COMPOSE(double, add)(1, 2)":              6,
+		"COMPOSE(ROUND, double, double)(1.3)":     5,
+		"2 |> (COMPOSE(double, PARTIAL(add, 1)))": 6,
+	}
+	for formula, expected := range cases {
+		res, err := e.Query(context.Background(), pageId, formula)
+		if err != nil {
+			t.Errorf("Unexpected error response for %s: %s", formula, err)
+			continue
+		}
+		if n, _ := res.ToNumber(); n != expected {
+			t.Errorf("Expected %s to be %v; got %v", formula, expected, n)
+		}
+	}
+}
+
+func TestQuery_Table(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"sales": `[{region = "west", amount = 10}, {region = "east", amount = 20}, {region = "west", amount = 5}]`,
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	res, err := e.Query(context.Background(), pageId, `sales
+		|> WHERE((row) => NOT(EQUAL(GET(row, "amount"), 20)))
+		|> GROUPBY("region", {total = (rows) => SUM(...COLUMN(rows, "amount"))})`)
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+
+	if s, _ := FormatObject(res); s != `[{region = "west", total = 15}]` {
+		t.Errorf("Unexpected table: %s", s)
+	}
+}
+
+func TestQuery_SQL(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"sales": `[{region = "west", amount = 10}, {region = "east", amount = 20}, {region = "west", amount = 5}]`,
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	res, err := e.Query(context.Background(), pageId, `QUERY(sales, "SELECT region, SUM(amount) AS total GROUP BY region ORDER BY total")`)
+	if err != nil {
+		t.Fatalf("Unexpected error response: %s", err)
+	}
+	if s, _ := FormatObject(res); s != `[{region = "west", total = 15}, {region = "east", total = 20}]` {
+		t.Errorf("Unexpected result: %s", s)
+	}
+}
+
+func TestQuery_Stats(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"sales": `[{region = "west", amount = 10}, {region = "east", amount = 20}, {region = "west", amount = 6}]`,
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := map[string]string{
+		`AVERAGE(COLUMN(sales, "amount"))`:                     "12",
+		`sales |> COLUMN("amount") |> MEDIAN`:                  "10",
+		`COUNTIF(COLUMN(sales, "region"), "west")`:             "2",
+		`COUNTIF(COLUMN(sales, "amount"), (x) => EQUAL(x, 6))`: "1",
+		`LINEST([3, 5, 7], [1, 2, 3])`:                         "{slope = 2, intercept = 1, r2 = 1}",
+	}
+
+	for formula, expected := range cases {
+		res, err := e.Query(context.Background(), pageId, formula)
+		if err != nil {
+			t.Fatalf("Unexpected error response for %s: %s", formula, err)
+		}
+		if s, _ := FormatObject(res); s != expected {
+			t.Errorf("Expected %s to be %s; got %s", formula, expected, s)
+		}
+	}
+}
+
+func TestQuery_Finance(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"loan": `{amount = 8000, months = 48}`,
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := map[string]string{
+		`ROUND(PMT(0.01, 12, 10000), 2)`:                                 "-888.49",
+		`ROUND(FV(0.005, 10, -200, -500, type = 1), 2)`:                  "2581.4",
+		`ROUND(RATE(GET(loan, "months"), -200, GET(loan, "amount")), 6)`: "0.007701",
+		`SLN(30000, 7500, 10)`:                                           "2250",
+	}
+
+	for formula, expected := range cases {
+		res, err := e.Query(context.Background(), pageId, formula)
+		if err != nil {
+			t.Fatalf("Unexpected error response for %s: %s", formula, err)
+		}
+		if s, _ := FormatObject(res); s != expected {
+			t.Errorf("Expected %s to be %s; got %s", formula, expected, s)
+		}
+	}
+}
+
+func TestQuery_Matrix(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"coefficients": `[[2, 1], [1, 3]]`,
+		"totals":       `[3, 5]`,
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := map[string]string{
+		`SOLVE(coefficients, totals)`:                  "[0.8, 1.4]",
+		`MMULT(coefficients, TRANSPOSE([[1, 1]]))`:     "[[3], [4]]",
+		`coefficients |> MULTIPLY(2) |> ADD([0, 0.5])`: "[[4, 2.5], [2, 6.5]]",
+		`MDETERM(coefficients)`:                        "5",
+		`DOT(totals, totals)`:                          "34",
+	}
+
+	for formula, expected := range cases {
+		res, err := e.Query(context.Background(), pageId, formula)
+		if err != nil {
+			t.Fatalf("Unexpected error response for %s: %s", formula, err)
+		}
+		if s, _ := FormatObject(res); s != expected {
+			t.Errorf("Expected %s to be %s; got %s", formula, expected, s)
+		}
+	}
+}
+
+func TestQuery_Units(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"distance": `25 km`,
+		"duration": `UNIT(2, "h")`,
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := map[string]string{
+		`DIVIDE(distance, duration)`:                          "12.5 km/h",
+		`CONVERT(distance, "mi") |> ROUND(2)`:                 "15.53 mi",
+		`SUM(distance, 500 m, 1 mi)`:                          "27.109344 km",
+		`MULTIPLY([1 h, 30 min], DIVIDE(distance, duration))`: "[12.5 km, 6.25 km]",
+		`DIVIDE(distance, 5 km)`:                              "5",
+	}
+
+	for formula, expected := range cases {
+		res, err := e.Query(context.Background(), pageId, formula)
+		if err != nil {
+			t.Fatalf("Unexpected error response for %s: %s", formula, err)
+		}
+		if s, _ := FormatObject(res); s != expected {
+			t.Errorf("Expected %s to be %s; got %s", formula, expected, s)
+		}
+	}
+
+	_, err := e.Query(context.Background(), pageId, `ADD(distance, duration)`)
+	if err == nil || !strings.Contains(err.Error(), "cannot add km and h") {
+		t.Errorf("Expected an error adding incompatible units; got %v", err)
+	}
+}
+
+func TestQuery_Money(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"rates":    `{USD = 1, EUR = 0.8}`,
+		"rent":     `1200.00 EUR`,
+		"software": `MONEY("49.99", "USD")`,
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := map[string]string{
+		`rent`:                                    "1200.00 EUR",
+		`MULTIPLY(software, 12)`:                  "599.88 USD",
+		`SUM(FX(rent, "USD", rates), software)`:   "1549.99 USD",
+		`SUM(...[0.1 USD, 0.2 USD], 0.3 USD)`:     "0.60 USD",
+		`EQUAL(FX(rent, "USD", rates), 1500 USD)`: "true",
+	}
+
+	for formula, expected := range cases {
+		res, err := e.Query(context.Background(), pageId, formula)
+		if err != nil {
+			t.Fatalf("Unexpected error response for %s: %s", formula, err)
+		}
+		if s, _ := FormatObject(res); s != expected {
+			t.Errorf("Expected %s to be %s; got %s", formula, expected, s)
+		}
+	}
+
+	_, err := e.Query(context.Background(), pageId, `SUM(rent, software)`)
+	if err == nil || !strings.Contains(err.Error(), "cannot combine EUR and USD") {
+		t.Errorf("Expected an error mixing currencies; got %v", err)
+	}
+}
+
+func TestQuery_Simulate(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"demand":  `NORMAL(1000, 100)`,
+		"price":   `UNIFORM(9, 11)`,
+		"revenue": `MULTIPLY(demand, price)`,
+	})
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+	formula := `SIMULATE(() => revenue, 500, seed = 42)`
+
+	// Outside of a simulation distributions give their means.
+	res, err := NewEngine(svc).Query(context.Background(), pageId, `revenue`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s, _ := FormatObject(res); s != "10000" {
+		t.Errorf("Expected revenue to be 10000 outside of a simulation; got %s", s)
+	}
+
+	res, err = NewEngine(svc).Query(context.Background(), pageId, formula)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	summary, err := res.ToRecord()
+	if err != nil {
+		t.Fatalf("Expected a record; got a %s", res.Type())
+	}
+	mean, _ := summary.Get("mean")
+	if m, _ := mean.ToNumber(); m < 9500 || m > 10500 {
+		t.Errorf("Expected mean revenue to be about 10000; got %v", m)
+	}
+	stdev, _ := summary.Get("stdev")
+	if sd, _ := stdev.ToNumber(); sd <= 0 {
+		t.Errorf("Expected page variables to be sampled in every trial; got a stdev of %v", sd)
+	}
+
+	// The same seed gives the same summary, even when resolving in parallel.
+	expected, _ := FormatObject(res)
+	for _, e := range []*Engine{NewEngine(svc), NewEngine(svc, WithParallelism(4))} {
+		again, err := e.Query(context.Background(), pageId, formula)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if s, _ := FormatObject(again); s != expected {
+			t.Errorf("Expected the same seed to give %s; got %s", expected, s)
+		}
+	}
+}
+
+func TestQuery_SimulateSource(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{})
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+	formula := `GET(SIMULATE(() => NORMAL(0, 1), 100), "mean")`
+
+	query := func(e *Engine) string {
+		res, err := e.Query(context.Background(), pageId, formula)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		s, _ := FormatObject(res)
+		return s
+	}
+
+	first := query(NewEngine(svc))
+	if second := query(NewEngine(svc)); second != first {
+		t.Errorf("Expected unseeded simulations to be reproducible; got %s then %s", first, second)
+	}
+
+	injected := NewEngine(svc, WithRandomSource(func() rand.Source {
+		return rand.NewSource(99)
+	}))
+	if other := query(injected); other == first {
+		t.Errorf("Expected the injected source to change the result; got %s both times", other)
+	}
+
+	_, err := NewEngine(svc).Query(context.Background(), pageId, `SIMULATE(5, 10)`)
+	if err == nil || !strings.Contains(err.Error(), "SIMULATE expects a lambda") {
+		t.Errorf("Expected an error simulating a number; got %v", err)
+	}
+}
+
+func TestGoalSeek(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"price":   "10",
+		"demand":  "SUBTRACT(100, price)",
+		"revenue": "MULTIPLY(price, demand)",
+		"profit":  "SUBTRACT(revenue, 1600)",
+		"margin":  "DIVIDE(profit, revenue)",
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := []struct {
+		goal     *Goal
+		solution float64
+	}{
+		{&Goal{Target: "profit", Value: 0, Input: "price", Low: 0, High: 50}, 20},
+		{&Goal{Target: "profit", Value: 0, Input: "price", Low: 50, High: 100}, 80},
+		{&Goal{Target: "revenue", Value: 1900, Input: "price", Low: 0, High: 40}, 50 - math.Sqrt(600)},
+		{&Goal{Target: "profit", Value: 500, Input: "price", Low: 60, High: 100}, 70},
+		{&Goal{Target: "margin", Value: 0.25, Input: "price", Low: 20, High: 45}, 50 - math.Sqrt(2500-1600/0.75)},
+	}
+
+	for _, c := range cases {
+		res, err := e.GoalSeek(context.Background(), pageId, c.goal)
+		if err != nil {
+			t.Fatalf("Unexpected error seeking %s = %v: %v", c.goal.Target, c.goal.Value, err)
+		}
+		if !res.Converged {
+			t.Errorf("Expected seeking %s = %v to converge; stopped at %v after %d iterations", c.goal.Target, c.goal.Value, res.Solution, res.Iterations)
+		}
+		if math.Abs(res.Solution-c.solution) > 1e-6 {
+			t.Errorf("Expected %s = %v when %s is %v; got %v", c.goal.Target, c.goal.Value, c.goal.Input, c.solution, res.Solution)
+		}
+		if math.Abs(res.Value-c.goal.Value) > 1e-6 {
+			t.Errorf("Expected %s to reach %v; got %v", c.goal.Target, c.goal.Value, res.Value)
+		}
+	}
+
+	// Profit peaks at 900 so it can't reach 1000.
+	res, err := e.GoalSeek(context.Background(), pageId, &Goal{Target: "profit", Value: 1000, Input: "price", Low: 0, High: 100, MaxIterations: 20})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res.Converged {
+		t.Errorf("Expected seeking an unreachable profit not to converge; got %v", res.Solution)
+	}
+
+	if _, err := e.GoalSeek(context.Background(), pageId, &Goal{Target: "profit", Input: "cost", Low: 0, High: 1}); err == nil {
+		t.Error("Expected an error seeking with an input which isn't on the page")
+	}
+
+	for _, v := range svc.variables {
+		if v.Name == "price" && v.Formula != "10" {
+			t.Errorf("Expected the stored formula to be left alone; got %q", v.Formula)
+		}
+	}
+}
+
+func TestDataTable(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"rate":   "0.05",
+		"years":  "10",
+		"fixed":  "TICK(100)",
+		"growth": "MULTIPLY(fixed, rate)",
+		"total":  "SUM(growth, years)",
+	})
+
+	// Count how often the variable upstream of the inputs is resolved.
+	var mx sync.Mutex
+	ticks := 0
+	builtinFunctions["tick"] = builtin{f: func(params []*types.Object) (*types.Object, error) {
+		mx.Lock()
+		defer mx.Unlock()
+		ticks++
+		return params[0], nil
+	}}
+	defer delete(builtinFunctions, "tick")
+
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+	rows, err := e.DataTable(context.Background(), pageId, &DataTable{
+		Output:       "total",
+		RowInput:     "rate",
+		RowValues:    []string{"0.01", "0.02", "0.03"},
+		ColumnInput:  "years",
+		ColumnValues: []string{"1", "2"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := [][]string{{"2", "3"}, {"3", "4"}, {"4", "5"}}
+	if len(rows) != len(expected) {
+		t.Fatalf("Expected %d rows; got %d", len(expected), len(rows))
+	}
+	for i, row := range rows {
+		for j, cell := range row {
+			if cell.Err != nil {
+				t.Fatalf("Unexpected error in cell %d,%d: %v", i, j, cell.Err)
+			}
+			if s, _ := FormatObject(cell.Value); s != expected[i][j] {
+				t.Errorf("Expected cell %d,%d to be %s; got %s", i, j, expected[i][j], s)
+			}
+		}
+	}
+	if ticks != 1 {
+		t.Errorf("Expected variables upstream of the inputs to be resolved once; resolved %d times", ticks)
+	}
+
+	// A one-variable table has a single column.
+	rows, err = e.DataTable(context.Background(), pageId, &DataTable{
+		Output:    "growth",
+		RowInput:  "rate",
+		RowValues: []string{"0.1", "SUM(0.1, 0.1)"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rows) != 2 || len(rows[1]) != 1 {
+		t.Fatalf("Expected 2 rows of 1 cell; got %v", rows)
+	}
+	if s, _ := FormatObject(rows[1][0].Value); s != "20" {
+		t.Errorf("Expected growth to be 20; got %s", s)
+	}
+
+	_, err = e.DataTable(context.Background(), pageId, &DataTable{
+		Output:    "total",
+		RowInput:  "rate",
+		RowValues: []string{"0.01"},
+		Timeout:   time.Nanosecond,
+	})
+	if err == nil || !strings.Contains(err.Error(), "data table stopped") {
+		t.Errorf("Expected the time limit to stop the table; got %v", err)
+	}
+
+	_, err = e.DataTable(context.Background(), pageId, &DataTable{
+		Output:       "total",
+		RowInput:     "rate",
+		RowValues:    make([]string, 101),
+		ColumnInput:  "years",
+		ColumnValues: make([]string, 100),
+	})
+	if err == nil || err.Error() != "data table may have at most 10000 cells; found 10100" {
+		t.Errorf("Expected the table to be too large; got %v", err)
+	}
+
+	for _, v := range svc.variables {
+		if v.Name == "rate" && v.Formula != "0.05" {
+			t.Errorf("Expected the stored formula to be left alone; got %q", v.Formula)
+		}
+	}
+}
+
+func TestDataTable_SlowCell(t *testing.T) {
+	// Each cell would take a hundred thousand simulations of a hundred thousand trials.
+	svc := newPageVarSvc(map[string]string{
+		"rate": "0.05",
+		"slow": `GET(SIMULATE(() => GET(SIMULATE(() => SUM(rate, NORMAL(0, 1)), 100000), "mean"), 100000), "mean")`,
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := e.DataTable(context.Background(), pageId, &DataTable{
+			Output:    "slow",
+			RowInput:  "rate",
+			RowValues: []string{"0.01", "0.02"},
+			Timeout:   50 * time.Millisecond,
+		})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "data table stopped") {
+			t.Errorf("Expected the time limit to stop the table; got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Expected the time limit to stop a cell part way through")
+	}
+}
+
+func TestQuery_Grid(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"A1":    "10",
+		"A2":    "20",
+		"A4":    "MULTIPLY(A1, rate)",
+		"B1":    "\"x\"",
+		"B2":    "SUM(...A1:A2)",
+		"Q1":    "7",
+		"q1":    "5",
+		"rate":  "3",
+		"total": "SUM(...A1:A4)",
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	cases := map[string]string{
+		"A4":                        "30",
+		"total":                     "60",
+		"COUNT(...A1:A9)":           "3",
+		"COUNT(A1:B2)":              "3",
+		"A1:B1":                     "[10, \"x\"]",
+		"A1:B2":                     "[[10, \"x\"], [20, 30]]",
+		"B2:A1":                     "[[10, \"x\"], [20, 30]]",
+		"C1:C3":                     "[\"\", \"\", \"\"]",
+		"A1:A4":                     "[10, 20, \"\", 30]",
+		"A2:B4":                     "[[20, 30], [\"\", \"\"], [30, \"\"]]",
+		"SUM(...A2:A3)":             "20",
+		"AVERAGE(A1:A4)":            "20",
+		"XLOOKUP(20, A1:A4, B1:B4)": "30",
+		"Q1":                        "7",
+		"q1":                        "5",
+	}
+
+	for formula, expected := range cases {
+		res, err := e.Query(context.Background(), pageId, formula)
+		if err != nil {
+			t.Fatalf("Unexpected error response for %s: %s", formula, err)
+		}
+		if s, _ := FormatObject(res); s != expected {
+			t.Errorf("Expected %s to be %s; got %s", formula, expected, s)
+		}
+	}
+}
+
+func TestQuery_CellNamedLikeVariable(t *testing.T) {
+	svc := newPageVarSvc(map[string]string{
+		"q1":    "5",
+		"total": "SUM(Q1, 1)",
+	})
+	e := NewEngine(svc)
+	pageId := "c5b8a2c6-51b5-4f0a-8d51-bd8a38fbd4ee"
+
+	_, err := e.Query(context.Background(), pageId, "total")
+	expected := "variable `Q1` is not defined; `Q1` refers to a cell so write `q1` for the variable"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q; got %v", expected, err)
+	}
+
+	_, err = e.Query(context.Background(), pageId, "Q2")
+	if err == nil || err.Error() != "variable `Q2` is not defined" {
+		t.Errorf("Expected Q2 to be undefined; got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+const defaultGoalSeekIterations = 100
+
+// Goal describes a goal seek: find the value of Input between Low and High for which Target resolves to Value.
+type Goal struct {
+	Target string
+	Value  float64
+	Input  string
+	Low    float64
+	High   float64
+	// Defaults to 100 when zero.
+	MaxIterations int
+}
+
+// GoalSeekResult reports the best value found for the input along with the value of the target at that point.
+type GoalSeekResult struct {
+	Solution   float64
+	Value      float64
+	Iterations int
+	Converged  bool
+}
+
+// GoalSeek searches for the value of the goal's input which makes its target reach the desired value. The page is
+// resolved afresh for each guess with the input overridden, so stored formulas are never changed. When the target
+// crosses the desired value between the bounds, secant steps are taken within the bracket, falling back to
+// bisection whenever they stop closing in. Otherwise secant steps are taken from the bounds without leaving them.
+func (e *Engine) GoalSeek(ctx context.Context, pageId string, goal *Goal) (*GoalSeekResult, error) {
+	if pageId == "" {
+		return nil, errors.New("pageId must be provided")
+	}
+	if goal.Low >= goal.High {
+		return nil, fmt.Errorf("expected low to be less than high; found %v and %v", goal.Low, goal.High)
+	}
+	maxIterations := goal.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = defaultGoalSeekIterations
+	}
+
+	qc := e.startQuery(pageId)
+	ctx = setQueryContext(ctx, qc)
+	if _, err := e.fetchPageVariables(ctx, qc); err != nil {
+		return nil, err
+	}
+	for _, name := range []string{goal.Target, goal.Input} {
+		if v, _ := qc.lookupVariable(name); v == nil {
+			return nil, fmt.Errorf("`%s` is not a variable on the page", name)
+		}
+	}
+
+	s := &seeker{
+		e:    e,
+		ctx:  ctx,
+		qc:   qc,
+		goal: goal,
+		unit: inputUnit(e, ctx, goal.Input),
+	}
+	return s.seek(maxIterations)
+}
+
+// seeker evaluates the target for guesses of the input.
+type seeker struct {
+	e    *Engine
+	ctx  context.Context
+	qc   *queryContext
+	goal *Goal
+	// Appended to each guess so the input keeps the unit or currency it had.
+	unit string
+}
+
+// inputUnit returns the unit or currency of the input's current value, if it has one.
+func inputUnit(e *Engine, ctx context.Context, input string) string {
+	v, err := e.resolveVariable(ctx, &types.Variable{Name: input}, []string{}, true)
+	if err != nil {
+		return ""
+	}
+	if m, err := v.ToMoney(); err == nil {
+		return m.Currency
+	}
+	return v.Unit().String()
+}
+
+// evaluate returns how far the target is from the desired value when the input is x.
+func (s *seeker) evaluate(x float64) (float64, error) {
+	formula := strconv.FormatFloat(x, 'f', -1, 64)
+	if s.unit != "" {
+		formula += " " + s.unit
+	}
+
+	qc := s.qc.fork(nil)
+	qc.override(Overrides{s.goal.Input: formula})
+	res, err := s.e.resolveVariable(setQueryContext(s.ctx, qc), &types.Variable{Name: s.goal.Target}, []string{}, true)
+	if err != nil {
+		return 0, err
+	}
+
+	var y float64
+	switch res.Type() {
+	case types.TypeMoney:
+		m, _ := res.ToMoney()
+		y = m.Amount.Float64()
+	case types.TypeNumber:
+		y, _ = res.ToNumber()
+	default:
+		return 0, fmt.Errorf("expected `%s` to be a number; found a %s", s.goal.Target, res.Type())
+	}
+
+	return y - s.goal.Value, nil
+}
+
+func (s *seeker) seek(maxIterations int) (*GoalSeekResult, error) {
+	a, b := s.goal.Low, s.goal.High
+	fa, err := s.evaluate(a)
+	if err != nil {
+		return nil, err
+	}
+	fb, err := s.evaluate(b)
+	if err != nil {
+		return nil, err
+	}
+
+	tolerance := 1e-9 * math.Max(1, math.Abs(s.goal.Value))
+	best := func(x, fx float64, iterations int, converged bool) *GoalSeekResult {
+		return &GoalSeekResult{
+			Solution:   x,
+			Value:      fx + s.goal.Value,
+			Iterations: iterations,
+			Converged:  converged,
+		}
+	}
+	if math.Abs(fa) <= tolerance {
+		return best(a, fa, 0, true), nil
+	}
+	if math.Abs(fb) <= tolerance {
+		return best(b, fb, 0, true), nil
+	}
+
+	bracketed := math.Signbit(fa) != math.Signbit(fb)
+	for i := 1; i <= maxIterations; i++ {
+		x := b - fb*(b-a)/(fb-fa)
+		if bracketed {
+			// Secant steps which land outside the bracket, or too near its ends to shrink it, are replaced by
+			// bisection so the bracket always at least halves every other step.
+			lo, hi := math.Min(a, b), math.Max(a, b)
+			if math.IsNaN(x) || x <= lo || x >= hi || i%2 == 0 {
+				x = (a + b) / 2
+			}
+		} else if math.IsNaN(x) || math.IsInf(x, 0) {
+			return best(b, fb, i, false), nil
+		} else {
+			x = math.Max(s.goal.Low, math.Min(s.goal.High, x))
+		}
+
+		fx, err := s.evaluate(x)
+		if err != nil {
+			return nil, err
+		}
+		if math.Abs(fx) <= tolerance {
+			return best(x, fx, i, true), nil
+		}
+
+		if bracketed {
+			// Keep the ends on opposite sides of the desired value.
+			if math.Signbit(fx) == math.Signbit(fa) {
+				a, fa = x, fx
+			} else {
+				b, fb = x, fx
+			}
+			if math.Abs(b-a) <= 1e-12*math.Max(1, math.Abs(x)) {
+				return best(x, fx, i, true), nil
+			}
+			continue
+		}
+
+		if x == b {
+			// Pinned against a bound without reaching the goal.
+			return best(x, fx, i, false), nil
+		}
+		a, fa, b, fb = b, fb, x, fx
+		if math.Signbit(fa) != math.Signbit(fb) {
+			bracketed = true
+		}
+	}
+
+	if math.Abs(fa) < math.Abs(fb) {
+		return best(a, fa, maxIterations, false), nil
+	}
+	return best(b, fb, maxIterations, false), nil
+}
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/tobyjsullivan/chalk/parsing"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// resolveRange resolves the cells of a range. A range within a single row or column is a list of values and any
+// other range is a list of rows. Blank cells, which are either undefined or have an empty formula, are kept as blank
+// placeholders so every row is the same length and values stay in position.
+func (e *Engine) resolveRange(ctx context.Context, r *types.Range, varHistory []string) (*types.Object, error) {
+	rows, err := parsing.ExpandRange(r.From, r.To)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([][]*types.Object, len(rows))
+	for i, row := range rows {
+		values[i] = []*types.Object{}
+		for _, cell := range row {
+			v, err := e.resolveVariable(ctx, &types.Variable{Name: cell}, varHistory, false)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				v = types.NewBlank()
+			}
+			values[i] = append(values[i], v)
+		}
+	}
+
+	if len(rows) == 1 {
+		return types.NewList(values[0]), nil
+	}
+	if len(rows[0]) == 1 {
+		var column []*types.Object
+		for _, row := range values {
+			column = append(column, row...)
+		}
+		return types.NewList(column), nil
+	}
+
+	out := make([]*types.Object, len(values))
+	for i, row := range values {
+		out[i] = types.NewList(row)
+	}
+	return types.NewList(out), nil
+}
+
+// rangeCells returns the normalised names of the cells in a range, or nothing if the range isn't valid.
+func rangeCells(r *types.Range) []string {
+	rows, err := parsing.ExpandRange(r.From, r.To)
+	if err != nil {
+		// Leave the error to be reported when the range is resolved.
+		return nil
+	}
+
+	var out []string
+	for _, row := range rows {
+		for _, cell := range row {
+			out = append(out, normaliseVarName(cell))
+		}
+	}
+	return out
+}
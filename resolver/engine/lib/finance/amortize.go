@@ -0,0 +1,45 @@
+package finance
+
+import (
+	"errors"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Amortize returns the schedule for repaying a loan of pv over nper periods at rate per period, as a list of
+// records with the `period`, `payment`, `interest`, `principal` and remaining `balance`. Amounts are given as
+// positive numbers.
+var Amortize = func(params []*types.Object) (*types.Object, error) {
+	args, err := numberArgs(params, 3)
+	if err != nil {
+		return nil, err
+	}
+	rate, nper, pv := args[0], args[1], args[2]
+	if nper < 1 || nper != math.Trunc(nper) {
+		return nil, errors.New("expected a whole number of periods")
+	}
+
+	payment := -pmt(rate, nper, pv, 0, 0)
+	balance := pv
+	rows := make([]*types.Object, int(nper))
+	for i := range rows {
+		interest := balance * rate
+		principal := payment - interest
+		balance -= principal
+		if i == len(rows)-1 {
+			// Avoid leaving a rounding error as the final balance.
+			balance = 0
+		}
+
+		rows[i] = types.NewRecord([]*types.RecordProperty{
+			{Name: "period", Value: types.NewNumber(float64(i + 1))},
+			{Name: "payment", Value: types.NewNumber(payment)},
+			{Name: "interest", Value: types.NewNumber(interest)},
+			{Name: "principal", Value: types.NewNumber(principal)},
+			{Name: "balance", Value: types.NewNumber(balance)},
+		})
+	}
+
+	return types.NewList(rows), nil
+}
@@ -0,0 +1,47 @@
+package finance
+
+import (
+	"errors"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Sln returns the straight-line depreciation each period: cost, salvage and life.
+var Sln = func(params []*types.Object) (*types.Object, error) {
+	args, err := numberArgs(params, 3)
+	if err != nil {
+		return nil, err
+	}
+	cost, salvage, life := args[0], args[1], args[2]
+	if life == 0 {
+		return nil, errors.New("expected a non-zero life")
+	}
+
+	return types.NewNumber((cost - salvage) / life), nil
+}
+
+// Ddb returns the depreciation in a period by the declining balance method: cost, salvage, life, period and factor.
+// A factor of 2 is the double-declining balance. The book value never falls below salvage.
+var Ddb = func(params []*types.Object) (*types.Object, error) {
+	args, err := numberArgs(params, 5)
+	if err != nil {
+		return nil, err
+	}
+	cost, salvage, life, period, factor := args[0], args[1], args[2], args[3], args[4]
+	if life <= 0 || factor <= 0 || cost < 0 || salvage < 0 {
+		return nil, errors.New("expected a positive life and factor and non-negative cost and salvage")
+	}
+	if period < 1 || period > life || period != math.Trunc(period) {
+		return nil, errors.New("expected period to be a whole number between 1 and life")
+	}
+
+	book := cost
+	var depreciation float64
+	for p := 1.0; p <= period; p++ {
+		depreciation = math.Max(0, math.Min(book*factor/life, book-salvage))
+		book -= depreciation
+	}
+
+	return types.NewNumber(depreciation), nil
+}
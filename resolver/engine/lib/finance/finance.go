@@ -0,0 +1,123 @@
+// Package finance provides spreadsheet-equivalent financial builtins. Like spreadsheets, money paid out is negative
+// and money received is positive, and payments are due at the end of each period unless type is 1.
+package finance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// number converts a param to a number. Units have no meaning in financial calculations so numbers with one are
+// rejected rather than having it dropped.
+func number(p *types.Object) (float64, error) {
+	n, err := p.ToNumber()
+	if err != nil {
+		return 0, err
+	}
+	if u := p.Unit(); u != nil {
+		return 0, fmt.Errorf("expected a number without a unit; found a number in %s", u)
+	}
+
+	return n, nil
+}
+
+// numberArgs converts exactly n params to numbers.
+func numberArgs(params []*types.Object, n int) ([]float64, error) {
+	if len(params) != n {
+		return nil, fmt.Errorf("expected exactly %d parameters; found %d", n, len(params))
+	}
+
+	out := make([]float64, n)
+	for i, p := range params {
+		var err error
+		out[i], err = number(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// cashFlows flattens params into the numbers they contain. Blank cells are skipped.
+func cashFlows(params []*types.Object) ([]float64, error) {
+	var out []float64
+	for _, p := range params {
+		if p.Type() == types.TypeBlank {
+			continue
+		}
+		if p.Type() == types.TypeList {
+			l, _ := p.ToList()
+			ns, err := cashFlows(l.Elements)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, ns...)
+			continue
+		}
+
+		if _, err := p.ToNumber(); err != nil {
+			return nil, fmt.Errorf("expected cash flows to be numbers; found a %s", p.Type())
+		}
+		n, err := number(p)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+
+	return out, nil
+}
+
+// dates converts a list of dates to days. A date is either a number of days or a string formatted as YYYY-MM-DD.
+func dates(list *types.Object) ([]float64, error) {
+	l, err := list.ToList()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]float64, len(l.Elements))
+	for i, d := range l.Elements {
+		switch d.Type() {
+		case types.TypeNumber:
+			var err error
+			out[i], err = number(d)
+			if err != nil {
+				return nil, err
+			}
+		case types.TypeString:
+			s, _ := d.ToString()
+			t, err := time.Parse("2006-01-02", s)
+			if err != nil {
+				return nil, fmt.Errorf("expected a date formatted as YYYY-MM-DD; found %q", s)
+			}
+			out[i] = float64(t.Unix() / (24 * 60 * 60))
+		default:
+			return nil, fmt.Errorf("expected a date; found a %s", d.Type())
+		}
+	}
+
+	return out, nil
+}
+
+// datedFlows returns cash flows alongside their dates, which must be equal in number.
+func datedFlows(values, when *types.Object) ([]float64, []float64, error) {
+	flows, err := cashFlows([]*types.Object{values})
+	if err != nil {
+		return nil, nil, err
+	}
+	days, err := dates(when)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(flows) != len(days) {
+		return nil, nil, fmt.Errorf("expected a date for each of %d values; found %d", len(flows), len(days))
+	}
+	if len(flows) == 0 {
+		return nil, nil, fmt.Errorf("expected at least one value")
+	}
+
+	return flows, days, nil
+}
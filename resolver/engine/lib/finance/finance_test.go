@@ -0,0 +1,134 @@
+package finance
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/internal/libtest"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Reference values are the results spreadsheets give for the same arguments.
+
+func dateStrings(ss ...string) []*types.Object {
+	out := make([]*types.Object, len(ss))
+	for i, s := range ss {
+		out[i] = types.NewString(s)
+	}
+	return out
+}
+
+func TestReferenceValues(t *testing.T) {
+	flows := types.NewList(libtest.Numbers(-10000, 2750, 4250, 3250, 2750))
+	days := types.NewList(dateStrings("2008-01-01", "2008-03-01", "2008-10-30", "2009-02-15", "2009-04-01"))
+
+	cases := []struct {
+		name      string
+		f         func([]*types.Object) (*types.Object, error)
+		params    []*types.Object
+		expected  float64
+		tolerance float64
+	}{
+		{"PMT", Pmt, libtest.Numbers(0.08/12, 10, 10000, 0, 0), -1037.0320893, 1e-6},
+		{"PMT", Pmt, libtest.Numbers(0.06/12, 18*12, 0, 50000, 0), -129.0811609, 1e-6},
+		{"PMT", Pmt, libtest.Numbers(0, 10, 1000, 0, 0), -100, 1e-9},
+		{"PV", Pv, libtest.Numbers(0.08/12, 12*20, 500, 0, 0), -59777.15, 0.005},
+		{"FV", Fv, libtest.Numbers(0.06/12, 10, -200, -500, 1), 2581.4033741, 1e-6},
+		{"FV", Fv, libtest.Numbers(0.12/12, 12, -1000, 0, 0), 12682.5030131, 1e-6},
+		{"NPER", Nper, libtest.Numbers(0.12/12, -100, -1000, 10000, 1), 59.6738657, 1e-6},
+		{"NPER", Nper, libtest.Numbers(0.12/12, -100, -1000, 0, 0), -9.5785940, 1e-6},
+		{"RATE", Rate, libtest.Numbers(4*12, -200, 8000, 0, 0, 0.1), 0.0077014725, 1e-9},
+		{"NPV", Npv, append(libtest.Numbers(0.1, -10000), types.NewList(libtest.Numbers(3000, 4200, 6800))), 1188.4434123, 1e-6},
+		{"XNPV", Xnpv, []*types.Object{types.NewNumber(0.09), flows, days}, 2086.6476021, 1e-6},
+		{"IRR", Irr, []*types.Object{types.NewList(libtest.Numbers(-70000, 12000, 15000, 18000, 21000, 26000)), types.NewNumber(0.1)}, 0.0866309480, 1e-9},
+		{"IRR", Irr, []*types.Object{types.NewList(libtest.Numbers(-70000, 12000, 15000, 18000, 21000)), types.NewNumber(0.1)}, -0.02124485, 1e-8},
+		{"XIRR", Xirr, []*types.Object{flows, days, types.NewNumber(0.1)}, 0.3733625335, 1e-8},
+		{"SLN", Sln, libtest.Numbers(30000, 7500, 10), 2250, 1e-9},
+		{"DDB", Ddb, libtest.Numbers(2400, 300, 10*365, 1, 2), 1.3150685, 1e-6},
+		{"DDB", Ddb, libtest.Numbers(2400, 300, 10, 1, 2), 480, 1e-9},
+		{"DDB", Ddb, libtest.Numbers(2400, 300, 10, 2, 1.5), 306, 1e-9},
+		{"DDB", Ddb, libtest.Numbers(2400, 300, 10, 10, 2), 22.1225472, 1e-6},
+	}
+
+	for _, c := range cases {
+		result, err := c.f(c.params)
+		if err != nil {
+			t.Fatalf("Unexpected error from %s: %v", c.name, err)
+		}
+
+		n, err := result.ToNumber()
+		if err != nil {
+			t.Fatalf("Expected %s to return a number; got a %s", c.name, result.Type())
+		}
+		if math.Abs(n-c.expected) > c.tolerance {
+			t.Errorf("Expected %s to be %v; got %v", c.name, c.expected, n)
+		}
+	}
+}
+
+func TestIrr_NonConvergence(t *testing.T) {
+	if _, err := Irr([]*types.Object{types.NewList(libtest.Numbers(100, 200)), types.NewNumber(0.1)}); err == nil {
+		t.Error("Expected an error when every cash flow is positive")
+	}
+
+	// No rate makes these flows worth nothing.
+	_, err := Irr([]*types.Object{types.NewList(libtest.Numbers(-100, 300, -300)), types.NewNumber(0.1)})
+	if err == nil {
+		t.Fatal("Expected an error when there's no rate of return")
+	}
+	if !strings.HasPrefix(err.Error(), "IRR did not converge") {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestAmortize(t *testing.T) {
+	result, err := Amortize(libtest.Numbers(0.01, 12, 10000))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rows, _ := result.ToList()
+	if len(rows.Elements) != 12 {
+		t.Fatalf("Expected 12 periods; got %d", len(rows.Elements))
+	}
+
+	get := func(row int, name string) float64 {
+		r, _ := rows.Elements[row].ToRecord()
+		v, _ := r.Get(name)
+		n, _ := v.ToNumber()
+		return n
+	}
+
+	if p := get(0, "payment"); math.Abs(p-888.4878868) > 1e-6 {
+		t.Errorf("Unexpected payment: %v", p)
+	}
+	if i := get(0, "interest"); math.Abs(i-100) > 1e-9 {
+		t.Errorf("Unexpected interest: %v", i)
+	}
+	if b := get(0, "balance"); math.Abs(b-9211.5121132) > 1e-6 {
+		t.Errorf("Unexpected balance: %v", b)
+	}
+	if b := get(11, "balance"); b != 0 {
+		t.Errorf("Expected the loan to be repaid; got a balance of %v", b)
+	}
+
+	var principal float64
+	for i := range rows.Elements {
+		principal += get(i, "principal")
+	}
+	if math.Abs(principal-10000) > 1e-6 {
+		t.Errorf("Expected the principal to total the loan; got %v", principal)
+	}
+}
+
+func TestUnits(t *testing.T) {
+	_, err := Npv([]*types.Object{types.NewNumber(0.1), libtest.Quantity(t, 10, "h")})
+	if err == nil || err.Error() != "expected a number without a unit; found a number in h" {
+		t.Errorf("Expected an error for a cash flow measured in hours; got %v", err)
+	}
+
+	_, err = Pmt([]*types.Object{types.NewNumber(0.01), libtest.Quantity(t, 12, "h"), types.NewNumber(1000), types.NewNumber(0), types.NewNumber(0)})
+	if err == nil || err.Error() != "expected a number without a unit; found a number in h" {
+		t.Errorf("Expected an error for periods measured in hours; got %v", err)
+	}
+}
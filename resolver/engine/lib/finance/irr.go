@@ -0,0 +1,118 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+const (
+	// Solvers give up once they've iterated this many times without converging.
+	maxIterations = 100
+	tolerance     = 1e-10
+)
+
+// Irr returns the internal rate of return of cash flows over equal periods: values and guess. It's solved
+// iteratively starting from guess.
+var Irr = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	flows, err := cashFlows(params[:1])
+	if err != nil {
+		return nil, err
+	}
+	guess, err := number(params[1])
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFlows(flows); err != nil {
+		return nil, err
+	}
+
+	rate, err := solve("IRR", guess, func(r float64) float64 {
+		var total float64
+		for i, v := range flows {
+			total += v / math.Pow(1+r, float64(i))
+		}
+		return total
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewNumber(rate), nil
+}
+
+// Xirr returns the annual internal rate of return of cash flows on the given dates: values, dates and guess.
+var Xirr = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 3 {
+		return nil, fmt.Errorf("expected exactly 3 parameters; found %d", n)
+	}
+	flows, days, err := datedFlows(params[0], params[1])
+	if err != nil {
+		return nil, err
+	}
+	guess, err := number(params[2])
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFlows(flows); err != nil {
+		return nil, err
+	}
+
+	rate, err := solve("XIRR", guess, func(r float64) float64 {
+		return xnpv(r, flows, days)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewNumber(rate), nil
+}
+
+// checkFlows ensures there's a rate of return to find.
+func checkFlows(flows []float64) error {
+	var positive, negative bool
+	for _, v := range flows {
+		positive = positive || v > 0
+		negative = negative || v < 0
+	}
+	if !positive || !negative {
+		return errors.New("expected at least one positive and one negative value")
+	}
+
+	return nil
+}
+
+// solve finds a rate at which f is zero using Newton's method, starting from guess.
+func solve(name string, guess float64, f func(rate float64) float64) (float64, error) {
+	rate := guess
+	for i := 0; i < maxIterations; i++ {
+		y := f(rate)
+		if math.Abs(y) < tolerance {
+			return rate, nil
+		}
+
+		// Approximate the derivative since f can be anything.
+		h := 1e-7 * math.Max(1, math.Abs(rate))
+		slope := (f(rate+h) - f(rate-h)) / (2 * h)
+		if slope == 0 || math.IsNaN(slope) {
+			return 0, fmt.Errorf("%s did not converge; try a different guess", name)
+		}
+
+		next := rate - y/slope
+		if next <= -1 {
+			// Rates at or below -100% aren't meaningful so step halfway there instead.
+			next = (rate - 1) / 2
+		}
+		if math.Abs(next-rate) < tolerance {
+			return next, nil
+		}
+		rate = next
+	}
+
+	return 0, fmt.Errorf("%s did not converge after %d iterations; try a different guess", name, maxIterations)
+}
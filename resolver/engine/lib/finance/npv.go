@@ -0,0 +1,57 @@
+package finance
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Npv returns the net present value of a rate followed by cash flows, which may be given in lists. The first cash
+// flow is discounted by a full period, as in spreadsheets.
+var Npv = func(params []*types.Object) (*types.Object, error) {
+	if len(params) < 2 {
+		return nil, fmt.Errorf("expected a rate and at least one value; found %d parameters", len(params))
+	}
+	rate, err := number(params[0])
+	if err != nil {
+		return nil, err
+	}
+	flows, err := cashFlows(params[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	var total float64
+	for i, v := range flows {
+		total += v / math.Pow(1+rate, float64(i+1))
+	}
+
+	return types.NewNumber(total), nil
+}
+
+// Xnpv returns the net present value of cash flows on the given dates, discounted to the first date at an annual
+// rate: rate, values and dates.
+var Xnpv = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 3 {
+		return nil, fmt.Errorf("expected exactly 3 parameters; found %d", n)
+	}
+	rate, err := number(params[0])
+	if err != nil {
+		return nil, err
+	}
+	flows, days, err := datedFlows(params[1], params[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewNumber(xnpv(rate, flows, days)), nil
+}
+
+func xnpv(rate float64, flows, days []float64) float64 {
+	var total float64
+	for i, v := range flows {
+		total += v / math.Pow(1+rate, (days[i]-days[0])/365)
+	}
+	return total
+}
@@ -0,0 +1,113 @@
+package finance
+
+import (
+	"errors"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Pmt returns the payment each period on a loan or investment: rate, nper, pv, fv and type.
+var Pmt = func(params []*types.Object) (*types.Object, error) {
+	args, err := numberArgs(params, 5)
+	if err != nil {
+		return nil, err
+	}
+	rate, nper, pv, fv, due := args[0], args[1], args[2], args[3], args[4]
+	if nper == 0 {
+		return nil, errors.New("expected a non-zero number of periods")
+	}
+
+	return types.NewNumber(pmt(rate, nper, pv, fv, due)), nil
+}
+
+// Pv returns the present value of a series of payments: rate, nper, pmt, fv and type.
+var Pv = func(params []*types.Object) (*types.Object, error) {
+	args, err := numberArgs(params, 5)
+	if err != nil {
+		return nil, err
+	}
+	rate, nper, pmt, fv, due := args[0], args[1], args[2], args[3], args[4]
+
+	if rate == 0 {
+		return types.NewNumber(-(fv + pmt*nper)), nil
+	}
+	growth := math.Pow(1+rate, nper)
+	return types.NewNumber(-(fv + pmt*(1+rate*due)*(growth-1)/rate) / growth), nil
+}
+
+// Fv returns the future value of a series of payments: rate, nper, pmt, pv and type.
+var Fv = func(params []*types.Object) (*types.Object, error) {
+	args, err := numberArgs(params, 5)
+	if err != nil {
+		return nil, err
+	}
+	rate, nper, pmt, pv, due := args[0], args[1], args[2], args[3], args[4]
+
+	return types.NewNumber(fv(rate, nper, pmt, pv, due)), nil
+}
+
+// Nper returns the number of periods needed to pay off a loan or reach a future value: rate, pmt, pv, fv and type.
+var Nper = func(params []*types.Object) (*types.Object, error) {
+	args, err := numberArgs(params, 5)
+	if err != nil {
+		return nil, err
+	}
+	rate, pmt, pv, fv, due := args[0], args[1], args[2], args[3], args[4]
+
+	if rate == 0 {
+		if pmt == 0 {
+			return nil, errors.New("expected a non-zero payment when the rate is zero")
+		}
+		return types.NewNumber(-(pv + fv) / pmt), nil
+	}
+
+	p := pmt * (1 + rate*due)
+	ratio := (p - fv*rate) / (p + pv*rate)
+	if ratio <= 0 {
+		return nil, errors.New("the payments never reach the future value")
+	}
+	return types.NewNumber(math.Log(ratio) / math.Log(1+rate)), nil
+}
+
+// Rate returns the interest rate per period which makes a series of payments worth the present and future values:
+// nper, pmt, pv, fv, type and guess. It's solved iteratively starting from guess.
+var Rate = func(params []*types.Object) (*types.Object, error) {
+	args, err := numberArgs(params, 6)
+	if err != nil {
+		return nil, err
+	}
+	nper, pmt, pv, fv, due, guess := args[0], args[1], args[2], args[3], args[4], args[5]
+
+	rate, err := solve("RATE", guess, func(r float64) float64 {
+		// The future value of everything is zero at the right rate.
+		return pv*math.Pow(1+r, nper) + pmt*annuity(r, nper, due) + fv
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewNumber(rate), nil
+}
+
+func pmt(rate, nper, pv, fv, due float64) float64 {
+	if rate == 0 {
+		return -(pv + fv) / nper
+	}
+
+	growth := math.Pow(1+rate, nper)
+	return -(pv*growth + fv) * rate / ((1 + rate*due) * (growth - 1))
+}
+
+func fv(rate, nper, pmt, pv, due float64) float64 {
+	return -(pv*math.Pow(1+rate, nper) + pmt*annuity(rate, nper, due))
+}
+
+// annuity returns the future value of a payment of 1 each period.
+func annuity(rate, nper, due float64) float64 {
+	if rate == 0 {
+		return nper
+	}
+
+	return (1 + rate*due) * (math.Pow(1+rate, nper) - 1) / rate
+}
@@ -0,0 +1,102 @@
+// Package libtest provides the builders and checks shared by the tests of the builtin libraries.
+package libtest
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Results may differ from reference values by rounding alone.
+const tolerance = 1e-9
+
+// Numbers returns each of xs as a number, such as for the params of a builtin.
+func Numbers(xs ...float64) []*types.Object {
+	out := make([]*types.Object, len(xs))
+	for i, x := range xs {
+		out[i] = types.NewNumber(x)
+	}
+	return out
+}
+
+// List returns a list of the numbers xs.
+func List(xs ...float64) *types.Object {
+	return types.NewList(Numbers(xs...))
+}
+
+// Quantity returns n measured in unit.
+func Quantity(t testing.TB, n float64, unit string) *types.Object {
+	t.Helper()
+	u, err := types.ParseUnit(unit)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing unit %q: %v", unit, err)
+	}
+	return types.NewQuantity(n, u)
+}
+
+// Money returns an amount, such as "0.10", of a currency.
+func Money(t testing.TB, amount, currency string) *types.Object {
+	t.Helper()
+	d, err := types.ParseDecimal(amount)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing amount %q: %v", amount, err)
+	}
+	m, err := types.NewMoney(d, currency)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return m
+}
+
+// ExpectNumber checks that the builtin called name returned the expected number, whatever its unit.
+func ExpectNumber(t testing.TB, name string, result *types.Object, err error, expected float64) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("Unexpected error from %s: %v", name, err)
+	}
+	n, err := result.ToNumber()
+	if err != nil {
+		t.Fatalf("Expected %s to return a number; got a %s", name, result.Type())
+	}
+	if math.Abs(n-expected) > tolerance {
+		t.Errorf("Expected %s to be %v; got %v", name, expected, n)
+	}
+}
+
+// ExpectQuantity checks that the builtin called name returned the expected number measured in unit, which is empty
+// for a number without one.
+func ExpectQuantity(t testing.TB, name string, result *types.Object, err error, expected float64, unit string) {
+	t.Helper()
+	ExpectNumber(t, name, result, err, expected)
+	if actual := result.Unit().String(); actual != unit {
+		t.Errorf("Expected %s to be measured in %q; got %q", name, unit, actual)
+	}
+}
+
+// ExpectMoney checks that the builtin called name returned the expected amount and currency, such as "12.50 USD".
+func ExpectMoney(t testing.TB, name string, result *types.Object, err error, expected string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("Unexpected error from %s: %v", name, err)
+	}
+	m, err := result.ToMoney()
+	if err != nil {
+		t.Fatalf("Expected %s to return money; got a %s", name, result.Type())
+	}
+	if actual := m.Amount.String() + " " + m.Currency; actual != expected {
+		t.Errorf("Expected %s to be %s; got %s", name, expected, actual)
+	}
+}
+
+// ExpectError checks that the builtin called name failed with an error mentioning contains.
+func ExpectError(t testing.TB, name string, err error, contains string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("Expected an error from %s", name)
+	}
+	if !strings.Contains(err.Error(), contains) {
+		t.Errorf("Expected the error from %s to mention %q; got %q", name, contains, err)
+	}
+}
@@ -0,0 +1,153 @@
+package matrix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/units"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Add, Subtract, Multiply and Divide apply arithmetic element-wise to numbers, money and nested lists of them,
+// taking any units and currencies into account. Operands of different shapes are broadcast against each other by
+// aligning their innermost dimensions: a dimension of length 1 or a missing outer dimension is repeated to match the
+// other operand, so a number applies to every element and a row applies to every row of a matrix.
+var (
+	Add      = elementWise('+')
+	Subtract = elementWise('-')
+	Multiply = elementWise('*')
+	Divide   = elementWise('/')
+)
+
+func elementWise(op rune) types.Function {
+	return func(params []*types.Object) (*types.Object, error) {
+		if n := len(params); n != 2 {
+			return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+		}
+		a, b := params[0], params[1]
+
+		sa, err := shapeOf(a)
+		if err != nil {
+			return nil, err
+		}
+		sb, err := shapeOf(b)
+		if err != nil {
+			return nil, err
+		}
+		out, err := broadcastShapes(sa, sb)
+		if err != nil {
+			return nil, err
+		}
+
+		return apply(op, a, sa, b, sb, out)
+	}
+}
+
+// shapeOf returns the length of each dimension of a number or nested list, outermost first. Lists must be
+// rectangular.
+func shapeOf(obj *types.Object) ([]int, error) {
+	switch obj.Type() {
+	case types.TypeMoney, types.TypeNumber:
+		return nil, nil
+	case types.TypeList:
+		l, _ := obj.ToList()
+		if len(l.Elements) == 0 {
+			return []int{0}, nil
+		}
+
+		inner, err := shapeOf(l.Elements[0])
+		if err != nil {
+			return nil, err
+		}
+		for i, el := range l.Elements[1:] {
+			s, err := shapeOf(el)
+			if err != nil {
+				return nil, err
+			}
+			if formatShape(s) != formatShape(inner) {
+				return nil, fmt.Errorf("expected every element to have the same shape; element 1 is %s but element %d is %s", formatShape(inner), i+2, formatShape(s))
+			}
+		}
+		return append([]int{len(l.Elements)}, inner...), nil
+	default:
+		return nil, fmt.Errorf("expected numbers, money or lists of them; found a %s", obj.Type())
+	}
+}
+
+func broadcastShapes(a, b []int) ([]int, error) {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+
+	out := make([]int, n)
+	for i := 1; i <= n; i++ {
+		da, db := dim(a, len(a)-i), dim(b, len(b)-i)
+		switch {
+		case da == db || db == 1:
+			out[n-i] = da
+		case da == 1:
+			out[n-i] = db
+		default:
+			return nil, fmt.Errorf("cannot broadcast shapes %s and %s; dimensions of length %d and %d don't match", formatShape(a), formatShape(b), da, db)
+		}
+	}
+
+	return out, nil
+}
+
+// dim returns the length of dimension i, treating missing dimensions as length 1.
+func dim(shape []int, i int) int {
+	if i < 0 {
+		return 1
+	}
+	return shape[i]
+}
+
+func apply(op rune, a *types.Object, sa []int, b *types.Object, sb []int, out []int) (*types.Object, error) {
+	if len(out) == 0 {
+		return units.Arithmetic(op, a, b)
+	}
+
+	elements := make([]*types.Object, out[0])
+	for i := range elements {
+		ea, esa := element(a, sa, len(out), i)
+		eb, esb := element(b, sb, len(out), i)
+
+		var err error
+		elements[i], err = apply(op, ea, esa, eb, esb, out[1:])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return types.NewList(elements), nil
+}
+
+// element returns the ith element of the outermost dimension of obj and its shape when broadcasting to rank
+// dimensions.
+func element(obj *types.Object, shape []int, rank int, i int) (*types.Object, []int) {
+	if len(shape) < rank {
+		// The operand is repeated across this dimension.
+		return obj, shape
+	}
+
+	l, _ := obj.ToList()
+	if shape[0] == 1 {
+		i = 0
+	}
+	return l.Elements[i], shape[1:]
+}
+
+func formatShape(shape []int) string {
+	if len(shape) == 0 {
+		return "a number"
+	}
+
+	dims := make([]string, len(shape))
+	for i, d := range shape {
+		dims[i] = strconv.Itoa(d)
+	}
+	return strings.Join(dims, "×")
+}
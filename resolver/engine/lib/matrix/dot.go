@@ -0,0 +1,32 @@
+package matrix
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Dot returns the dot product of two vectors of the same length.
+var Dot = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	a, err := toVector(params[0])
+	if err != nil {
+		return nil, err
+	}
+	b, err := toVector(params[1])
+	if err != nil {
+		return nil, err
+	}
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("cannot take the dot product of vectors of length %d and %d", len(a), len(b))
+	}
+
+	var total float64
+	for i := range a {
+		total += a[i] * b[i]
+	}
+
+	return types.NewNumber(total), nil
+}
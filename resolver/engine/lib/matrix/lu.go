@@ -0,0 +1,192 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Pivots smaller than this, relative to the largest element, are treated as zero.
+const singularTolerance = 1e-12
+
+var errSingular = errors.New("the matrix is singular so has no inverse")
+
+// MDeterm returns the determinant of a square matrix.
+var MDeterm = func(params []*types.Object) (*types.Object, error) {
+	m, err := squareParam(params, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := factorise(m)
+	if err == errSingular {
+		return types.NewNumber(0), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	det := f.sign
+	for i := range f.lu {
+		det *= f.lu[i][i]
+	}
+	return types.NewNumber(det), nil
+}
+
+// MInverse returns the inverse of a square matrix. It's an error if the matrix is singular.
+var MInverse = func(params []*types.Object) (*types.Object, error) {
+	m, err := squareParam(params, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := factorise(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromMatrix(f.solve(identity(m.rows()))), nil
+}
+
+// Solve returns x such that A x = b for a square matrix A. b is either a vector, giving a vector, or a matrix with as
+// many rows as A, giving a matrix.
+var Solve = func(params []*types.Object) (*types.Object, error) {
+	a, err := squareParam(params, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := toMatrix(params[1])
+	if err != nil {
+		return nil, err
+	}
+	vector := b.rows() == 1 && a.rows() != 1
+	if vector {
+		// A list of numbers is a column of values rather than a row.
+		b = columnOf(b[0])
+	}
+	if b.rows() != a.rows() {
+		return nil, fmt.Errorf("cannot solve a %s system for %d values; expected %d", a.shape(), b.rows(), a.rows())
+	}
+
+	f, err := factorise(a)
+	if err == errSingular {
+		return nil, errors.New("the system has no unique solution since the matrix is singular")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	x := f.solve(b)
+	if vector {
+		out := make([]float64, len(x))
+		for i, row := range x {
+			out[i] = row[0]
+		}
+		return fromVector(out), nil
+	}
+	return fromMatrix(x), nil
+}
+
+// squareParam returns the first of n params as a square matrix.
+func squareParam(params []*types.Object, n int) (dense, error) {
+	if len(params) != n {
+		return nil, fmt.Errorf("expected exactly %d parameters; found %d", n, len(params))
+	}
+	m, err := toMatrix(params[0])
+	if err != nil {
+		return nil, err
+	}
+	if m.rows() != m.cols() {
+		return nil, fmt.Errorf("expected a square matrix; found a %s matrix", m.shape())
+	}
+
+	return m, nil
+}
+
+func columnOf(v []float64) dense {
+	out := newDense(len(v), 1)
+	for i, x := range v {
+		out[i][0] = x
+	}
+	return out
+}
+
+// factorisation is an LU decomposition with partial pivoting. lu holds both the unit lower and upper triangles and
+// row i of lu corresponds to row perm[i] of the original.
+type factorisation struct {
+	lu   dense
+	perm []int
+	sign float64
+}
+
+func factorise(m dense) (*factorisation, error) {
+	n := m.rows()
+	lu := newDense(n, n)
+	var scale float64
+	for i := range m {
+		copy(lu[i], m[i])
+		for _, x := range m[i] {
+			scale = math.Max(scale, math.Abs(x))
+		}
+	}
+
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	sign := 1.0
+
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu[i][k]) > math.Abs(lu[p][k]) {
+				p = i
+			}
+		}
+		if math.Abs(lu[p][k]) <= singularTolerance*scale {
+			return nil, errSingular
+		}
+		if p != k {
+			lu[p], lu[k] = lu[k], lu[p]
+			perm[p], perm[k] = perm[k], perm[p]
+			sign = -sign
+		}
+
+		for i := k + 1; i < n; i++ {
+			lu[i][k] /= lu[k][k]
+			for j := k + 1; j < n; j++ {
+				lu[i][j] -= lu[i][k] * lu[k][j]
+			}
+		}
+	}
+
+	return &factorisation{lu: lu, perm: perm, sign: sign}, nil
+}
+
+// solve returns x such that the original matrix times x is b.
+func (f *factorisation) solve(b dense) dense {
+	n := len(f.lu)
+	x := newDense(n, b.cols())
+	for i := range x {
+		copy(x[i], b[f.perm[i]])
+	}
+
+	for j := range x[0] {
+		for i := 0; i < n; i++ {
+			for k := 0; k < i; k++ {
+				x[i][j] -= f.lu[i][k] * x[k][j]
+			}
+		}
+		for i := n - 1; i >= 0; i-- {
+			for k := i + 1; k < n; k++ {
+				x[i][j] -= f.lu[i][k] * x[k][j]
+			}
+			x[i][j] /= f.lu[i][i]
+		}
+	}
+
+	return x
+}
@@ -0,0 +1,133 @@
+// Package matrix provides linear algebra builtins over lists of lists of numbers, where each inner list is a row.
+// A list of numbers is treated as a matrix with a single row.
+package matrix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+type dense [][]float64
+
+func (m dense) rows() int {
+	return len(m)
+}
+
+func (m dense) cols() int {
+	return len(m[0])
+}
+
+func (m dense) shape() string {
+	return fmt.Sprintf("%d×%d", m.rows(), m.cols())
+}
+
+func newDense(rows, cols int) dense {
+	m := make(dense, rows)
+	for i := range m {
+		m[i] = make([]float64, cols)
+	}
+	return m
+}
+
+func identity(n int) dense {
+	m := newDense(n, n)
+	for i := range m {
+		m[i][i] = 1
+	}
+	return m
+}
+
+// toMatrix converts a list of rows, or a single row of numbers, to a matrix. Every row must be the same length.
+func toMatrix(obj *types.Object) (dense, error) {
+	l, err := obj.ToList()
+	if err != nil {
+		return nil, fmt.Errorf("expected a matrix; found a %s", obj.Type())
+	}
+	if len(l.Elements) == 0 {
+		return nil, errors.New("expected a matrix; found an empty list")
+	}
+
+	if l.Elements[0].Type() != types.TypeList {
+		row, err := toRow(l.Elements, 1)
+		if err != nil {
+			return nil, err
+		}
+		return dense{row}, nil
+	}
+
+	m := make(dense, len(l.Elements))
+	for i, el := range l.Elements {
+		r, err := el.ToList()
+		if err != nil {
+			return nil, fmt.Errorf("expected row %d to be a list; found a %s", i+1, el.Type())
+		}
+		m[i], err = toRow(r.Elements, i+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(m[i]) != len(m[0]) {
+			return nil, fmt.Errorf("expected every row to have %d columns like row 1; row %d has %d", len(m[0]), i+1, len(m[i]))
+		}
+	}
+
+	return m, nil
+}
+
+func toRow(elements []*types.Object, row int) ([]float64, error) {
+	if len(elements) == 0 {
+		return nil, fmt.Errorf("expected row %d to have at least one column", row)
+	}
+
+	out := make([]float64, len(elements))
+	for j, el := range elements {
+		n, err := el.ToNumber()
+		if err != nil {
+			return nil, fmt.Errorf("expected a number at row %d, column %d; found a %s", row, j+1, el.Type())
+		}
+		if u := el.Unit(); u != nil {
+			return nil, fmt.Errorf("expected a number without a unit at row %d, column %d; found one in %s", row, j+1, u)
+		}
+		out[j] = n
+	}
+
+	return out, nil
+}
+
+// toVector converts a list of numbers, or a matrix with a single column, to a vector.
+func toVector(obj *types.Object) ([]float64, error) {
+	m, err := toMatrix(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case m.rows() == 1:
+		return m[0], nil
+	case m.cols() == 1:
+		out := make([]float64, m.rows())
+		for i, row := range m {
+			out[i] = row[0]
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("expected a vector; found a %s matrix", m.shape())
+	}
+}
+
+func fromMatrix(m dense) *types.Object {
+	rows := make([]*types.Object, len(m))
+	for i, row := range m {
+		rows[i] = fromVector(row)
+	}
+	return types.NewList(rows)
+}
+
+func fromVector(v []float64) *types.Object {
+	out := make([]*types.Object, len(v))
+	for i, n := range v {
+		out[i] = types.NewNumber(n)
+	}
+	return types.NewList(out)
+}
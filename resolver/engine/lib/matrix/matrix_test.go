@@ -0,0 +1,170 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/internal/libtest"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+func m(rows ...[]float64) *types.Object {
+	return fromMatrix(rows)
+}
+
+func v(xs ...float64) *types.Object {
+	return fromVector(xs)
+}
+
+func expectMatrix(t *testing.T, name string, result *types.Object, err error, expected dense) {
+	if err != nil {
+		t.Fatalf("Unexpected error from %s: %v", name, err)
+	}
+	actual, err := toMatrix(result)
+	if err != nil {
+		t.Fatalf("Expected %s to return a matrix: %v", name, err)
+	}
+	if actual.rows() != expected.rows() || actual.cols() != expected.cols() {
+		t.Fatalf("Expected %s to return a %s matrix; got %s", name, expected.shape(), actual.shape())
+	}
+
+	for i := range expected {
+		for j := range expected[i] {
+			if math.Abs(actual[i][j]-expected[i][j]) > 1e-9 {
+				t.Errorf("Expected %s to be %v; got %v", name, expected, actual)
+				return
+			}
+		}
+	}
+}
+
+func TestMMult(t *testing.T) {
+	res, err := MMult([]*types.Object{m([]float64{1, 2, 3}, []float64{4, 5, 6}), m([]float64{7, 8}, []float64{9, 10}, []float64{11, 12})})
+	expectMatrix(t, "MMULT", res, err, dense{{58, 64}, {139, 154}})
+
+	_, err = MMult([]*types.Object{m([]float64{1, 2, 3}), m([]float64{1, 2}, []float64{3, 4})})
+	libtest.ExpectError(t, "MMULT", err, "cannot multiply a 1×3 matrix by a 2×2 matrix")
+}
+
+func TestTranspose(t *testing.T) {
+	res, err := Transpose([]*types.Object{m([]float64{1, 2, 3}, []float64{4, 5, 6})})
+	expectMatrix(t, "TRANSPOSE", res, err, dense{{1, 4}, {2, 5}, {3, 6}})
+
+	res, err = Transpose([]*types.Object{v(1, 2)})
+	expectMatrix(t, "TRANSPOSE", res, err, dense{{1}, {2}})
+
+	_, err = Transpose([]*types.Object{m([]float64{1, 2}, []float64{3})})
+	libtest.ExpectError(t, "TRANSPOSE", err, "row 2 has 1")
+}
+
+func TestMDeterm(t *testing.T) {
+	cases := []struct {
+		matrix   *types.Object
+		expected float64
+	}{
+		{m([]float64{3, 8}, []float64{4, 6}), -14},
+		{m([]float64{6, 1, 1}, []float64{4, -2, 5}, []float64{2, 8, 7}), -306},
+		{m([]float64{0, 1}, []float64{1, 0}), -1},
+		{m([]float64{1, 2}, []float64{2, 4}), 0},
+	}
+
+	for _, c := range cases {
+		res, err := MDeterm([]*types.Object{c.matrix})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if n, _ := res.ToNumber(); math.Abs(n-c.expected) > 1e-9 {
+			t.Errorf("Expected MDETERM to be %v; got %v", c.expected, n)
+		}
+	}
+
+	_, err := MDeterm([]*types.Object{m([]float64{1, 2, 3})})
+	libtest.ExpectError(t, "MDETERM", err, "expected a square matrix; found a 1×3 matrix")
+}
+
+func TestMInverse(t *testing.T) {
+	res, err := MInverse([]*types.Object{m([]float64{4, -1}, []float64{2, 0})})
+	expectMatrix(t, "MINVERSE", res, err, dense{{0, 0.5}, {-1, 2}})
+
+	_, err = MInverse([]*types.Object{m([]float64{1, 2}, []float64{2, 4})})
+	libtest.ExpectError(t, "MINVERSE", err, "singular")
+}
+
+func TestSolve(t *testing.T) {
+	a := m([]float64{2, 1, -1}, []float64{-3, -1, 2}, []float64{-2, 1, 2})
+
+	res, err := Solve([]*types.Object{a, v(8, -11, -3)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	x, err := toVector(res)
+	if err != nil {
+		t.Fatalf("Expected a vector: %v", err)
+	}
+	for i, expected := range []float64{2, 3, -1} {
+		if math.Abs(x[i]-expected) > 1e-9 {
+			t.Errorf("Expected SOLVE to be [2, 3, -1]; got %v", x)
+		}
+	}
+
+	res, err = Solve([]*types.Object{a, m([]float64{8, 1}, []float64{-11, 0}, []float64{-3, 0})})
+	expectMatrix(t, "SOLVE", res, err, dense{{2, 4}, {3, -2}, {-1, 5}})
+
+	_, err = Solve([]*types.Object{a, v(1, 2)})
+	libtest.ExpectError(t, "SOLVE", err, "cannot solve a 3×3 system for 2 values")
+
+	_, err = Solve([]*types.Object{m([]float64{1, 2}, []float64{2, 4}), v(1, 2)})
+	libtest.ExpectError(t, "SOLVE", err, "no unique solution")
+}
+
+func TestDot(t *testing.T) {
+	res, err := Dot([]*types.Object{v(1, 2, 3), m([]float64{4}, []float64{5}, []float64{6})})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n, _ := res.ToNumber(); n != 32 {
+		t.Errorf("Expected DOT to be 32; got %v", n)
+	}
+
+	_, err = Dot([]*types.Object{v(1, 2, 3), v(1, 2)})
+	libtest.ExpectError(t, "DOT", err, "vectors of length 3 and 2")
+}
+
+func TestBroadcast(t *testing.T) {
+	grid := m([]float64{1, 2, 3}, []float64{4, 5, 6})
+
+	res, err := Add([]*types.Object{grid, types.NewNumber(10)})
+	expectMatrix(t, "ADD", res, err, dense{{11, 12, 13}, {14, 15, 16}})
+
+	res, err = Multiply([]*types.Object{grid, v(1, 10, 100)})
+	expectMatrix(t, "MULTIPLY", res, err, dense{{1, 20, 300}, {4, 50, 600}})
+
+	res, err = Subtract([]*types.Object{grid, m([]float64{1}, []float64{4})})
+	expectMatrix(t, "SUBTRACT", res, err, dense{{0, 1, 2}, {0, 1, 2}})
+
+	res, err = Divide([]*types.Object{types.NewNumber(6), types.NewNumber(4)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n, _ := res.ToNumber(); n != 1.5 {
+		t.Errorf("Expected DIVIDE to be 1.5; got %v", n)
+	}
+
+	_, err = Add([]*types.Object{grid, v(1, 2)})
+	libtest.ExpectError(t, "ADD", err, "cannot broadcast shapes 2×3 and 2")
+
+	_, err = Divide([]*types.Object{v(1, 2), v(1, 0)})
+	libtest.ExpectError(t, "DIVIDE", err, "cannot divide by zero")
+
+	_, err = Add([]*types.Object{v(1), types.NewString("a")})
+	libtest.ExpectError(t, "ADD", err, "found a string")
+}
+
+func TestUnits(t *testing.T) {
+	row := types.NewList([]*types.Object{libtest.Quantity(t, 1, "km"), libtest.Quantity(t, 2, "km")})
+
+	_, err := Transpose([]*types.Object{types.NewList([]*types.Object{row})})
+	if err == nil || err.Error() != "expected a number without a unit at row 1, column 1; found one in km" {
+		t.Errorf("Expected an error transposing distances; got %v", err)
+	}
+}
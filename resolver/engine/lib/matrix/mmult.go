@@ -0,0 +1,39 @@
+package matrix
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// MMult returns the matrix product of two matrices. The first must have as many columns as the second has rows.
+var MMult = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	a, err := toMatrix(params[0])
+	if err != nil {
+		return nil, err
+	}
+	b, err := toMatrix(params[1])
+	if err != nil {
+		return nil, err
+	}
+	if a.cols() != b.rows() {
+		return nil, fmt.Errorf("cannot multiply a %s matrix by a %s matrix; the first has %d columns but the second has %d rows", a.shape(), b.shape(), a.cols(), b.rows())
+	}
+
+	return fromMatrix(multiply(a, b)), nil
+}
+
+func multiply(a, b dense) dense {
+	out := newDense(a.rows(), b.cols())
+	for i := range out {
+		for j := range out[i] {
+			for k := range b {
+				out[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return out
+}
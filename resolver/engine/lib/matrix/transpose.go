@@ -0,0 +1,27 @@
+package matrix
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Transpose swaps the rows and columns of a matrix. A list of numbers becomes a single column.
+var Transpose = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 1 {
+		return nil, fmt.Errorf("expected exactly 1 parameter; found %d", n)
+	}
+	m, err := toMatrix(params[0])
+	if err != nil {
+		return nil, err
+	}
+
+	out := newDense(m.cols(), m.rows())
+	for i, row := range m {
+		for j, x := range row {
+			out[j][i] = x
+		}
+	}
+
+	return fromMatrix(out), nil
+}
@@ -0,0 +1,163 @@
+// Package money provides builtins for amounts of money in a currency. Amounts are exact decimals, and amounts in
+// different currencies can only be combined once FX converts them to the same one.
+package money
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Quotients are rounded to this many decimal places.
+const divisionPlaces = 12
+
+// Money creates an amount of money from a number or, to avoid any rounding, a string such as "1234.50".
+var Money = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	amount, err := toDecimal(params[0])
+	if err != nil {
+		return nil, err
+	}
+	currency, err := params[1].ToString()
+	if err != nil {
+		return nil, fmt.Errorf("expected the currency to be a string; found a %s", params[1].Type())
+	}
+
+	return types.NewMoney(amount, currency)
+}
+
+// Fx converts an amount of money to another currency using a record of rates keyed by currency code. Each rate is
+// the value of the same base amount in that currency, such as {USD = 1, EUR = 0.92}.
+var Fx = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 3 {
+		return nil, fmt.Errorf("expected exactly 3 parameters; found %d", n)
+	}
+	m, err := params[0].ToMoney()
+	if err != nil {
+		return nil, fmt.Errorf("expected an amount of money; found a %s", params[0].Type())
+	}
+	to, err := params[1].ToString()
+	if err != nil {
+		return nil, fmt.Errorf("expected the currency to be a string; found a %s", params[1].Type())
+	}
+	rates, err := params[2].ToRecord()
+	if err != nil {
+		return nil, fmt.Errorf("expected rates to be a record; found a %s", params[2].Type())
+	}
+
+	if m.Currency == to {
+		return params[0], nil
+	}
+	from, err := rate(rates, m.Currency)
+	if err != nil {
+		return nil, err
+	}
+	into, err := rate(rates, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewMoney(m.Amount.Mul(into).Quo(from, divisionPlaces), to)
+}
+
+// Arithmetic applies one of the operators `+`, `-`, `*` or `/` where either operand is money. Money may be added to
+// or subtracted from money in the same currency, multiplied or divided by plain numbers, and divided by money in the
+// same currency to give a plain number.
+func Arithmetic(op rune, a, b *types.Object) (*types.Object, error) {
+	ma, _ := a.ToMoney()
+	mb, _ := b.ToMoney()
+
+	switch {
+	case ma != nil && mb != nil:
+		if ma.Currency != mb.Currency {
+			return nil, fmt.Errorf("cannot combine %s and %s; convert one with FX first", ma.Currency, mb.Currency)
+		}
+		switch op {
+		case '+':
+			return types.NewMoney(ma.Amount.Add(mb.Amount), ma.Currency)
+		case '-':
+			return types.NewMoney(ma.Amount.Sub(mb.Amount), ma.Currency)
+		case '/':
+			if mb.Amount.Sign() == 0 {
+				return nil, errors.New("cannot divide by zero")
+			}
+			return types.NewNumber(ma.Amount.Quo(mb.Amount, divisionPlaces).Float64()), nil
+		default:
+			return nil, errors.New("cannot multiply money by money")
+		}
+	case ma != nil:
+		return scale(op, ma, b, false)
+	case mb != nil:
+		return scale(op, mb, a, true)
+	default:
+		return nil, errors.New("expected money")
+	}
+}
+
+// scale multiplies or divides money by a plain number. flipped means the number came first.
+func scale(op rune, m *types.Money, n *types.Object, flipped bool) (*types.Object, error) {
+	if n.Type() != types.TypeNumber || n.Unit() != nil {
+		return nil, fmt.Errorf("cannot combine %s with a %s", m.Currency, describe(n))
+	}
+	if op == '+' || op == '-' {
+		return nil, fmt.Errorf("cannot add or subtract a number without a currency and %s", m.Currency)
+	}
+	if op == '/' && flipped {
+		return nil, fmt.Errorf("cannot divide by %s", m.Currency)
+	}
+
+	f, _ := n.ToNumber()
+	d, err := types.DecimalFromFloat(f)
+	if err != nil {
+		return nil, err
+	}
+	if op == '*' {
+		return types.NewMoney(m.Amount.Mul(d), m.Currency)
+	}
+	if d.Sign() == 0 {
+		return nil, errors.New("cannot divide by zero")
+	}
+	return types.NewMoney(m.Amount.Quo(d, divisionPlaces), m.Currency)
+}
+
+func rate(rates *types.Record, currency string) (types.Decimal, error) {
+	v, ok := rates.Get(currency)
+	if !ok {
+		return types.Decimal{}, fmt.Errorf("rates has no rate for `%s`", currency)
+	}
+	d, err := toDecimal(v)
+	if err != nil {
+		return types.Decimal{}, err
+	}
+	if d.Sign() <= 0 {
+		return types.Decimal{}, fmt.Errorf("expected the rate for `%s` to be positive", currency)
+	}
+
+	return d, nil
+}
+
+func toDecimal(obj *types.Object) (types.Decimal, error) {
+	switch obj.Type() {
+	case types.TypeNumber:
+		if obj.Unit() != nil {
+			return types.Decimal{}, fmt.Errorf("expected a number without a unit; found %s", obj.Unit())
+		}
+		n, _ := obj.ToNumber()
+		return types.DecimalFromFloat(n)
+	case types.TypeString:
+		s, _ := obj.ToString()
+		return types.ParseDecimal(s)
+	default:
+		return types.Decimal{}, fmt.Errorf("expected a number; found a %s", obj.Type())
+	}
+}
+
+func describe(obj *types.Object) string {
+	if u := obj.Unit(); u != nil {
+		return "number in " + u.String()
+	}
+	return string(obj.Type())
+}
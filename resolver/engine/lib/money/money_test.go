@@ -0,0 +1,93 @@
+package money
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/internal/libtest"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+func TestMoney(t *testing.T) {
+	res, err := Money([]*types.Object{types.NewNumber(12.5), types.NewString("USD")})
+	libtest.ExpectMoney(t, "MONEY", res, err, "12.50 USD")
+
+	res, err = Money([]*types.Object{types.NewString("1234.5670"), types.NewString("EUR")})
+	libtest.ExpectMoney(t, "MONEY", res, err, "1234.567 EUR")
+
+	res, err = Money([]*types.Object{types.NewNumber(500), types.NewString("JPY")})
+	libtest.ExpectMoney(t, "MONEY", res, err, "500 JPY")
+
+	if _, err := Money([]*types.Object{types.NewNumber(1), types.NewString("XYZ")}); err == nil {
+		t.Error("Expected an error for an unknown currency")
+	}
+	if _, err := Money([]*types.Object{types.NewString("1.2.3"), types.NewString("USD")}); err == nil {
+		t.Error("Expected an error for an invalid amount")
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	// Floats can't add these exactly.
+	res, err := Arithmetic('+', libtest.Money(t, "0.10", "USD"), libtest.Money(t, "0.20", "USD"))
+	libtest.ExpectMoney(t, "ADD", res, err, "0.30 USD")
+
+	res, err = Arithmetic('-', libtest.Money(t, "10", "GBP"), libtest.Money(t, "0.01", "GBP"))
+	libtest.ExpectMoney(t, "SUBTRACT", res, err, "9.99 GBP")
+
+	res, err = Arithmetic('*', libtest.Money(t, "19.99", "USD"), types.NewNumber(3))
+	libtest.ExpectMoney(t, "MULTIPLY", res, err, "59.97 USD")
+
+	res, err = Arithmetic('*', types.NewNumber(0.5), libtest.Money(t, "0.05", "USD"))
+	libtest.ExpectMoney(t, "MULTIPLY", res, err, "0.025 USD")
+
+	res, err = Arithmetic('/', libtest.Money(t, "10", "USD"), types.NewNumber(3))
+	libtest.ExpectMoney(t, "DIVIDE", res, err, "3.333333333333 USD")
+
+	res, err = Arithmetic('/', libtest.Money(t, "1", "USD"), libtest.Money(t, "4", "USD"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n, _ := res.ToNumber(); n != 0.25 {
+		t.Errorf("Expected a ratio of 0.25; got %v", n)
+	}
+
+	errorCases := []struct {
+		op       rune
+		a, b     *types.Object
+		contains string
+	}{
+		{'+', libtest.Money(t, "1", "USD"), libtest.Money(t, "1", "EUR"), "cannot combine USD and EUR; convert one with FX first"},
+		{'+', libtest.Money(t, "1", "USD"), types.NewNumber(1), "cannot add or subtract a number without a currency"},
+		{'*', libtest.Money(t, "1", "USD"), libtest.Money(t, "1", "USD"), "cannot multiply money by money"},
+		{'/', types.NewNumber(1), libtest.Money(t, "1", "USD"), "cannot divide by USD"},
+		{'/', libtest.Money(t, "1", "USD"), types.NewNumber(0), "cannot divide by zero"},
+	}
+	for _, c := range errorCases {
+		_, err := Arithmetic(c.op, c.a, c.b)
+		if err == nil || !strings.Contains(err.Error(), c.contains) {
+			t.Errorf("Expected an error containing %q; got %v", c.contains, err)
+		}
+	}
+}
+
+func TestFx(t *testing.T) {
+	rates := types.NewRecord([]*types.RecordProperty{
+		{Name: "USD", Value: types.NewNumber(1)},
+		{Name: "EUR", Value: types.NewNumber(0.8)},
+		{Name: "JPY", Value: types.NewNumber(150)},
+	})
+
+	res, err := Fx([]*types.Object{libtest.Money(t, "100", "USD"), types.NewString("EUR"), rates})
+	libtest.ExpectMoney(t, "FX", res, err, "80.00 EUR")
+
+	res, err = Fx([]*types.Object{libtest.Money(t, "100", "EUR"), types.NewString("USD"), rates})
+	libtest.ExpectMoney(t, "FX", res, err, "125.00 USD")
+
+	res, err = Fx([]*types.Object{libtest.Money(t, "10", "EUR"), types.NewString("JPY"), rates})
+	libtest.ExpectMoney(t, "FX", res, err, "1875 JPY")
+
+	_, err = Fx([]*types.Object{libtest.Money(t, "10", "GBP"), types.NewString("USD"), rates})
+	if err == nil || err.Error() != "rates has no rate for `GBP`" {
+		t.Errorf("Expected an error for a missing rate; got %v", err)
+	}
+}
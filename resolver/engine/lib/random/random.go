@@ -0,0 +1,107 @@
+// Package random provides builtins which sample from probability distributions. They only draw random numbers
+// while a simulation is running; anywhere else they return the mean of their distribution so that ordinary
+// formulas always resolve to the same value.
+package random
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Normal samples from a normal distribution with mean mu and standard deviation sigma.
+var Normal = func(rng *rand.Rand, params []*types.Object) (*types.Object, error) {
+	xs, err := numberArgs(params, 2)
+	if err != nil {
+		return nil, err
+	}
+	mu, sigma := xs[0], xs[1]
+	if sigma < 0 {
+		return nil, fmt.Errorf("expected sigma to be at least 0; found %v", sigma)
+	}
+
+	if rng == nil {
+		return types.NewNumber(mu), nil
+	}
+	return types.NewNumber(mu + sigma*rng.NormFloat64()), nil
+}
+
+// Uniform samples evenly from between low and high.
+var Uniform = func(rng *rand.Rand, params []*types.Object) (*types.Object, error) {
+	xs, err := numberArgs(params, 2)
+	if err != nil {
+		return nil, err
+	}
+	low, high := xs[0], xs[1]
+	if low > high {
+		return nil, fmt.Errorf("expected low to be no greater than high; found %v and %v", low, high)
+	}
+
+	if rng == nil {
+		return types.NewNumber((low + high) / 2), nil
+	}
+	return types.NewNumber(low + (high-low)*rng.Float64()), nil
+}
+
+// Triangular samples from a triangular distribution between low and high which peaks at mode. It's a common way
+// to model an estimate given as a best guess along with optimistic and pessimistic bounds.
+var Triangular = func(rng *rand.Rand, params []*types.Object) (*types.Object, error) {
+	xs, err := numberArgs(params, 3)
+	if err != nil {
+		return nil, err
+	}
+	low, mode, high := xs[0], xs[1], xs[2]
+	if low > mode || mode > high || low == high {
+		return nil, fmt.Errorf("expected low <= mode <= high with low < high; found %v, %v and %v", low, mode, high)
+	}
+
+	if rng == nil {
+		return types.NewNumber((low + mode + high) / 3), nil
+	}
+
+	// Invert the cumulative distribution at a uniform sample.
+	u := rng.Float64()
+	width := high - low
+	if u < (mode-low)/width {
+		return types.NewNumber(low + math.Sqrt(u*width*(mode-low))), nil
+	}
+	return types.NewNumber(high - math.Sqrt((1-u)*width*(high-mode))), nil
+}
+
+// Lognormal samples from a distribution whose natural logarithm is normally distributed with mean mu and standard
+// deviation sigma.
+var Lognormal = func(rng *rand.Rand, params []*types.Object) (*types.Object, error) {
+	xs, err := numberArgs(params, 2)
+	if err != nil {
+		return nil, err
+	}
+	mu, sigma := xs[0], xs[1]
+	if sigma < 0 {
+		return nil, fmt.Errorf("expected sigma to be at least 0; found %v", sigma)
+	}
+
+	if rng == nil {
+		return types.NewNumber(math.Exp(mu + sigma*sigma/2)), nil
+	}
+	return types.NewNumber(math.Exp(mu + sigma*rng.NormFloat64())), nil
+}
+
+// numberArgs checks there are exactly n params and that each is a number.
+func numberArgs(params []*types.Object, n int) ([]float64, error) {
+	if len(params) != n {
+		return nil, fmt.Errorf("expected exactly %d parameters; found %d", n, len(params))
+	}
+
+	out := make([]float64, n)
+	for i, p := range params {
+		x, err := p.ToNumber()
+		if err != nil {
+			return nil, err
+		}
+		out[i] = x
+	}
+
+	return out, nil
+}
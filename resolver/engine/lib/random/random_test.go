@@ -0,0 +1,102 @@
+package random
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/internal/libtest"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+type distribution func(rng *rand.Rand, params []*types.Object) (*types.Object, error)
+
+// sampleMean draws n samples and returns their mean along with the smallest and largest seen.
+func sampleMean(t *testing.T, d distribution, params []*types.Object, n int) (float64, float64, float64) {
+	rng := rand.New(rand.NewSource(42))
+	var total float64
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for i := 0; i < n; i++ {
+		res, err := d(rng, params)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		x, err := res.ToNumber()
+		if err != nil {
+			t.Fatalf("Expected a number; got a %s", res.Type())
+		}
+		total += x
+		lowest = math.Min(lowest, x)
+		highest = math.Max(highest, x)
+	}
+
+	return total / float64(n), lowest, highest
+}
+
+func TestDistributions(t *testing.T) {
+	cases := []struct {
+		name   string
+		d      distribution
+		params []*types.Object
+		mean   float64
+		low    float64
+		high   float64
+	}{
+		{"NORMAL", Normal, libtest.Numbers(100, 10), 100, math.Inf(-1), math.Inf(1)},
+		{"UNIFORM", Uniform, libtest.Numbers(2, 4), 3, 2, 4},
+		{"TRIANGULAR", Triangular, libtest.Numbers(1, 2, 6), 3, 1, 6},
+		{"LOGNORMAL", Lognormal, libtest.Numbers(0, 0.5), math.Exp(0.125), 0, math.Inf(1)},
+	}
+
+	for _, c := range cases {
+		res, err := c.d(nil, c.params)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if x, _ := res.ToNumber(); math.Abs(x-c.mean) > 1e-12 {
+			t.Errorf("Expected %s without a simulation to return its mean %v; got %v", c.name, c.mean, x)
+		}
+
+		m, lowest, highest := sampleMean(t, c.d, c.params, 20000)
+		if math.Abs(m-c.mean) > 0.02*c.mean {
+			t.Errorf("Expected samples of %s to average about %v; got %v", c.name, c.mean, m)
+		}
+		if lowest < c.low || highest > c.high {
+			t.Errorf("Expected samples of %s to fall within [%v, %v]; got [%v, %v]", c.name, c.low, c.high, lowest, highest)
+		}
+	}
+}
+
+func TestDistributions_Reproducible(t *testing.T) {
+	params := libtest.Numbers(0, 1)
+	a, b := rand.New(rand.NewSource(7)), rand.New(rand.NewSource(7))
+	for i := 0; i < 10; i++ {
+		xRes, _ := Normal(a, params)
+		yRes, _ := Normal(b, params)
+		x, _ := xRes.ToNumber()
+		y, _ := yRes.ToNumber()
+		if x != y {
+			t.Fatalf("Expected the same seed to produce the same samples; got %v and %v", x, y)
+		}
+	}
+}
+
+func TestDistributions_InvalidParams(t *testing.T) {
+	cases := []struct {
+		name   string
+		d      distribution
+		params []*types.Object
+	}{
+		{"NORMAL", Normal, libtest.Numbers(0, -1)},
+		{"UNIFORM", Uniform, libtest.Numbers(4, 2)},
+		{"TRIANGULAR", Triangular, libtest.Numbers(1, 7, 6)},
+		{"TRIANGULAR", Triangular, libtest.Numbers(3, 3, 3)},
+		{"LOGNORMAL", Lognormal, libtest.Numbers(0)},
+	}
+
+	for _, c := range cases {
+		if _, err := c.d(nil, c.params); err == nil {
+			t.Errorf("Expected an error from %s with %v", c.name, c.params)
+		}
+	}
+}
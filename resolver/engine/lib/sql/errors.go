@@ -0,0 +1,21 @@
+package sql
+
+import "fmt"
+
+// Error is a problem with a query, found either while parsing or while running it.
+type Error struct {
+	// Position of the problem in the query, counting characters from 1.
+	Position int
+	Message  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+func errorAt(pos int, format string, args ...interface{}) error {
+	return &Error{
+		Position: pos + 1,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,525 @@
+package sql
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// scope is what an expression is evaluated against: a single row or, in a grouped query, a group of rows.
+type scope struct {
+	// The row, or the first row of the group. Nil when no rows are available.
+	row *types.Record
+	// The rows aggregates are computed over. Nil where aggregates aren't allowed.
+	group []*types.Record
+}
+
+// result is a row of output along with the scope it was computed in, which ORDER BY may still need.
+type result struct {
+	record *types.Record
+	scope  *scope
+}
+
+func run(s *statement, rows []*types.Record) ([]*types.Record, error) {
+	if s.where != nil {
+		var kept []*types.Record
+		for _, row := range rows {
+			keep, err := evalBoolean(s.where, &scope{row: row})
+			if err != nil {
+				return nil, err
+			}
+			if keep {
+				kept = append(kept, row)
+			}
+		}
+		rows = kept
+	}
+
+	scopes, err := groupScopes(s, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*result
+	for _, sc := range scopes {
+		if s.having != nil {
+			keep, err := evalBoolean(s.having, sc)
+			if err != nil {
+				return nil, err
+			}
+			if !keep {
+				continue
+			}
+		}
+
+		record, err := project(s, sc)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &result{record: record, scope: sc})
+	}
+
+	if err := order(s, results); err != nil {
+		return nil, err
+	}
+
+	offset, err := evalCount(s.offset, 0)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := evalCount(s.limit, len(results))
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*types.Record
+	for i := offset; i < len(results) && i-offset < limit; i++ {
+		out = append(out, results[i].record)
+	}
+	return out, nil
+}
+
+// groupScopes returns a scope for each row or, in a grouped query, for each group in the order they first appear.
+func groupScopes(s *statement, rows []*types.Record) ([]*scope, error) {
+	if !isGrouped(s) {
+		scopes := make([]*scope, len(rows))
+		for i, row := range rows {
+			scopes[i] = &scope{row: row}
+		}
+		return scopes, nil
+	}
+
+	if err := checkGrouping(s); err != nil {
+		return nil, err
+	}
+	if len(s.groupBy) == 0 {
+		// Aggregating without GROUP BY produces a single row, even for no data.
+		sc := &scope{group: rows}
+		if len(rows) > 0 {
+			sc.row = rows[0]
+		}
+		return []*scope{sc}, nil
+	}
+
+	var scopes []*scope
+	byKey := make(map[string]*scope)
+	for _, row := range rows {
+		var key string
+		for _, g := range s.groupBy {
+			v, err := eval(g, &scope{row: row})
+			if err != nil {
+				return nil, err
+			}
+			k, err := types.Key(v)
+			if err != nil {
+				return nil, errorAt(g.pos, "cannot group by `%s`: %s", g.name, err)
+			}
+			key += k
+		}
+
+		sc, ok := byKey[key]
+		if !ok {
+			sc = &scope{row: row}
+			byKey[key] = sc
+			scopes = append(scopes, sc)
+		}
+		sc.group = append(sc.group, row)
+	}
+
+	return scopes, nil
+}
+
+func isGrouped(s *statement) bool {
+	if len(s.groupBy) > 0 || s.having != nil {
+		return true
+	}
+	for _, c := range s.columns {
+		if hasAggregate(c.expr) {
+			return true
+		}
+	}
+	for _, t := range s.orderBy {
+		if hasAggregate(t.expr) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAggregate(e *expr) bool {
+	if e == nil {
+		return false
+	}
+	if e.kind == exprAggregate {
+		return true
+	}
+	for _, arg := range e.args {
+		if hasAggregate(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkGrouping makes sure that, outside of aggregates, a grouped query only refers to the columns it groups by.
+func checkGrouping(s *statement) error {
+	grouped := make(map[string]bool)
+	for _, g := range s.groupBy {
+		grouped[strings.ToLower(g.name)] = true
+	}
+
+	var check func(e *expr) error
+	check = func(e *expr) error {
+		if e == nil || e.kind == exprAggregate {
+			return nil
+		}
+		if e.kind == exprColumn && !grouped[strings.ToLower(e.name)] {
+			return errorAt(e.pos, "column `%s` must be in GROUP BY or used in an aggregate", e.name)
+		}
+		for _, arg := range e.args {
+			if err := check(arg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, c := range s.columns {
+		if c.expr == nil {
+			return errorAt(c.pos, "SELECT * cannot be used with GROUP BY or aggregates")
+		}
+		if err := check(c.expr); err != nil {
+			return err
+		}
+	}
+	if err := check(s.having); err != nil {
+		return err
+	}
+	for _, t := range s.orderBy {
+		if _, ok := outputColumn(s, t.expr); ok {
+			continue
+		}
+		if err := check(t.expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func project(s *statement, sc *scope) (*types.Record, error) {
+	out := &types.Record{}
+	for _, c := range s.columns {
+		if c.expr == nil {
+			for _, prop := range sc.row.Properties {
+				out = out.Set(prop.Name, prop.Value)
+			}
+			continue
+		}
+
+		if _, ok := out.Get(c.name); ok {
+			return nil, errorAt(c.pos, "duplicate column `%s`; rename it with AS", c.name)
+		}
+		v, err := eval(c.expr, sc)
+		if err != nil {
+			return nil, err
+		}
+		out = out.Set(c.name, v)
+	}
+
+	return out, nil
+}
+
+// outputColumn finds the column of the output an ORDER BY term refers to, either by position or by name.
+func outputColumn(s *statement, e *expr) (int, bool) {
+	if e.kind == exprLiteral && e.value.Type() == types.TypeNumber {
+		n, _ := e.value.ToNumber()
+		return int(n) - 1, true
+	}
+	if e.kind == exprColumn {
+		for i, c := range s.columns {
+			if c.expr != nil && c.name == e.name {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func order(s *statement, results []*result) error {
+	if len(s.orderBy) == 0 {
+		return nil
+	}
+
+	keys := make([][]*types.Object, len(results))
+	for i, r := range results {
+		keys[i] = make([]*types.Object, len(s.orderBy))
+		for j, t := range s.orderBy {
+			v, err := orderValue(s, t.expr, r)
+			if err != nil {
+				return err
+			}
+			keys[i][j] = v
+		}
+	}
+
+	indexes := make([]int, len(results))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	var sortErr error
+	sort.SliceStable(indexes, func(a, b int) bool {
+		for j, t := range s.orderBy {
+			cmp, err := types.Compare(keys[indexes[a]][j], keys[indexes[b]][j])
+			if err != nil {
+				if sortErr == nil {
+					sortErr = errorAt(t.expr.pos, "cannot sort: %s", err)
+				}
+				return false
+			}
+			if cmp != 0 {
+				return (cmp < 0) != t.descending
+			}
+		}
+		return false
+	})
+	if sortErr != nil {
+		return sortErr
+	}
+
+	sorted := make([]*result, len(results))
+	for i, j := range indexes {
+		sorted[i] = results[j]
+	}
+	copy(results, sorted)
+	return nil
+}
+
+func orderValue(s *statement, e *expr, r *result) (*types.Object, error) {
+	i, ok := outputColumn(s, e)
+	if !ok {
+		return eval(e, r.scope)
+	}
+
+	if i < 0 || i >= len(s.columns) || s.columns[i].expr == nil {
+		return nil, errorAt(e.pos, "there's no column %d to sort by", i+1)
+	}
+	v, _ := r.record.Get(s.columns[i].name)
+	return v, nil
+}
+
+func evalBoolean(e *expr, sc *scope) (bool, error) {
+	v, err := eval(e, sc)
+	if err != nil {
+		return false, err
+	}
+	b, err := v.ToBoolean()
+	if err != nil {
+		return false, errorAt(e.pos, "expected a boolean; found a %s", v.Type())
+	}
+	return b, nil
+}
+
+// evalCount evaluates the argument of LIMIT or OFFSET.
+func evalCount(e *expr, def int) (int, error) {
+	if e == nil {
+		return def, nil
+	}
+
+	v, err := eval(e, &scope{})
+	if err != nil {
+		return 0, err
+	}
+	n, err := v.ToNumber()
+	if err != nil || n < 0 || n != math.Trunc(n) || v.Type() != types.TypeNumber {
+		return 0, errorAt(e.pos, "expected a whole number of rows")
+	}
+	return int(n), nil
+}
+
+func eval(e *expr, sc *scope) (*types.Object, error) {
+	switch e.kind {
+	case exprLiteral:
+		return e.value, nil
+	case exprColumn:
+		if sc.row == nil {
+			return nil, errorAt(e.pos, "column `%s` cannot be used here", e.name)
+		}
+		return lookup(sc.row, e)
+	case exprUnary:
+		v, err := eval(e.args[0], sc)
+		if err != nil {
+			return nil, err
+		}
+		if e.name == "NOT" {
+			b, err := v.ToBoolean()
+			if err != nil {
+				return nil, errorAt(e.args[0].pos, "expected a boolean; found a %s", v.Type())
+			}
+			return types.NewBoolean(!b), nil
+		}
+		n, err := toNumber(e.args[0], v)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewNumber(-n), nil
+	case exprBinary:
+		return evalBinary(e, sc)
+	case exprAggregate:
+		return evalAggregate(e, sc)
+	default:
+		return nil, errorAt(e.pos, "unexpected expression")
+	}
+}
+
+func evalBinary(e *expr, sc *scope) (*types.Object, error) {
+	left, err := eval(e.args[0], sc)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.name == "AND" || e.name == "OR" {
+		l, err := left.ToBoolean()
+		if err != nil {
+			return nil, errorAt(e.args[0].pos, "expected a boolean; found a %s", left.Type())
+		}
+		if (e.name == "AND" && !l) || (e.name == "OR" && l) {
+			return types.NewBoolean(l), nil
+		}
+		r, err := evalBoolean(e.args[1], sc)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewBoolean(r), nil
+	}
+
+	right, err := eval(e.args[1], sc)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.name {
+	case "=", "!=", "<", "<=", ">", ">=":
+		cmp, err := types.Compare(left, right)
+		if err != nil {
+			return nil, errorAt(e.pos, "%s", err)
+		}
+		switch e.name {
+		case "=":
+			return types.NewBoolean(cmp == 0), nil
+		case "!=":
+			return types.NewBoolean(cmp != 0), nil
+		case "<":
+			return types.NewBoolean(cmp < 0), nil
+		case "<=":
+			return types.NewBoolean(cmp <= 0), nil
+		case ">":
+			return types.NewBoolean(cmp > 0), nil
+		default:
+			return types.NewBoolean(cmp >= 0), nil
+		}
+	}
+
+	l, err := toNumber(e.args[0], left)
+	if err != nil {
+		return nil, err
+	}
+	r, err := toNumber(e.args[1], right)
+	if err != nil {
+		return nil, err
+	}
+	switch e.name {
+	case "+":
+		return types.NewNumber(l + r), nil
+	case "-":
+		return types.NewNumber(l - r), nil
+	case "*":
+		return types.NewNumber(l * r), nil
+	default:
+		if r == 0 {
+			return nil, errorAt(e.args[1].pos, "division by zero")
+		}
+		return types.NewNumber(l / r), nil
+	}
+}
+
+func evalAggregate(e *expr, sc *scope) (*types.Object, error) {
+	if sc.group == nil {
+		return nil, errorAt(e.pos, "%s cannot be used here", e.name)
+	}
+	if e.name == "COUNT" && e.star {
+		return types.NewNumber(float64(len(sc.group))), nil
+	}
+
+	// Aggregates can't be nested so each row is evaluated on its own.
+	values := make([]*types.Object, len(sc.group))
+	for i, row := range sc.group {
+		var err error
+		values[i], err = eval(e.args[0], &scope{row: row})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch e.name {
+	case "COUNT":
+		return types.NewNumber(float64(len(values))), nil
+	case "SUM", "AVG":
+		var total float64
+		for _, v := range values {
+			n, err := toNumber(e.args[0], v)
+			if err != nil {
+				return nil, err
+			}
+			total += n
+		}
+		if e.name == "SUM" {
+			return types.NewNumber(total), nil
+		}
+		if len(values) == 0 {
+			return nil, errorAt(e.pos, "AVG of no rows")
+		}
+		return types.NewNumber(total / float64(len(values))), nil
+	default:
+		if len(values) == 0 {
+			return nil, errorAt(e.pos, "%s of no rows", e.name)
+		}
+		best := values[0]
+		for _, v := range values[1:] {
+			cmp, err := types.Compare(v, best)
+			if err != nil {
+				return nil, errorAt(e.pos, "%s", err)
+			}
+			if (e.name == "MIN" && cmp < 0) || (e.name == "MAX" && cmp > 0) {
+				best = v
+			}
+		}
+		return best, nil
+	}
+}
+
+// lookup finds a column by name, ignoring case if there's no exact match.
+func lookup(row *types.Record, e *expr) (*types.Object, error) {
+	if v, ok := row.Get(e.name); ok {
+		return v, nil
+	}
+	for _, prop := range row.Properties {
+		if strings.EqualFold(prop.Name, e.name) {
+			return prop.Value, nil
+		}
+	}
+
+	return nil, errorAt(e.pos, "unknown column `%s`", e.name)
+}
+
+func toNumber(e *expr, v *types.Object) (float64, error) {
+	if v.Type() != types.TypeNumber {
+		return 0, errorAt(e.pos, "expected a number; found a %s", v.Type())
+	}
+	if u := v.Unit(); u != nil {
+		return 0, errorAt(e.pos, "expected a number without a unit; found a number in %s", u)
+	}
+	n, _ := v.ToNumber()
+	return n, nil
+}
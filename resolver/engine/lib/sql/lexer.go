@@ -0,0 +1,144 @@
+package sql
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenKeyword
+	tokenNumber
+	tokenString
+	tokenSymbol
+)
+
+var keywords = map[string]bool{
+	"and":    true,
+	"as":     true,
+	"asc":    true,
+	"by":     true,
+	"desc":   true,
+	"false":  true,
+	"group":  true,
+	"having": true,
+	"limit":  true,
+	"not":    true,
+	"offset": true,
+	"or":     true,
+	"order":  true,
+	"select": true,
+	"true":   true,
+	"where":  true,
+}
+
+var symbols = map[string]bool{
+	"(":  true,
+	")":  true,
+	",":  true,
+	"*":  true,
+	"+":  true,
+	"-":  true,
+	"/":  true,
+	"=":  true,
+	"<":  true,
+	">":  true,
+	"<=": true,
+	">=": true,
+	"<>": true,
+	"!=": true,
+}
+
+type token struct {
+	kind tokenKind
+	// Keywords are upper case. Identifiers and strings are unquoted.
+	text string
+	// Offset of the first character in the query, counting from 0.
+	pos int
+	end int
+}
+
+func tokenize(query string) ([]*token, error) {
+	input := []rune(query)
+	var out []*token
+	pos := 0
+	for {
+		for pos < len(input) && unicode.IsSpace(input[pos]) {
+			pos++
+		}
+		if pos >= len(input) {
+			out = append(out, &token{kind: tokenEOF, pos: pos, end: pos})
+			return out, nil
+		}
+
+		start := pos
+		ch := input[pos]
+		switch {
+		case isIdentStart(ch):
+			for pos < len(input) && isIdent(input[pos]) {
+				pos++
+			}
+			word := string(input[start:pos])
+			if keywords[strings.ToLower(word)] {
+				out = append(out, &token{kind: tokenKeyword, text: strings.ToUpper(word), pos: start, end: pos})
+			} else {
+				out = append(out, &token{kind: tokenIdentifier, text: word, pos: start, end: pos})
+			}
+		case unicode.IsDigit(ch) || (ch == '.' && pos+1 < len(input) && unicode.IsDigit(input[pos+1])):
+			for pos < len(input) && (unicode.IsDigit(input[pos]) || input[pos] == '.') {
+				pos++
+			}
+			out = append(out, &token{kind: tokenNumber, text: string(input[start:pos]), pos: start, end: pos})
+		case ch == '\'' || ch == '"' || ch == '`':
+			// Backticks quote identifiers, such as column names with spaces. A quote is escaped by doubling it.
+			var text []rune
+			pos++
+			for {
+				if pos >= len(input) {
+					return nil, errorAt(start, "unterminated quote")
+				}
+				if input[pos] == ch {
+					if pos+1 < len(input) && input[pos+1] == ch {
+						text = append(text, ch)
+						pos += 2
+						continue
+					}
+					pos++
+					break
+				}
+				text = append(text, input[pos])
+				pos++
+			}
+
+			kind := tokenString
+			if ch == '`' {
+				kind = tokenIdentifier
+			}
+			out = append(out, &token{kind: kind, text: string(text), pos: start, end: pos})
+		default:
+			symbol := string(ch)
+			if pos+1 < len(input) {
+				switch pair := string(input[pos : pos+2]); pair {
+				case "<=", ">=", "<>", "!=":
+					symbol = pair
+				}
+			}
+			if !symbols[symbol] {
+				return nil, errorAt(start, "unexpected character %q", ch)
+			}
+			pos += len([]rune(symbol))
+			out = append(out, &token{kind: tokenSymbol, text: symbol, pos: start, end: pos})
+		}
+	}
+}
+
+func isIdentStart(ch rune) bool {
+	return ch == '_' || unicode.IsLetter(ch)
+}
+
+func isIdent(ch rune) bool {
+	return isIdentStart(ch) || unicode.IsDigit(ch)
+}
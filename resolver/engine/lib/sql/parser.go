@@ -0,0 +1,369 @@
+package sql
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+type statement struct {
+	columns []*column
+	where   *expr
+	groupBy []*expr
+	having  *expr
+	orderBy []*orderTerm
+	limit   *expr
+	offset  *expr
+}
+
+type column struct {
+	// Nil for `*`, which stands for every column of the data.
+	expr *expr
+	// The alias given with AS, or otherwise the column's source text.
+	name string
+	pos  int
+}
+
+type orderTerm struct {
+	expr       *expr
+	descending bool
+}
+
+type exprKind int
+
+const (
+	exprColumn exprKind = iota
+	exprLiteral
+	exprUnary
+	exprBinary
+	exprAggregate
+)
+
+type expr struct {
+	kind exprKind
+	// The column name, operator or upper case aggregate name.
+	name  string
+	value *types.Object
+	args  []*expr
+	// Set for COUNT(*).
+	star bool
+	pos  int
+	end  int
+}
+
+var aggregates = map[string]bool{
+	"AVG":   true,
+	"COUNT": true,
+	"MAX":   true,
+	"MIN":   true,
+	"SUM":   true,
+}
+
+type parser struct {
+	query  []rune
+	tokens []*token
+	next   int
+}
+
+func parse(query string) (*statement, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{
+		query:  []rune(query),
+		tokens: tokens,
+	}
+	return p.parseStatement()
+}
+
+func (p *parser) peek() *token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() *token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+// accept consumes the next token if it's the given keyword or symbol.
+func (p *parser) accept(text string) bool {
+	tok := p.peek()
+	if (tok.kind == tokenKeyword || tok.kind == tokenSymbol) && tok.text == text {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		return p.unexpected(text)
+	}
+	return nil
+}
+
+func (p *parser) unexpected(expected string) error {
+	return p.unexpectedToken(p.peek(), expected)
+}
+
+func (p *parser) unexpectedToken(tok *token, expected string) error {
+	if tok.kind == tokenEOF {
+		return errorAt(tok.pos, "expected %s but the query ended", expected)
+	}
+	return errorAt(tok.pos, "expected %s; found %q", expected, p.source(tok.pos, tok.end))
+}
+
+func (p *parser) source(pos, end int) string {
+	return strings.TrimSpace(string(p.query[pos:end]))
+}
+
+func (p *parser) parseStatement() (*statement, error) {
+	if err := p.expect("SELECT"); err != nil {
+		return nil, err
+	}
+
+	s := &statement{}
+	if tok := p.peek(); p.accept("*") {
+		s.columns = []*column{{name: "*", pos: tok.pos}}
+	} else {
+		for {
+			c, err := p.parseColumn()
+			if err != nil {
+				return nil, err
+			}
+			s.columns = append(s.columns, c)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	var err error
+	if p.accept("WHERE") {
+		if s.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("GROUP") {
+		if err := p.expect("BY"); err != nil {
+			return nil, err
+		}
+		for {
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if e.kind != exprColumn {
+				return nil, errorAt(e.pos, "expected a column to group by")
+			}
+			s.groupBy = append(s.groupBy, e)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+	if p.accept("HAVING") {
+		if s.having, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("ORDER") {
+		if err := p.expect("BY"); err != nil {
+			return nil, err
+		}
+		for {
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			term := &orderTerm{expr: e}
+			if p.accept("DESC") {
+				term.descending = true
+			} else {
+				p.accept("ASC")
+			}
+			s.orderBy = append(s.orderBy, term)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+	if p.accept("LIMIT") {
+		if s.limit, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.accept("OFFSET") {
+		if s.offset, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorAt(tok.pos, "unexpected %q", p.source(tok.pos, tok.end))
+	}
+	return s, nil
+}
+
+func (p *parser) parseColumn() (*column, error) {
+	e, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &column{
+		expr: e,
+		name: p.source(e.pos, e.end),
+		pos:  e.pos,
+	}
+	if e.kind == exprColumn {
+		c.name = e.name
+	}
+	if p.accept("AS") {
+		tok := p.advance()
+		if tok.kind != tokenIdentifier && tok.kind != tokenString {
+			return nil, p.unexpectedToken(tok, "a column name")
+		}
+		c.name = tok.text
+	}
+
+	return c, nil
+}
+
+// Operators from loosest to tightest binding.
+var binaryOperators = [][]string{
+	{"OR"},
+	{"AND"},
+	{"=", "!=", "<>", "<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/"},
+}
+
+func (p *parser) parseExpr() (*expr, error) {
+	return p.parseBinary(0)
+}
+
+func (p *parser) parseBinary(level int) (*expr, error) {
+	if level == len(binaryOperators) {
+		return p.parseUnary()
+	}
+	// NOT binds more loosely than comparisons but more tightly than AND.
+	if binaryOperators[level][0] == "=" {
+		if tok := p.peek(); tok.kind == tokenKeyword && tok.text == "NOT" {
+			p.advance()
+			operand, err := p.parseBinary(level)
+			if err != nil {
+				return nil, err
+			}
+			return &expr{kind: exprUnary, name: "NOT", args: []*expr{operand}, pos: tok.pos, end: operand.end}, nil
+		}
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		op := ""
+		for _, candidate := range binaryOperators[level] {
+			if (tok.kind == tokenKeyword || tok.kind == tokenSymbol) && tok.text == candidate {
+				op = candidate
+			}
+		}
+		if op == "" {
+			return left, nil
+		}
+		p.advance()
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		if op == "<>" {
+			op = "!="
+		}
+		left = &expr{kind: exprBinary, name: op, args: []*expr{left, right}, pos: left.pos, end: right.end}
+	}
+}
+
+func (p *parser) parseUnary() (*expr, error) {
+	tok := p.peek()
+	if tok.kind == tokenSymbol && tok.text == "-" {
+		p.advance()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &expr{kind: exprUnary, name: "-", args: []*expr{operand}, pos: tok.pos, end: operand.end}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (*expr, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokenNumber:
+		n, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, errorAt(tok.pos, "invalid number %q", tok.text)
+		}
+		return &expr{kind: exprLiteral, value: types.NewNumber(n), pos: tok.pos, end: tok.end}, nil
+	case tokenString:
+		return &expr{kind: exprLiteral, value: types.NewString(tok.text), pos: tok.pos, end: tok.end}, nil
+	case tokenKeyword:
+		switch tok.text {
+		case "TRUE", "FALSE":
+			return &expr{kind: exprLiteral, value: types.NewBoolean(tok.text == "TRUE"), pos: tok.pos, end: tok.end}, nil
+		}
+	case tokenSymbol:
+		if tok.text == "(" {
+			e, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			closing := p.peek()
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			// Keep the parentheses in the source text of the expression.
+			e.pos, e.end = tok.pos, closing.end
+			return e, nil
+		}
+	case tokenIdentifier:
+		if name := strings.ToUpper(tok.text); aggregates[name] && p.accept("(") {
+			return p.parseAggregate(name, tok)
+		}
+		return &expr{kind: exprColumn, name: tok.text, pos: tok.pos, end: tok.end}, nil
+	}
+
+	return nil, p.unexpectedToken(tok, "an expression")
+}
+
+func (p *parser) parseAggregate(name string, start *token) (*expr, error) {
+	e := &expr{kind: exprAggregate, name: name, pos: start.pos}
+	if name == "COUNT" && p.accept("*") {
+		e.star = true
+	} else {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		e.args = []*expr{arg}
+	}
+
+	closing := p.peek()
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	e.end = closing.end
+
+	return e, nil
+}
@@ -0,0 +1,79 @@
+package sql
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Query runs a SQL SELECT statement over a table, given either as a list of records or as a record of equally long
+// lists, one per column. There's no FROM clause since the data is passed in. The result is a list of records with
+// properties in the order they were selected.
+var Query = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	rows, err := toRows(params[0])
+	if err != nil {
+		return nil, err
+	}
+	query, err := params[1].ToString()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := parse(query)
+	if err != nil {
+		return nil, err
+	}
+	out, err := run(s, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]*types.Object, len(out))
+	for i, r := range out {
+		elements[i] = types.NewRecord(r.Properties)
+	}
+	return types.NewList(elements), nil
+}
+
+func toRows(data *types.Object) ([]*types.Record, error) {
+	switch data.Type() {
+	case types.TypeList:
+		l, _ := data.ToList()
+		rows := make([]*types.Record, len(l.Elements))
+		for i, el := range l.Elements {
+			r, err := el.ToRecord()
+			if err != nil {
+				return nil, fmt.Errorf("expected row %d of data to be a record; found a %s", i, el.Type())
+			}
+			rows[i] = r
+		}
+		return rows, nil
+	case types.TypeRecord:
+		r, _ := data.ToRecord()
+		var rows []*types.Record
+		for i, prop := range r.Properties {
+			l, err := prop.Value.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("expected column `%s` of data to be a list; found a %s", prop.Name, prop.Value.Type())
+			}
+			if i == 0 {
+				rows = make([]*types.Record, len(l.Elements))
+				for j := range rows {
+					rows[j] = &types.Record{}
+				}
+			}
+			if len(l.Elements) != len(rows) {
+				return nil, fmt.Errorf("expected column `%s` of data to have %d values; found %d", prop.Name, len(rows), len(l.Elements))
+			}
+			for j, v := range l.Elements {
+				rows[j] = rows[j].Set(prop.Name, v)
+			}
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("expected data to be a list of records or a record of lists; found a %s", data.Type())
+	}
+}
@@ -0,0 +1,191 @@
+package sql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/internal/libtest"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+func testData() *types.Object {
+	row := func(region string, year, amount float64) *types.Object {
+		return types.NewRecord([]*types.RecordProperty{
+			{Name: "region", Value: types.NewString(region)},
+			{Name: "year", Value: types.NewNumber(year)},
+			{Name: "amount", Value: types.NewNumber(amount)},
+		})
+	}
+
+	return types.NewList([]*types.Object{
+		row("west", 2026, 10),
+		row("east", 2026, 20),
+		row("west", 2025, 100),
+		row("north", 2026, 5),
+		row("west", 2026, 15),
+	})
+}
+
+// format prints the result of a query as one line per row for comparison.
+func format(t *testing.T, result *types.Object) string {
+	l, err := result.ToList()
+	if err != nil {
+		t.Fatalf("Expected a list; got %s", result.Type())
+	}
+
+	var lines []string
+	for _, el := range l.Elements {
+		r, _ := el.ToRecord()
+		var cells []string
+		for _, prop := range r.Properties {
+			s, _ := prop.Value.ToString()
+			cells = append(cells, prop.Name+"="+s)
+		}
+		lines = append(lines, strings.Join(cells, " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestQuery(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected string
+	}{
+		{
+			"SELECT region, SUM(amount) WHERE year = 2026 GROUP BY region ORDER BY 2 DESC",
+			"region=west SUM(amount)=25\nregion=east SUM(amount)=20\nregion=north SUM(amount)=5",
+		},
+		{
+			"select region, amount * 2 as doubled where amount > 10 and not region = 'east' order by doubled",
+			"region=west doubled=30\nregion=west doubled=200",
+		},
+		{
+			"SELECT * WHERE region = \"north\"",
+			"region=north year=2026 amount=5",
+		},
+		{
+			"SELECT COUNT(*) AS rows, AVG(amount) AS mean, MAX(region) AS last",
+			"rows=5 mean=30 last=west",
+		},
+		{
+			"SELECT region, COUNT(*) AS n GROUP BY region HAVING COUNT(*) > 1",
+			"region=west n=3",
+		},
+		{
+			"SELECT amount ORDER BY year, amount DESC LIMIT 2 OFFSET 1",
+			"amount=20\namount=15",
+		},
+		{
+			"SELECT `region` AS `where` WHERE (amount - 5) / 5 = 0",
+			"where=north",
+		},
+	}
+
+	for _, c := range cases {
+		result, err := Query([]*types.Object{testData(), types.NewString(c.query)})
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %s", c.query, err)
+			continue
+		}
+		if actual := format(t, result); actual != c.expected {
+			t.Errorf("Unexpected result for %q:\n%s", c.query, actual)
+		}
+	}
+}
+
+func TestQuery_RecordOfLists(t *testing.T) {
+	data := types.NewRecord([]*types.RecordProperty{
+		{Name: "name", Value: types.NewList([]*types.Object{types.NewString("a"), types.NewString("b")})},
+		{Name: "score", Value: types.NewList([]*types.Object{types.NewNumber(1), types.NewNumber(2)})},
+	})
+
+	result, err := Query([]*types.Object{data, types.NewString("SELECT name WHERE score > 1")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if actual := format(t, result); actual != "name=b" {
+		t.Errorf("Unexpected result: %s", actual)
+	}
+}
+
+func TestQuery_Money(t *testing.T) {
+	var rows []*types.Object
+	for _, amount := range []string{"1.50", "2", "1.5"} {
+		d, _ := types.ParseDecimal(amount)
+		price, err := types.NewMoney(d, "USD")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		rows = append(rows, types.NewRecord([]*types.RecordProperty{{Name: "price", Value: price}}))
+	}
+
+	result, err := Query([]*types.Object{types.NewList(rows), types.NewString("SELECT COUNT(*) AS n GROUP BY price")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if actual := format(t, result); actual != "n=2\nn=1" {
+		t.Errorf("Unexpected result:\n%s", actual)
+	}
+}
+
+func TestQuery_Errors(t *testing.T) {
+	cases := map[string]string{
+		"SELECT regoin":                          "unknown column `regoin` at position 8",
+		"SELECT region WHERE":                    "expected an expression but the query ended at position 20",
+		"SELECT region FROM data":                `unexpected "FROM" at position 15`,
+		"SELECT region, SUM(amount)":             "column `region` must be in GROUP BY or used in an aggregate at position 8",
+		"SELECT region WHERE SUM(amount) > 1":    "SUM cannot be used here at position 21",
+		"SELECT region WHERE amount = 'ten'":     "cannot compare a number with a string at position 21",
+		"SELECT region ORDER BY 3":               "there's no column 3 to sort by at position 24",
+		"SELECT 'unterminated":                   "unterminated quote at position 8",
+		"SELECT region, region":                  "duplicate column `region`; rename it with AS at position 16",
+		"SELECT amount / (year - 2026)":          "division by zero at position 17",
+		"SELECT region GROUP BY region LIMIT -1": "expected a whole number of rows at position 37",
+	}
+
+	for query, expected := range cases {
+		_, err := Query([]*types.Object{testData(), types.NewString(query)})
+		if err == nil || err.Error() != expected {
+			t.Errorf("Expected error %q for %q; got %v", expected, query, err)
+		}
+	}
+}
+
+func TestQuery_Units(t *testing.T) {
+	var rows []*types.Object
+	for _, d := range []*types.Object{libtest.Quantity(t, 1, "km"), libtest.Quantity(t, 500, "m"), libtest.Quantity(t, 1000, "m")} {
+		rows = append(rows, types.NewRecord([]*types.RecordProperty{{Name: "d", Value: d}}))
+	}
+	data := types.NewList(rows)
+
+	result, err := Query([]*types.Object{data, types.NewString("SELECT COUNT(*) AS n GROUP BY d")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if actual := format(t, result); actual != "n=2\nn=1" {
+		t.Errorf("Expected 1 km and 1000 m to be grouped together; got:\n%s", actual)
+	}
+
+	result, err = Query([]*types.Object{data, types.NewString("SELECT d ORDER BY d")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if actual := format(t, result); actual != "d=500\nd=1\nd=1000" {
+		t.Errorf("Expected distances to be ordered by length; got:\n%s", actual)
+	}
+
+	result, err = Query([]*types.Object{data, types.NewString("SELECT MAX(d) AS longest")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	l, _ := result.ToList()
+	r, _ := l.Elements[0].ToRecord()
+	if longest, _ := r.Get("longest"); longest.Unit().String() != "km" {
+		t.Errorf("Expected the longest distance to be 1 km; got one in %s", longest.Unit())
+	}
+
+	_, err = Query([]*types.Object{data, types.NewString("SELECT SUM(d)")})
+	if err == nil || err.Error() != "expected a number without a unit; found a number in km at position 12" {
+		t.Errorf("Expected an error summing distances; got %v", err)
+	}
+}
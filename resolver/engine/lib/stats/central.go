@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"errors"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Average returns the arithmetic mean. It's an error to average no values.
+var Average = func(params []*types.Object) (*types.Object, error) {
+	xs, unit, err := numbers(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(xs) == 0 {
+		return nil, errors.New("cannot average no values")
+	}
+
+	return types.NewQuantity(mean(xs), unit), nil
+}
+
+// Median returns the middle value, or the mean of the two middle values when there's an even number. It's an error
+// to take the median of no values.
+var Median = func(params []*types.Object) (*types.Object, error) {
+	xs, unit, err := numbers(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(xs) == 0 {
+		return nil, errors.New("cannot take the median of no values")
+	}
+
+	s := sorted(xs)
+	mid := len(s) / 2
+	if len(s)%2 == 1 {
+		return types.NewQuantity(s[mid], unit), nil
+	}
+	return types.NewQuantity((s[mid-1]+s[mid])/2, unit), nil
+}
+
+// Mode returns the most frequent value. Ties go to the value which appears first. Like spreadsheets, it's an error
+// if no value appears more than once.
+var Mode = func(params []*types.Object) (*types.Object, error) {
+	xs, unit, err := numbers(params)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[float64]int)
+	best, bestCount := 0.0, 1
+	for _, x := range xs {
+		counts[x]++
+	}
+	for _, x := range xs {
+		if counts[x] > bestCount {
+			best, bestCount = x, counts[x]
+		}
+	}
+	if bestCount < 2 {
+		return nil, errors.New("no value appears more than once")
+	}
+
+	return types.NewQuantity(best, unit), nil
+}
@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"errors"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Correl returns the Pearson correlation coefficient of two lists of values. It's an error if either list has no
+// variation.
+var Correl = func(params []*types.Object) (*types.Object, error) {
+	xs, ys, _, _, err := pair(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(xs) < 2 {
+		return nil, errors.New("expected at least 2 pairs of values")
+	}
+
+	sxy, sxx, syy := deviations(xs, ys)
+	if sxx == 0 || syy == 0 {
+		return nil, errors.New("cannot correlate values which don't vary")
+	}
+
+	return types.NewNumber(sxy / math.Sqrt(sxx*syy)), nil
+}
+
+// Covar returns the population covariance of two lists of values, measured in the product of their units.
+var Covar = func(params []*types.Object) (*types.Object, error) {
+	xs, ys, ux, uy, err := pair(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(xs) == 0 {
+		return nil, errors.New("expected at least 1 pair of values")
+	}
+
+	sxy, _, _ := deviations(xs, ys)
+	unit, factor := ux.Multiply(uy, 1)
+	return types.NewQuantity(sxy/float64(len(xs))*factor, unit), nil
+}
+
+// Linest fits a straight line to known y values, given first, and x values by least squares. It returns a record of
+// the slope, intercept and r2, the coefficient of determination. The intercept is measured in the unit of the y
+// values and the slope in that unit per unit of x.
+var Linest = func(params []*types.Object) (*types.Object, error) {
+	ys, xs, uy, ux, err := pair(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(xs) < 2 {
+		return nil, errors.New("expected at least 2 pairs of values")
+	}
+
+	sxy, sxx, syy := deviations(xs, ys)
+	if sxx == 0 {
+		return nil, errors.New("cannot fit a line to x values which don't vary")
+	}
+
+	slope := sxy / sxx
+	intercept := mean(ys) - slope*mean(xs)
+	r2 := 1.0
+	if syy != 0 {
+		r2 = sxy * sxy / (sxx * syy)
+	}
+	slopeUnit, factor := uy.Multiply(ux, -1)
+
+	return types.NewRecord([]*types.RecordProperty{
+		{Name: "slope", Value: types.NewQuantity(slope*factor, slopeUnit)},
+		{Name: "intercept", Value: types.NewQuantity(intercept, uy)},
+		{Name: "r2", Value: types.NewNumber(r2)},
+	}), nil
+}
+
+// deviations returns the sums of the products of the deviations from the mean of each pair of values, and of the
+// squared deviations of xs and of ys.
+func deviations(xs, ys []float64) (sxy, sxx, syy float64) {
+	mx, my := mean(xs), mean(ys)
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	return sxy, sxx, syy
+}
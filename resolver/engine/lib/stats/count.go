@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Count returns how many numbers there are among the values. Values of other types are ignored.
+var Count = func(params []*types.Object) (*types.Object, error) {
+	var n int
+	for _, v := range flatten(params) {
+		if v.Type() == types.TypeNumber {
+			n++
+		}
+	}
+
+	return types.NewNumber(float64(n)), nil
+}
+
+// CountIf returns how many of the values meet the criterion. The criterion is either a function or lambda which
+// returns whether a value counts, or a value to compare each with.
+var CountIf = func(call types.Call, params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	criterion := params[1]
+	callable := criterion.Type() == types.TypeFunction || criterion.Type() == types.TypeLambda
+
+	var n int
+	for _, v := range flatten(params[:1]) {
+		var match bool
+		if callable {
+			res, err := call(criterion, []*types.Object{v})
+			if err != nil {
+				return nil, err
+			}
+			match, err = res.ToBoolean()
+			if err != nil {
+				return nil, fmt.Errorf("expected criterion to return a boolean; found a %s", res.Type())
+			}
+		} else {
+			// Values which can't be compared with the criterion don't match it.
+			cmp, err := types.Compare(v, criterion)
+			match = err == nil && cmp == 0
+		}
+		if match {
+			n++
+		}
+	}
+
+	return types.NewNumber(float64(n)), nil
+}
+
+func flatten(params []*types.Object) []*types.Object {
+	var out []*types.Object
+	for _, p := range params {
+		if p.Type() == types.TypeList {
+			l, _ := p.ToList()
+			out = append(out, flatten(l.Elements)...)
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Var returns the variance of a sample, which needs at least 2 values.
+var Var = func(params []*types.Object) (*types.Object, error) {
+	return variance(params, true, false)
+}
+
+// Varp returns the variance of an entire population, which needs at least 1 value.
+var Varp = func(params []*types.Object) (*types.Object, error) {
+	return variance(params, false, false)
+}
+
+// Stdev returns the standard deviation of a sample, which needs at least 2 values.
+var Stdev = func(params []*types.Object) (*types.Object, error) {
+	return variance(params, true, true)
+}
+
+// Stdevp returns the standard deviation of an entire population, which needs at least 1 value.
+var Stdevp = func(params []*types.Object) (*types.Object, error) {
+	return variance(params, false, true)
+}
+
+func variance(params []*types.Object, sample, root bool) (*types.Object, error) {
+	xs, unit, err := numbers(params)
+	if err != nil {
+		return nil, err
+	}
+
+	n := float64(len(xs))
+	if sample {
+		n--
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("expected at least %d values; found %d", len(xs)+1-int(n), len(xs))
+	}
+
+	m := mean(xs)
+	var total float64
+	for _, x := range xs {
+		total += (x - m) * (x - m)
+	}
+
+	v := total / n
+	if root {
+		return types.NewQuantity(math.Sqrt(v), unit), nil
+	}
+	// Variance is measured in the square of the values' unit.
+	squared, factor := unit.Multiply(unit, 1)
+	return types.NewQuantity(v*factor, squared), nil
+}
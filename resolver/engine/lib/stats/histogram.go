@@ -0,0 +1,96 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Histogram counts the values falling into each of a set of buckets, returned as a list of records with the `from`
+// and `to` bounds of each bucket and its `count`. Buckets include their lower bound and exclude their upper bound,
+// except for the last, which includes both.
+//
+// The buckets are given either as a number, which splits the range of the values evenly, or as a list of ascending
+// bounds. Values outside the bounds aren't counted. With a number of buckets, no values produce no buckets.
+var Histogram = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	values := &measurer{}
+	if err := values.add(params[:1]); err != nil {
+		return nil, err
+	}
+	xs, unit := values.xs, values.unit()
+
+	var bounds []float64
+	if params[1].Type() == types.TypeList {
+		// Bounds are measured in the values' unit so they can be compared.
+		limits := &measurer{first: values.first}
+		if err := limits.add(params[1:]); err != nil {
+			return nil, err
+		}
+		// Without any values, the bounds give the unit.
+		bounds, unit = limits.xs, limits.unit()
+		if len(bounds) < 2 {
+			return nil, errors.New("expected at least 2 bounds")
+		}
+		for i := 1; i < len(bounds); i++ {
+			if bounds[i] <= bounds[i-1] {
+				return nil, errors.New("expected bounds to be in ascending order")
+			}
+		}
+	} else {
+		n, err := params[1].ToNumber()
+		if err != nil {
+			return nil, err
+		}
+		if n < 1 || n != math.Trunc(n) {
+			return nil, fmt.Errorf("expected a whole number of buckets; found %v", n)
+		}
+		bounds = evenBounds(xs, int(n))
+	}
+
+	var buckets []*types.Object
+	for i := 1; i < len(bounds); i++ {
+		from, to := bounds[i-1], bounds[i]
+		last := i == len(bounds)-1
+
+		var count int
+		for _, x := range xs {
+			if x >= from && (x < to || (last && x == to)) {
+				count++
+			}
+		}
+
+		buckets = append(buckets, types.NewRecord([]*types.RecordProperty{
+			{Name: "from", Value: types.NewQuantity(from, unit)},
+			{Name: "to", Value: types.NewQuantity(to, unit)},
+			{Name: "count", Value: types.NewNumber(float64(count))},
+		}))
+	}
+
+	return types.NewList(buckets), nil
+}
+
+// evenBounds splits the range of the values into n buckets of equal width.
+func evenBounds(xs []float64, n int) []float64 {
+	if len(xs) == 0 {
+		return nil
+	}
+
+	s := sorted(xs)
+	lo, hi := s[0], s[len(s)-1]
+	if lo == hi {
+		// Give a single value a bucket of its own.
+		hi = lo + 1
+	}
+
+	bounds := make([]float64, n+1)
+	for i := range bounds {
+		bounds[i] = lo + (hi-lo)*float64(i)/float64(n)
+	}
+	bounds[n] = hi
+	return bounds
+}
@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Percentile returns the kth percentile of the values, for k between 0 and 1 inclusive, interpolating between the
+// closest values. It's an error to take a percentile of no values.
+var Percentile = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	k, err := params[1].ToNumber()
+	if err != nil {
+		return nil, err
+	}
+	if k < 0 || k > 1 {
+		return nil, fmt.Errorf("expected k to be between 0 and 1; found %v", k)
+	}
+
+	return percentile(params[0], k)
+}
+
+// Quartile returns the minimum, first quartile, median, third quartile or maximum of the values for a quart of 0
+// to 4 respectively.
+var Quartile = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	q, err := params[1].ToNumber()
+	if err != nil {
+		return nil, err
+	}
+	if q < 0 || q > 4 || q != math.Trunc(q) {
+		return nil, fmt.Errorf("expected quart to be a whole number from 0 to 4; found %v", q)
+	}
+
+	return percentile(params[0], q/4)
+}
+
+func percentile(values *types.Object, k float64) (*types.Object, error) {
+	xs, unit, err := numbers([]*types.Object{values})
+	if err != nil {
+		return nil, err
+	}
+	if len(xs) == 0 {
+		return nil, errors.New("cannot take a percentile of no values")
+	}
+
+	return types.NewQuantity(kth(sorted(xs), k), unit), nil
+}
+
+// kth interpolates the kth percentile of values which are already sorted.
+func kth(s []float64, k float64) float64 {
+	rank := k * float64(len(s)-1)
+	lower := math.Floor(rank)
+	i := int(lower)
+	if i == len(s)-1 {
+		return s[i]
+	}
+	return s[i] + (rank-lower)*(s[i+1]-s[i])
+}
@@ -0,0 +1,97 @@
+// Package stats provides statistical builtins. They accept numbers either individually or in lists, such as the
+// column of a table, and nested lists are flattened. Numbers measured in units are converted to the unit of the
+// first, which results are measured in too.
+package stats
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/units"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// numbers flattens params into the numbers they contain, measured in the unit of the first. It's an error for the
+// numbers' units to measure different things, or for anything other than a number or list to appear.
+func numbers(params []*types.Object) ([]float64, *types.Unit, error) {
+	m := &measurer{}
+	if err := m.add(params); err != nil {
+		return nil, nil, err
+	}
+	return m.xs, m.unit(), nil
+}
+
+// measurer collects numbers in the unit of the first it sees.
+type measurer struct {
+	first *types.Object
+	xs    []float64
+}
+
+func (m *measurer) add(params []*types.Object) error {
+	for _, p := range params {
+		switch p.Type() {
+		case types.TypeList:
+			l, _ := p.ToList()
+			if err := m.add(l.Elements); err != nil {
+				return err
+			}
+		case types.TypeBlank:
+			// Blank cells are skipped, as in spreadsheets.
+		case types.TypeNumber:
+			if m.first == nil {
+				m.first = p
+			}
+			_, n, err := units.Align("combine", m.first, p)
+			if err != nil {
+				return err
+			}
+			m.xs = append(m.xs, n)
+		default:
+			return fmt.Errorf("expected numbers or lists of numbers; found a %s", p.Type())
+		}
+	}
+
+	return nil
+}
+
+func (m *measurer) unit() *types.Unit {
+	if m.first == nil {
+		return nil
+	}
+	return m.first.Unit()
+}
+
+// pair returns two lists of numbers which must be the same length, along with their units.
+func pair(params []*types.Object) (xs, ys []float64, ux, uy *types.Unit, err error) {
+	if n := len(params); n != 2 {
+		return nil, nil, nil, nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	xs, ux, err = numbers(params[:1])
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	ys, uy, err = numbers(params[1:])
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if len(xs) != len(ys) {
+		return nil, nil, nil, nil, fmt.Errorf("expected the same number of values in both lists; found %d and %d", len(xs), len(ys))
+	}
+
+	return xs, ys, ux, uy, nil
+}
+
+func mean(xs []float64) float64 {
+	var total float64
+	for _, x := range xs {
+		total += x
+	}
+	return total / float64(len(xs))
+}
+
+func sorted(xs []float64) []float64 {
+	out := make([]float64, len(xs))
+	copy(out, xs)
+	sort.Float64s(out)
+	return out
+}
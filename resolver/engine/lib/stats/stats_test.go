@@ -0,0 +1,246 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/internal/libtest"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Reference values are the results spreadsheets give for the same functions.
+
+func TestCentral(t *testing.T) {
+	res, err := Average([]*types.Object{libtest.List(10, 7, 9), types.NewNumber(27), types.NewNumber(2)})
+	libtest.ExpectNumber(t, "AVERAGE", res, err, 11)
+
+	res, err = Median([]*types.Object{libtest.List(1, 2, 3, 4, 5, 6)})
+	libtest.ExpectNumber(t, "MEDIAN", res, err, 3.5)
+
+	res, err = Median([]*types.Object{libtest.List(5, 1, 3)})
+	libtest.ExpectNumber(t, "MEDIAN", res, err, 3)
+
+	res, err = Mode([]*types.Object{libtest.List(5.6, 4, 4, 3, 2, 4)})
+	libtest.ExpectNumber(t, "MODE", res, err, 4)
+
+	res, err = Mode([]*types.Object{libtest.List(1, 2, 2, 1)})
+	libtest.ExpectNumber(t, "MODE", res, err, 1)
+}
+
+func TestDispersion(t *testing.T) {
+	data := []*types.Object{libtest.List(1345, 1301, 1368, 1322, 1310, 1370, 1318, 1350, 1303, 1299)}
+
+	res, err := Stdev(data)
+	libtest.ExpectNumber(t, "STDEV", res, err, 27.46391571984349)
+
+	res, err = Stdevp(data)
+	libtest.ExpectNumber(t, "STDEVP", res, err, 26.054558142482477)
+
+	res, err = Var(data)
+	libtest.ExpectNumber(t, "VAR", res, err, 754.2666666666667)
+
+	res, err = Varp(data)
+	libtest.ExpectNumber(t, "VARP", res, err, 678.84)
+}
+
+func TestPercentile(t *testing.T) {
+	res, err := Percentile([]*types.Object{libtest.List(1, 3, 2, 4), types.NewNumber(0.3)})
+	libtest.ExpectNumber(t, "PERCENTILE", res, err, 1.9)
+
+	res, err = Percentile([]*types.Object{libtest.List(1, 3, 2, 4), types.NewNumber(1)})
+	libtest.ExpectNumber(t, "PERCENTILE", res, err, 4)
+
+	res, err = Quartile([]*types.Object{libtest.List(1, 2, 4, 7, 8, 9, 10, 12), types.NewNumber(1)})
+	libtest.ExpectNumber(t, "QUARTILE", res, err, 3.5)
+}
+
+func TestSummarise(t *testing.T) {
+	res, err := Summarise([]float64{1345, 1301, 1368, 1322, 1310, 1370, 1318, 1350, 1303, 1299}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	r, err := res.ToRecord()
+	if err != nil {
+		t.Fatalf("Expected a record; got a %s", res.Type())
+	}
+
+	expected := map[string]float64{
+		"n":     10,
+		"mean":  1328.6,
+		"stdev": 27.46391571984349,
+		"min":   1299,
+		"max":   1370,
+		"p50":   1320,
+		"p95":   1369.1,
+	}
+	for name, value := range expected {
+		v, ok := r.Get(name)
+		if !ok {
+			t.Fatalf("Expected summary to have %s", name)
+		}
+		libtest.ExpectNumber(t, "summary "+name, v, nil, value)
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	xs, ys := libtest.List(3, 2, 4, 5, 6), libtest.List(9, 7, 12, 15, 17)
+
+	res, err := Correl([]*types.Object{xs, ys})
+	libtest.ExpectNumber(t, "CORREL", res, err, 0.9970544855015815)
+
+	res, err = Covar([]*types.Object{xs, ys})
+	libtest.ExpectNumber(t, "COVAR", res, err, 5.2)
+}
+
+func TestLinest(t *testing.T) {
+	ys, xs := libtest.List(2, 3, 9, 1, 8, 7, 5), libtest.List(6, 5, 11, 7, 5, 4, 4)
+
+	res, err := Linest([]*types.Object{ys, xs})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	r, err := res.ToRecord()
+	if err != nil {
+		t.Fatalf("Expected a record; got a %s", res.Type())
+	}
+
+	expected := map[string]float64{
+		"slope":     0.3055555555555556,
+		"intercept": 3.1666666666666665,
+		"r2":        0.05795019157088122,
+	}
+	for name, value := range expected {
+		v, ok := r.Get(name)
+		if !ok {
+			t.Fatalf("Expected result to have %s", name)
+		}
+		libtest.ExpectNumber(t, "LINEST "+name, v, nil, value)
+	}
+}
+
+func TestCount(t *testing.T) {
+	values := types.NewList([]*types.Object{
+		types.NewNumber(1),
+		types.NewString("a"),
+		libtest.List(2, 2),
+		types.NewBoolean(true),
+		types.NewString("a"),
+	})
+
+	res, err := Count([]*types.Object{values})
+	libtest.ExpectNumber(t, "COUNT", res, err, 3)
+
+	res, err = CountIf(nil, []*types.Object{values, types.NewString("a")})
+	libtest.ExpectNumber(t, "COUNTIF", res, err, 2)
+
+	isTwo := types.NewFunction("istwo", func(params []*types.Object) (*types.Object, error) {
+		n, _ := params[0].ToNumber()
+		return types.NewBoolean(n == 2), nil
+	})
+	call := func(f *types.Object, args []*types.Object) (*types.Object, error) {
+		fn, _ := f.ToFunction()
+		return fn(args)
+	}
+	res, err = CountIf(call, []*types.Object{values, isTwo})
+	libtest.ExpectNumber(t, "COUNTIF", res, err, 2)
+}
+
+func TestHistogram(t *testing.T) {
+	res, err := Histogram([]*types.Object{libtest.List(1, 2, 2, 3, 5), types.NewNumber(2)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectBuckets(t, res, [][3]float64{{1, 3, 3}, {3, 5, 2}})
+
+	res, err = Histogram([]*types.Object{libtest.List(0, 1, 5, 10, 11), libtest.List(0, 5, 10)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectBuckets(t, res, [][3]float64{{0, 5, 2}, {5, 10, 2}})
+
+	res, err = Histogram([]*types.Object{libtest.List(), types.NewNumber(3)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectBuckets(t, res, nil)
+}
+
+func expectBuckets(t *testing.T, result *types.Object, expected [][3]float64) {
+	l, err := result.ToList()
+	if err != nil {
+		t.Fatalf("Expected a list; got a %s", result.Type())
+	}
+	if len(l.Elements) != len(expected) {
+		t.Fatalf("Expected %d buckets; got %d", len(expected), len(l.Elements))
+	}
+
+	for i, bucket := range l.Elements {
+		r, _ := bucket.ToRecord()
+		for j, name := range []string{"from", "to", "count"} {
+			v, _ := r.Get(name)
+			if n, _ := v.ToNumber(); n != expected[i][j] {
+				t.Errorf("Expected bucket %d to have %s %v; got %v", i, name, expected[i][j], n)
+			}
+		}
+	}
+}
+
+func TestEmptyInput(t *testing.T) {
+	empty := []*types.Object{libtest.List()}
+
+	if _, err := Average(empty); err == nil {
+		t.Error("Expected an error averaging no values")
+	}
+	if _, err := Median(empty); err == nil {
+		t.Error("Expected an error taking the median of no values")
+	}
+	if _, err := Mode([]*types.Object{libtest.List(1, 2, 3)}); err == nil {
+		t.Error("Expected an error when no value repeats")
+	}
+	if _, err := Stdev([]*types.Object{libtest.List(1)}); err == nil {
+		t.Error("Expected an error taking the sample deviation of one value")
+	}
+	if _, err := Percentile([]*types.Object{libtest.List(), types.NewNumber(0.5)}); err == nil {
+		t.Error("Expected an error taking a percentile of no values")
+	}
+	if _, err := Correl([]*types.Object{libtest.List(1, 1), libtest.List(2, 3)}); err == nil {
+		t.Error("Expected an error correlating values which don't vary")
+	}
+
+	res, err := Count(empty)
+	libtest.ExpectNumber(t, "COUNT", res, err, 0)
+}
+
+func TestUnits(t *testing.T) {
+	distances := types.NewList([]*types.Object{libtest.Quantity(t, 1, "km"), libtest.Quantity(t, 500, "m"), libtest.Quantity(t, 3, "km")})
+
+	res, err := Average([]*types.Object{distances})
+	libtest.ExpectQuantity(t, "AVERAGE", res, err, 1.5, "km")
+
+	res, err = Median([]*types.Object{distances})
+	libtest.ExpectQuantity(t, "MEDIAN", res, err, 1, "km")
+
+	res, err = Percentile([]*types.Object{distances, types.NewNumber(0)})
+	libtest.ExpectQuantity(t, "PERCENTILE", res, err, 0.5, "km")
+
+	res, err = Stdevp([]*types.Object{types.NewList([]*types.Object{libtest.Quantity(t, 1, "m"), libtest.Quantity(t, 300, "cm")})})
+	libtest.ExpectQuantity(t, "STDEVP", res, err, 1, "m")
+
+	res, err = Varp([]*types.Object{types.NewList([]*types.Object{libtest.Quantity(t, 1, "m"), libtest.Quantity(t, 300, "cm")})})
+	libtest.ExpectQuantity(t, "VARP", res, err, 1, "m^2")
+
+	_, err = Average([]*types.Object{libtest.Quantity(t, 1, "km"), libtest.Quantity(t, 1, "kg")})
+	if err == nil || err.Error() != "cannot combine km and kg since they measure different things" {
+		t.Errorf("Expected an error averaging incompatible units; got %v", err)
+	}
+	_, err = Median([]*types.Object{libtest.Quantity(t, 1, "km"), types.NewNumber(2)})
+	if err == nil || err.Error() != "cannot combine km and a number without a unit since they measure different things" {
+		t.Errorf("Expected an error mixing numbers with and without units; got %v", err)
+	}
+}
+
+func TestCountIf_Units(t *testing.T) {
+	values := types.NewList([]*types.Object{libtest.Quantity(t, 1, "km"), libtest.Quantity(t, 1000, "m"), libtest.Quantity(t, 1, "m"), libtest.Quantity(t, 1, "kg")})
+
+	res, err := CountIf(nil, []*types.Object{values, libtest.Quantity(t, 1, "km")})
+	libtest.ExpectNumber(t, "COUNTIF", res, err, 2)
+}
@@ -0,0 +1,47 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// summaryPercentiles are reported by Summarise, named p5, p25 and so on.
+var summaryPercentiles = []float64{5, 25, 50, 75, 95}
+
+// Summarise returns a record describing a sample: its size, mean, standard deviation, extremes and percentiles.
+// Everything but the size is measured in the given unit, which may be nil.
+func Summarise(xs []float64, unit *types.Unit) (*types.Object, error) {
+	if len(xs) == 0 {
+		return nil, errors.New("cannot summarise no values")
+	}
+
+	s := sorted(xs)
+	m := mean(s)
+	var total float64
+	for _, x := range s {
+		total += (x - m) * (x - m)
+	}
+	var sd float64
+	if len(s) > 1 {
+		sd = math.Sqrt(total / float64(len(s)-1))
+	}
+
+	props := []*types.RecordProperty{
+		{Name: "n", Value: types.NewNumber(float64(len(s)))},
+		{Name: "mean", Value: types.NewQuantity(m, unit)},
+		{Name: "stdev", Value: types.NewQuantity(sd, unit)},
+		{Name: "min", Value: types.NewQuantity(s[0], unit)},
+		{Name: "max", Value: types.NewQuantity(s[len(s)-1], unit)},
+	}
+	for _, p := range summaryPercentiles {
+		props = append(props, &types.RecordProperty{
+			Name:  fmt.Sprintf("p%v", p),
+			Value: types.NewQuantity(kth(s, p/100), unit),
+		})
+	}
+
+	return types.NewRecord(props), nil
+}
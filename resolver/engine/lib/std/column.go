@@ -0,0 +1,32 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Column returns the values in the named column of a table as a list.
+var Column = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	rows, err := toTable(params[0])
+	if err != nil {
+		return nil, err
+	}
+	name, err := params[1].ToString()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*types.Object, len(rows))
+	for i, row := range rows {
+		out[i], err = cell(row, i, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return types.NewList(out), nil
+}
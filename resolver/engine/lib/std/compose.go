@@ -0,0 +1,29 @@
+package std
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Compose returns a lambda which calls each of the given functions in turn, from last to first, passing the result of
+// one to the next. COMPOSE(f, g)(x) is f(g(x)). The last function receives all of the lambda's arguments.
+var Compose = func(params []*types.Object) (*types.Object, error) {
+	if len(params) == 0 {
+		return nil, errors.New("expected at least 1 parameter; found 0")
+	}
+	for i, p := range params {
+		if t := p.Type(); t != types.TypeFunction && t != types.TypeLambda {
+			return nil, fmt.Errorf("expected parameter %d to be a function or lambda; found %s", i, t)
+		}
+	}
+
+	return types.NewForwardingClosure(func(args *types.Object) *types.Object {
+		exp := types.NewApplication(params[len(params)-1], []*types.Object{args})
+		for i := len(params) - 2; i >= 0; i-- {
+			exp = types.NewApplication(params[i], []*types.Object{exp})
+		}
+		return exp
+	}), nil
+}
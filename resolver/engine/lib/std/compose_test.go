@@ -0,0 +1,40 @@
+package std
+
+import (
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+func TestCompose(t *testing.T) {
+	not := types.NewFunction("NOT", Not)
+	equal := types.NewFunction("EQUAL", Equal)
+
+	result, err := Compose([]*types.Object{not, equal})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	l, err := result.ToLambda()
+	if err != nil {
+		t.Fatalf("Expected a lambda; got %s", result.Type())
+	}
+	if l.RestVariable == "" || len(l.FreeVariables) != 0 {
+		t.Errorf("Expected the lambda to accept any arguments; got %v and %q", l.FreeVariables, l.RestVariable)
+	}
+
+	outer, _ := l.Expression.ToApplication()
+	if outer == nil || outer.Expression != not {
+		t.Fatalf("Expected NOT to be called last; got %+v", l.Expression)
+	}
+	inner, _ := outer.Arguments[0].ToApplication()
+	if inner == nil || inner.Expression != equal {
+		t.Errorf("Expected EQUAL to be called first; got %+v", outer.Arguments[0])
+	}
+}
+
+func TestCompose_NotCallable(t *testing.T) {
+	if _, err := Compose([]*types.Object{types.NewNumber(1)}); err == nil {
+		t.Error("Expected an error composing a number")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/units"
 	"github.com/tobyjsullivan/chalk/resolver/engine/types"
 )
 
@@ -28,6 +29,8 @@ func compareObjects(left, right *types.Object) (bool, error) {
 	switch left.Type() {
 	case types.TypeApplication:
 		return compareApplications(left, right)
+	case types.TypeBlank:
+		return true, nil
 	case types.TypeBoolean:
 		return compareBooleans(left, right)
 	case types.TypeFunction:
@@ -36,8 +39,12 @@ func compareObjects(left, right *types.Object) (bool, error) {
 		return compareLambdas(left, right)
 	case types.TypeList:
 		return compareLists(left, right)
+	case types.TypeMoney:
+		return compareMoney(left, right)
 	case types.TypeNumber:
 		return compareNumbers(left, right)
+	case types.TypeRange:
+		return compareRanges(left, right)
 	case types.TypeRecord:
 		return compareRecords(left, right)
 	case types.TypeString:
@@ -101,19 +108,26 @@ func compareLists(l, r *types.Object) (bool, error) {
 	return true, nil
 }
 
-func compareNumbers(l, r *types.Object) (bool, error) {
-	left, err := l.ToNumber()
-	if err != nil {
-		return false, err
-	}
+func compareMoney(l, r *types.Object) (bool, error) {
+	left, _ := l.ToMoney()
+	right, _ := r.ToMoney()
 
-	right, err := r.ToNumber()
+	return left.Currency == right.Currency && left.Amount.Cmp(right.Amount) == 0, nil
+}
+
+// compareNumbers compares numbers in a common unit, so 1 km equals 1000 m. Numbers whose units measure different
+// things can't be compared.
+func compareNumbers(l, r *types.Object) (bool, error) {
+	left, right, err := units.Align("compare", l, r)
 	if err != nil {
 		return false, err
 	}
 
 	return left == right, nil
+}
 
+func compareRanges(_, _ *types.Object) (bool, error) {
+	return false, errors.New("unresolved ranges cannot be compared")
 }
 
 func compareRecords(l, r *types.Object) (bool, error) {
@@ -131,14 +145,15 @@ func compareRecords(l, r *types.Object) (bool, error) {
 		return false, nil
 	}
 
-	for k, l := range left.Properties {
-		r, ok := right.Properties[k]
+	// Records with the same properties are equal regardless of the order they were defined in.
+	for _, prop := range left.Properties {
+		r, ok := right.Get(prop.Name)
 		if !ok {
 			// Right doesn't have this property
 			return false, nil
 		}
 
-		if res, err := compareObjects(l, r); err != nil || !res {
+		if res, err := compareObjects(prop.Value, r); err != nil || !res {
 			return false, err
 		}
 	}
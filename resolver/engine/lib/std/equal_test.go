@@ -0,0 +1,45 @@
+package std
+
+import (
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/internal/libtest"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+func TestEqual_Units(t *testing.T) {
+	cases := []struct {
+		name        string
+		left, right *types.Object
+		expected    bool
+	}{
+		{"1 km, 1000 m", libtest.Quantity(t, 1, "km"), libtest.Quantity(t, 1000, "m"), true},
+		{"1000 m, 1 km", libtest.Quantity(t, 1000, "m"), libtest.Quantity(t, 1, "km"), true},
+		{"1 km, 1 m", libtest.Quantity(t, 1, "km"), libtest.Quantity(t, 1, "m"), false},
+		{"90 min, 1.5 h", libtest.Quantity(t, 90, "min"), libtest.Quantity(t, 1.5, "h"), true},
+		{"3, 3", types.NewNumber(3), types.NewNumber(3), true},
+	}
+
+	for _, c := range cases {
+		result, err := Equal([]*types.Object{c.left, c.right})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if b, _ := result.ToBoolean(); b != c.expected {
+			t.Errorf("Expected EQUAL(%s) to be %v; got %v", c.name, c.expected, b)
+		}
+	}
+}
+
+func TestEqual_IncompatibleUnits(t *testing.T) {
+	_, err := Equal([]*types.Object{libtest.Quantity(t, 1, "km"), libtest.Quantity(t, 1, "kg")})
+	if err == nil || err.Error() != "cannot compare km and kg since they measure different things" {
+		t.Errorf("Expected an error comparing incompatible units; got %v", err)
+	}
+
+	_, err = Equal([]*types.Object{libtest.Quantity(t, 1, "km"), types.NewNumber(1)})
+	if err == nil || err.Error() != "cannot compare km and a number without a unit since they measure different things" {
+		t.Errorf("Expected an error comparing a number without a unit; got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Get returns the named property of a record. If the record has no such property, the optional third parameter is
+// returned instead.
+var Get = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 && n != 3 {
+		return nil, fmt.Errorf("expected 2 or 3 parameters; found %d", n)
+	}
+	rec, err := params[0].ToRecord()
+	if err != nil {
+		return nil, err
+	}
+	key, err := params[1].ToString()
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := rec.Get(key); ok {
+		return v, nil
+	}
+	if len(params) == 3 {
+		return params[2], nil
+	}
+
+	return nil, fmt.Errorf("record has no property `%s`", key)
+}
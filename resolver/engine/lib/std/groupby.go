@@ -0,0 +1,68 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// GroupBy returns a row for each distinct combination of values in the key columns, in the order they first appear.
+// Each aggregate is a function or lambda which is given the group's rows and returns the value of a column of the
+// same name. Without aggregates, the group's rows are returned in a `rows` column.
+var GroupBy = func(call types.Call, params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 && n != 3 {
+		return nil, fmt.Errorf("expected 2 or 3 parameters; found %d", n)
+	}
+	rows, err := toTable(params[0])
+	if err != nil {
+		return nil, err
+	}
+	keys, err := columnNames(params[1])
+	if err != nil {
+		return nil, err
+	}
+	var aggregates *types.Record
+	if len(params) == 3 {
+		aggregates, err = params[2].ToRecord()
+		if err != nil {
+			return nil, fmt.Errorf("expected aggregates to be a record; found a %s", params[2].Type())
+		}
+	}
+
+	var order []string
+	groups := make(map[string][]*types.Record)
+	for i, row := range rows {
+		k, err := rowKey(row, i, keys)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], row)
+	}
+
+	out := make([]*types.Record, len(order))
+	for i, k := range order {
+		group := groups[k]
+		out[i] = &types.Record{}
+		for _, c := range keys {
+			v, _ := group[0].Get(c)
+			out[i] = out[i].Set(c, v)
+		}
+
+		if aggregates == nil {
+			out[i] = out[i].Set("rows", newTable(group))
+			continue
+		}
+		for _, agg := range aggregates.Properties {
+			v, err := call(agg.Value, []*types.Object{newTable(group)})
+			if err != nil {
+				return nil, err
+			}
+			out[i] = out[i].Set(agg.Name, v)
+		}
+	}
+
+	return newTable(out), nil
+}
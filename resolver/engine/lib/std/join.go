@@ -0,0 +1,65 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Join combines the rows of two tables which have the same values in the key columns. An inner join drops rows of
+// the left table without a match while a left join keeps them as they are. Where both tables have a column of the
+// same name, the value from the right table is used.
+var Join = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 4 {
+		return nil, fmt.Errorf("expected exactly 4 parameters; found %d", n)
+	}
+	left, err := toTable(params[0])
+	if err != nil {
+		return nil, err
+	}
+	right, err := toTable(params[1])
+	if err != nil {
+		return nil, err
+	}
+	keys, err := columnNames(params[2])
+	if err != nil {
+		return nil, err
+	}
+	how, err := params[3].ToString()
+	if err != nil {
+		return nil, err
+	}
+	if how != "inner" && how != "left" {
+		return nil, fmt.Errorf("expected join to be \"inner\" or \"left\"; found %q", how)
+	}
+
+	matches := make(map[string][]*types.Record)
+	for i, row := range right {
+		k, err := rowKey(row, i, keys)
+		if err != nil {
+			return nil, fmt.Errorf("right table: %s", err)
+		}
+		matches[k] = append(matches[k], row)
+	}
+
+	var out []*types.Record
+	for i, row := range left {
+		k, err := rowKey(row, i, keys)
+		if err != nil {
+			return nil, fmt.Errorf("left table: %s", err)
+		}
+
+		if len(matches[k]) == 0 && how == "left" {
+			out = append(out, row)
+		}
+		for _, match := range matches[k] {
+			joined := row
+			for _, prop := range match.Properties {
+				joined = joined.Set(prop.Name, prop.Value)
+			}
+			out = append(out, joined)
+		}
+	}
+
+	return newTable(out), nil
+}
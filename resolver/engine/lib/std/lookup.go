@@ -0,0 +1,80 @@
+package std
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Lookup returns the first row of a table with the given value in the named column. If a result column is given,
+// only the value of that column is returned.
+var Lookup = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 3 && n != 4 {
+		return nil, fmt.Errorf("expected 3 or 4 parameters; found %d", n)
+	}
+	rows, err := toTable(params[0])
+	if err != nil {
+		return nil, err
+	}
+	column, err := params[1].ToString()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		v, err := cell(row, i, column)
+		if err != nil {
+			return nil, err
+		}
+		match, err := compareObjects(v, params[2])
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+
+		if len(params) == 3 {
+			return types.NewRecord(row.Properties), nil
+		}
+		result, err := params[3].ToString()
+		if err != nil {
+			return nil, err
+		}
+		return cell(row, i, result)
+	}
+
+	return nil, fmt.Errorf("no row has `%s` equal to the value given", column)
+}
+
+// XLookup returns the result at the same position as the first key equal to the value. Keys and results are lists
+// of the same length, such as two columns of the grid.
+var XLookup = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 3 {
+		return nil, fmt.Errorf("expected exactly 3 parameters; found %d", n)
+	}
+	keys, err := params[1].ToList()
+	if err != nil {
+		return nil, fmt.Errorf("expected keys to be a list; found a %s", params[1].Type())
+	}
+	results, err := params[2].ToList()
+	if err != nil {
+		return nil, fmt.Errorf("expected results to be a list; found a %s", params[2].Type())
+	}
+	if len(keys.Elements) != len(results.Elements) {
+		return nil, fmt.Errorf("expected the same number of keys and results; found %d and %d", len(keys.Elements), len(results.Elements))
+	}
+
+	for i, key := range keys.Elements {
+		match, err := compareObjects(key, params[0])
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			return results.Elements[i], nil
+		}
+	}
+
+	return nil, errors.New("no key is equal to the value given")
+}
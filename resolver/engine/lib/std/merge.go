@@ -0,0 +1,24 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Merge combines records from left to right. Properties of later records replace those of earlier ones.
+var Merge = func(params []*types.Object) (*types.Object, error) {
+	out := &types.Record{}
+	for i, p := range params {
+		rec, err := p.ToRecord()
+		if err != nil {
+			return nil, fmt.Errorf("unexpected param type %d: %s", i, err)
+		}
+
+		for _, prop := range rec.Properties {
+			out = out.Set(prop.Name, prop.Value)
+		}
+	}
+
+	return types.NewRecord(out.Properties), nil
+}
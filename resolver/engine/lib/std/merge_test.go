@@ -0,0 +1,38 @@
+package std
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+func TestMerge_Handler(t *testing.T) {
+	result, err := Merge([]*types.Object{
+		types.NewRecord([]*types.RecordProperty{
+			{Name: "b", Value: types.NewNumber(1)},
+			{Name: "a", Value: types.NewNumber(2)},
+		}),
+		types.NewRecord([]*types.RecordProperty{
+			{Name: "c", Value: types.NewNumber(3)},
+			{Name: "b", Value: types.NewNumber(4)},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rec, err := result.ToRecord()
+	if err != nil {
+		t.Fatalf("Unexpected cast error: %v", err)
+	}
+
+	if keys := rec.Keys(); !reflect.DeepEqual(keys, []string{"b", "a", "c"}) {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+	if b, _ := rec.Get("b"); b == nil {
+		t.Error("Expected property b")
+	} else if n, _ := b.ToNumber(); n != 4 {
+		t.Errorf("Expected b to be replaced by the later record; got %v", n)
+	}
+}
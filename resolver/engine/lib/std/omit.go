@@ -0,0 +1,60 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Omit returns a record without the named properties. Names may be given individually or as lists.
+var Omit = func(params []*types.Object) (*types.Object, error) {
+	if len(params) == 0 {
+		return nil, fmt.Errorf("expected at least 1 parameter; found 0")
+	}
+	rec, err := params[0].ToRecord()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := propertyNames(params[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	omitted := make(map[string]bool)
+	for _, k := range keys {
+		omitted[k] = true
+	}
+
+	var props []*types.RecordProperty
+	for _, prop := range rec.Properties {
+		if !omitted[prop.Name] {
+			props = append(props, prop)
+		}
+	}
+
+	return types.NewRecord(props), nil
+}
+
+// propertyNames flattens parameters which are either names or lists of names.
+func propertyNames(params []*types.Object) ([]string, error) {
+	var out []string
+	for i, p := range params {
+		if p.Type() == types.TypeList {
+			l, _ := p.ToList()
+			names, err := propertyNames(l.Elements)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, names...)
+			continue
+		}
+
+		name, err := p.ToString()
+		if err != nil {
+			return nil, fmt.Errorf("unexpected property name %d: %s", i, err)
+		}
+		out = append(out, name)
+	}
+
+	return out, nil
+}
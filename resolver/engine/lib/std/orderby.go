@@ -0,0 +1,70 @@
+package std
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// OrderBy sorts the rows of a table by the given columns, comparing by the first column and using later ones to
+// break ties. Rows which are otherwise equal keep their order.
+var OrderBy = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 3 {
+		return nil, fmt.Errorf("expected exactly 3 parameters; found %d", n)
+	}
+	rows, err := toTable(params[0])
+	if err != nil {
+		return nil, err
+	}
+	columns, err := columnNames(params[1])
+	if err != nil {
+		return nil, err
+	}
+	descending, err := params[2].ToBoolean()
+	if err != nil {
+		return nil, err
+	}
+
+	sortKeys := make([][]*types.Object, len(rows))
+	for i, row := range rows {
+		sortKeys[i] = make([]*types.Object, len(columns))
+		for j, c := range columns {
+			sortKeys[i][j], err = cell(row, i, c)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+	}
+	var sortErr error
+	sort.SliceStable(order, func(a, b int) bool {
+		for j, c := range columns {
+			cmp, err := types.Compare(sortKeys[order[a]][j], sortKeys[order[b]][j])
+			if err != nil {
+				if sortErr == nil {
+					sortErr = fmt.Errorf("cannot sort by column `%s`: %s", c, err)
+				}
+				return false
+			}
+			if cmp != 0 {
+				return (cmp < 0) != descending
+			}
+		}
+		return false
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	out := make([]*types.Record, len(rows))
+	for i, j := range order {
+		out[i] = rows[j]
+	}
+
+	return newTable(out), nil
+}
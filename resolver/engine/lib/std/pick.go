@@ -0,0 +1,34 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Pick returns a record with only the named properties, in the order they're named. Names may be given individually
+// or as lists.
+var Pick = func(params []*types.Object) (*types.Object, error) {
+	if len(params) == 0 {
+		return nil, fmt.Errorf("expected at least 1 parameter; found 0")
+	}
+	rec, err := params[0].ToRecord()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := propertyNames(params[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	out := &types.Record{}
+	for _, k := range keys {
+		v, ok := rec.Get(k)
+		if !ok {
+			return nil, fmt.Errorf("record has no property `%s`", k)
+		}
+		out = out.Set(k, v)
+	}
+
+	return types.NewRecord(out.Properties), nil
+}
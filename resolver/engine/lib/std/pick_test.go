@@ -0,0 +1,62 @@
+package std
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+func testRecord() *types.Object {
+	return types.NewRecord([]*types.RecordProperty{
+		{Name: "name", Value: types.NewString("Toby")},
+		{Name: "age", Value: types.NewNumber(35)},
+		{Name: "city", Value: types.NewString("Vancouver")},
+	})
+}
+
+func TestPick_Handler(t *testing.T) {
+	result, err := Pick([]*types.Object{
+		testRecord(),
+		types.NewString("city"),
+		types.NewList([]*types.Object{types.NewString("name")}),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rec, _ := result.ToRecord()
+	if keys := rec.Keys(); !reflect.DeepEqual(keys, []string{"city", "name"}) {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+
+	if _, err := Pick([]*types.Object{testRecord(), types.NewString("missing")}); err == nil {
+		t.Error("Expected error for missing property")
+	}
+}
+
+func TestOmit_Handler(t *testing.T) {
+	result, err := Omit([]*types.Object{testRecord(), types.NewString("age")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rec, _ := result.ToRecord()
+	if keys := rec.Keys(); !reflect.DeepEqual(keys, []string{"name", "city"}) {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+}
+
+func TestGet_Handler(t *testing.T) {
+	result, err := Get([]*types.Object{testRecord(), types.NewString("missing"), types.NewNumber(0)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n, _ := result.ToNumber(); n != 0 {
+		t.Errorf("Expected default value; got %v", n)
+	}
+
+	if _, err := Get([]*types.Object{testRecord(), types.NewString("missing")}); err == nil {
+		t.Error("Expected error for missing property without a default")
+	}
+}
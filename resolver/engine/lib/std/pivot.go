@@ -0,0 +1,100 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Pivot returns a row for each distinct combination of values in the row columns, with a column for each distinct
+// value of the pivot column. Each cell aggregates the values from the rows in both. The aggregate is given a list of
+// the values and defaults to SUM.
+var Pivot = func(call types.Call, params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 4 && n != 5 {
+		return nil, fmt.Errorf("expected 4 or 5 parameters; found %d", n)
+	}
+	rows, err := toTable(params[0])
+	if err != nil {
+		return nil, err
+	}
+	keys, err := columnNames(params[1])
+	if err != nil {
+		return nil, err
+	}
+	pivot, err := params[2].ToString()
+	if err != nil {
+		return nil, err
+	}
+	values, err := params[3].ToString()
+	if err != nil {
+		return nil, err
+	}
+	aggregate := func(values []*types.Object) (*types.Object, error) {
+		return Sum(values)
+	}
+	if len(params) == 5 {
+		aggregate = func(values []*types.Object) (*types.Object, error) {
+			return call(params[4], []*types.Object{types.NewList(values)})
+		}
+	}
+
+	var order, columns []string
+	groups := make(map[string]*types.Record)
+	cells := make(map[string]map[string][]*types.Object)
+	for i, row := range rows {
+		k, err := rowKey(row, i, keys)
+		if err != nil {
+			return nil, err
+		}
+		p, err := cell(row, i, pivot)
+		if err != nil {
+			return nil, err
+		}
+		column, err := p.ToString()
+		if err != nil {
+			return nil, fmt.Errorf("cannot pivot on column `%s`: expected a string or number; found a %s", pivot, p.Type())
+		}
+		v, err := cell(row, i, values)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+			groups[k] = row
+			cells[k] = make(map[string][]*types.Object)
+		}
+		if !contains(columns, column) {
+			columns = append(columns, column)
+		}
+		cells[k][column] = append(cells[k][column], v)
+	}
+
+	out := make([]*types.Record, len(order))
+	for i, k := range order {
+		out[i] = &types.Record{}
+		for _, c := range keys {
+			v, _ := groups[k].Get(c)
+			out[i] = out[i].Set(c, v)
+		}
+		for _, c := range columns {
+			v, err := aggregate(cells[k][c])
+			if err != nil {
+				return nil, err
+			}
+			out[i] = out[i].Set(c, v)
+		}
+	}
+
+	return newTable(out), nil
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+package std
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Round rounds a number half away from zero to the given number of decimal places. Negative places round to the
+// left of the decimal point. Any unit is kept.
+var Round = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	x, err := params[0].ToNumber()
+	if err != nil {
+		return nil, err
+	}
+	digits, err := params[1].ToNumber()
+	if err != nil {
+		return nil, err
+	}
+	if digits != math.Trunc(digits) {
+		return nil, fmt.Errorf("expected a whole number of digits; found %v", digits)
+	}
+
+	return types.NewQuantity(round(x, int(digits)), params[0].Unit()), nil
+}
+
+func round(x float64, digits int) float64 {
+	scale := math.Pow(10, float64(digits))
+	return math.Round(x*scale) / scale
+}
@@ -0,0 +1,31 @@
+package std
+
+import (
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+func TestRound(t *testing.T) {
+	cases := []struct {
+		x        float64
+		digits   float64
+		expected float64
+	}{
+		{3.14159, 2, 3.14},
+		{2.5, 0, 3},
+		{-2.5, 0, -3},
+		{1234.5, -2, 1200},
+	}
+
+	for _, c := range cases {
+		result, err := Round([]*types.Object{types.NewNumber(c.x), types.NewNumber(c.digits)})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if n, _ := result.ToNumber(); n != c.expected {
+			t.Errorf("Expected ROUND(%v, %v) to be %v; got %v", c.x, c.digits, c.expected, n)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Select returns a table with only the named columns, in the order given. Names may be given individually or as
+// lists.
+var Select = func(params []*types.Object) (*types.Object, error) {
+	if len(params) == 0 {
+		return nil, fmt.Errorf("expected at least 1 parameter; found 0")
+	}
+	rows, err := toTable(params[0])
+	if err != nil {
+		return nil, err
+	}
+	columns, err := propertyNames(params[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*types.Record, len(rows))
+	for i, row := range rows {
+		out[i] = &types.Record{}
+		for _, c := range columns {
+			v, err := cell(row, i, c)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = out[i].Set(c, v)
+		}
+	}
+
+	return newTable(out), nil
+}
@@ -0,0 +1,23 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+var Set = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 3 {
+		return nil, fmt.Errorf("expected exactly 3 parameters; found %d", n)
+	}
+	rec, err := params[0].ToRecord()
+	if err != nil {
+		return nil, err
+	}
+	key, err := params[1].ToString()
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewRecord(rec.Set(key, params[2]).Properties), nil
+}
@@ -4,27 +4,38 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/units"
 	"github.com/tobyjsullivan/chalk/resolver/engine/types"
 )
 
+// Sum adds numbers or money together. Numbers with units must all measure the same thing, and the total is in the
+// unit of the first. Money must all be in the same currency. Blank cells are skipped.
 var Sum = func(params []*types.Object) (*types.Object, error) {
-	numbers := make([]float64, len(params))
+	total := types.NewNumber(0)
+	first := true
 	for i, p := range params {
-		cur, err := p.ToNumber()
-		if err != nil {
-			return nil, errors.New(fmt.Sprintf("unexpected param type %d: %s", i, err))
+		if p.Type() == types.TypeBlank {
+			continue
+		}
+		n := p
+		if p.Type() != types.TypeMoney {
+			cur, err := p.ToNumber()
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf("unexpected param type %d: %s", i, err))
+			}
+			n = types.NewQuantity(cur, p.Unit())
 		}
 
-		numbers[i] = cur
+		if first {
+			total, first = n, false
+			continue
+		}
+		var err error
+		total, err = units.Arithmetic('+', total, n)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return types.NewNumber(sum(numbers...)), nil
-}
-
-func sum(numbers ...float64) float64 {
-	var acc float64
-	for _, n := range numbers {
-		acc += n
-	}
-	return acc
+	return total, nil
 }
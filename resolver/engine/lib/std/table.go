@@ -0,0 +1,77 @@
+package std
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Tables are lists of records, one record per row. Rows usually share the same properties, which are the table's
+// columns, but nothing requires it.
+
+func toTable(obj *types.Object) ([]*types.Record, error) {
+	l, err := obj.ToList()
+	if err != nil {
+		return nil, fmt.Errorf("expected a table; found a %s", obj.Type())
+	}
+
+	rows := make([]*types.Record, len(l.Elements))
+	for i, el := range l.Elements {
+		rows[i], err = el.ToRecord()
+		if err != nil {
+			return nil, fmt.Errorf("expected row %d of table to be a record; found a %s", i, el.Type())
+		}
+	}
+
+	return rows, nil
+}
+
+func newTable(rows []*types.Record) *types.Object {
+	elements := make([]*types.Object, len(rows))
+	for i, r := range rows {
+		elements[i] = types.NewRecord(r.Properties)
+	}
+
+	return types.NewList(elements)
+}
+
+func cell(row *types.Record, i int, column string) (*types.Object, error) {
+	v, ok := row.Get(column)
+	if !ok {
+		return nil, fmt.Errorf("row %d has no column `%s`", i, column)
+	}
+
+	return v, nil
+}
+
+// rowKey identifies the values of the given columns so rows can be matched by them.
+func rowKey(row *types.Record, i int, columns []string) (string, error) {
+	var key string
+	for _, c := range columns {
+		v, err := cell(row, i, c)
+		if err != nil {
+			return "", err
+		}
+		k, err := types.Key(v)
+		if err != nil {
+			return "", fmt.Errorf("cannot match rows on column `%s`: %s", c, err)
+		}
+		key += k
+	}
+
+	return key, nil
+}
+
+// columnNames takes a single name or a list of names.
+func columnNames(param *types.Object) ([]string, error) {
+	names, err := propertyNames([]*types.Object{param})
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, errors.New("expected at least one column")
+	}
+
+	return names, nil
+}
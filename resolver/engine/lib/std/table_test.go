@@ -0,0 +1,208 @@
+package std
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/internal/libtest"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+func testTable() *types.Object {
+	row := func(region, quarter string, amount float64) *types.Object {
+		return types.NewRecord([]*types.RecordProperty{
+			{Name: "region", Value: types.NewString(region)},
+			{Name: "quarter", Value: types.NewString(quarter)},
+			{Name: "amount", Value: types.NewNumber(amount)},
+		})
+	}
+
+	return types.NewList([]*types.Object{
+		row("west", "Q1", 10),
+		row("east", "Q1", 20),
+		row("west", "Q2", 5),
+		row("east", "Q2", 1),
+		row("west", "Q1", 2),
+	})
+}
+
+// tableColumn returns the values of a column of the table as strings.
+func tableColumn(t *testing.T, table *types.Object, column string) []string {
+	rows, err := toTable(table)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	out := make([]string, len(rows))
+	for i, row := range rows {
+		v, err := cell(row, i, column)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		out[i], _ = v.ToString()
+	}
+
+	return out
+}
+
+// callSum stands in for the engine by summing the amounts of a table or list.
+func callSum(_ *types.Object, args []*types.Object) (*types.Object, error) {
+	if args[0].Type() == types.TypeList {
+		if _, err := toTable(args[0]); err == nil {
+			amounts, err := Column([]*types.Object{args[0], types.NewString("amount")})
+			if err != nil {
+				return nil, err
+			}
+			args = []*types.Object{amounts}
+		}
+		l, _ := args[0].ToList()
+		return Sum(l.Elements)
+	}
+
+	return nil, nil
+}
+
+func TestSelect_Handler(t *testing.T) {
+	result, err := Select([]*types.Object{testTable(), types.NewString("amount"), types.NewString("region")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rows, _ := toTable(result)
+	if keys := rows[0].Keys(); !reflect.DeepEqual(keys, []string{"amount", "region"}) {
+		t.Errorf("Unexpected columns: %v", keys)
+	}
+
+	if _, err := Select([]*types.Object{testTable(), types.NewString("missing")}); err == nil {
+		t.Error("Expected error for missing column")
+	}
+}
+
+func TestGroupBy_Handler(t *testing.T) {
+	aggregates := types.NewRecord([]*types.RecordProperty{
+		{Name: "total", Value: types.NewFunction("TOTAL", nil)},
+	})
+	result, err := GroupBy(callSum, []*types.Object{testTable(), types.NewString("region"), aggregates})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if regions := tableColumn(t, result, "region"); !reflect.DeepEqual(regions, []string{"west", "east"}) {
+		t.Errorf("Unexpected groups: %v", regions)
+	}
+	if totals := tableColumn(t, result, "total"); !reflect.DeepEqual(totals, []string{"17", "21"}) {
+		t.Errorf("Unexpected totals: %v", totals)
+	}
+}
+
+func TestJoin_Handler(t *testing.T) {
+	managers := types.NewList([]*types.Object{
+		types.NewRecord([]*types.RecordProperty{
+			{Name: "region", Value: types.NewString("west")},
+			{Name: "manager", Value: types.NewString("Ana")},
+		}),
+	})
+
+	inner, err := Join([]*types.Object{testTable(), managers, types.NewString("region"), types.NewString("inner")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if names := tableColumn(t, inner, "manager"); !reflect.DeepEqual(names, []string{"Ana", "Ana", "Ana"}) {
+		t.Errorf("Unexpected inner join: %v", names)
+	}
+
+	left, err := Join([]*types.Object{testTable(), managers, types.NewString("region"), types.NewString("left")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if l, _ := left.ToList(); len(l.Elements) != 5 {
+		t.Errorf("Expected left join to keep all 5 rows; got %d", len(l.Elements))
+	}
+}
+
+func TestPivot_Handler(t *testing.T) {
+	result, err := Pivot(callSum, []*types.Object{
+		testTable(),
+		types.NewString("region"),
+		types.NewString("quarter"),
+		types.NewString("amount"),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rows, _ := toTable(result)
+	if keys := rows[0].Keys(); !reflect.DeepEqual(keys, []string{"region", "Q1", "Q2"}) {
+		t.Errorf("Unexpected columns: %v", keys)
+	}
+	if q1 := tableColumn(t, result, "Q1"); !reflect.DeepEqual(q1, []string{"12", "20"}) {
+		t.Errorf("Unexpected Q1 column: %v", q1)
+	}
+}
+
+func TestOrderBy_Handler(t *testing.T) {
+	result, err := OrderBy([]*types.Object{
+		testTable(),
+		types.NewList([]*types.Object{types.NewString("quarter"), types.NewString("amount")}),
+		types.NewBoolean(true),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if amounts := tableColumn(t, result, "amount"); !reflect.DeepEqual(amounts, []string{"5", "1", "20", "10", "2"}) {
+		t.Errorf("Unexpected order: %v", amounts)
+	}
+}
+
+func TestLookup_Handler(t *testing.T) {
+	result, err := Lookup([]*types.Object{
+		testTable(),
+		types.NewString("region"),
+		types.NewString("east"),
+		types.NewString("amount"),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n, _ := result.ToNumber(); n != 20 {
+		t.Errorf("Expected the first matching row; got %v", n)
+	}
+
+	if _, err := Lookup([]*types.Object{testTable(), types.NewString("region"), types.NewString("north")}); err == nil {
+		t.Error("Expected error when no row matches")
+	}
+}
+
+func TestTables_Units(t *testing.T) {
+	distances := types.NewList([]*types.Object{
+		types.NewRecord([]*types.RecordProperty{{Name: "d", Value: libtest.Quantity(t, 1, "km")}}),
+		types.NewRecord([]*types.RecordProperty{{Name: "d", Value: libtest.Quantity(t, 1, "m")}}),
+		types.NewRecord([]*types.RecordProperty{{Name: "d", Value: libtest.Quantity(t, 500, "m")}}),
+		types.NewRecord([]*types.RecordProperty{{Name: "d", Value: libtest.Quantity(t, 1000, "m")}}),
+	})
+
+	grouped, err := GroupBy(callSum, []*types.Object{distances, types.NewString("d")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if groups := tableColumn(t, grouped, "d"); !reflect.DeepEqual(groups, []string{"1", "1", "500"}) {
+		t.Errorf("Expected 1 km and 1000 m to be grouped together; got groups %v", groups)
+	}
+
+	ordered, err := OrderBy([]*types.Object{distances, types.NewString("d"), types.NewBoolean(false)})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if order := tableColumn(t, ordered, "d"); !reflect.DeepEqual(order, []string{"1", "500", "1", "1000"}) {
+		t.Errorf("Expected distances to be ordered by length; got %v", order)
+	}
+
+	mixed := types.NewList([]*types.Object{
+		types.NewRecord([]*types.RecordProperty{{Name: "d", Value: libtest.Quantity(t, 1, "km")}}),
+		types.NewRecord([]*types.RecordProperty{{Name: "d", Value: libtest.Quantity(t, 1, "kg")}}),
+	})
+	if _, err := OrderBy([]*types.Object{mixed, types.NewString("d"), types.NewBoolean(false)}); err == nil {
+		t.Error("Expected an error ordering lengths and masses together")
+	}
+}
@@ -0,0 +1,24 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+var Values = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 1 {
+		return nil, fmt.Errorf("expected exactly 1 parameter; found %d", n)
+	}
+	rec, err := params[0].ToRecord()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]*types.Object, len(rec.Properties))
+	for i, prop := range rec.Properties {
+		values[i] = prop.Value
+	}
+
+	return types.NewList(values), nil
+}
@@ -0,0 +1,35 @@
+package std
+
+import (
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Where returns the rows of a table for which the predicate returns true.
+var Where = func(call types.Call, params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	rows, err := toTable(params[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*types.Record
+	for i, row := range rows {
+		res, err := call(params[1], []*types.Object{types.NewRecord(row.Properties)})
+		if err != nil {
+			return nil, err
+		}
+		keep, err := res.ToBoolean()
+		if err != nil {
+			return nil, fmt.Errorf("expected predicate to return a boolean for row %d; found a %s", i, res.Type())
+		}
+		if keep {
+			out = append(out, row)
+		}
+	}
+
+	return newTable(out), nil
+}
@@ -0,0 +1,132 @@
+// Package units provides builtins for numbers measured in units, along with the arithmetic which keeps track of
+// their dimensions.
+package units
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/money"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Unit measures a number in the given unit, such as "km/h".
+var Unit = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	n, err := params[0].ToNumber()
+	if err != nil {
+		return nil, err
+	}
+	if u := params[0].Unit(); u != nil {
+		return nil, fmt.Errorf("number is already measured in %s; use CONVERT to change units", u)
+	}
+	unit, err := unitParam(params[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewQuantity(n, unit), nil
+}
+
+// Convert expresses a number in another unit which measures the same thing.
+var Convert = func(params []*types.Object) (*types.Object, error) {
+	if n := len(params); n != 2 {
+		return nil, fmt.Errorf("expected exactly 2 parameters; found %d", n)
+	}
+	n, err := params[0].ToNumber()
+	if err != nil {
+		return nil, err
+	}
+	to, err := unitParam(params[1])
+	if err != nil {
+		return nil, err
+	}
+
+	from := params[0].Unit()
+	if !from.Compatible(to) {
+		return nil, fmt.Errorf("cannot convert %s to %s since they measure different things", from.Describe(), to)
+	}
+
+	return types.NewQuantity(n*from.ConversionFactor(to), to), nil
+}
+
+// Arithmetic applies one of the operators `+`, `-`, `*` or `/` to two numbers, taking their units into account.
+// Numbers are only added or subtracted when their units measure the same thing, and the result is in the unit of
+// the first. Money is left to the money package.
+func Arithmetic(op rune, a, b *types.Object) (*types.Object, error) {
+	if a.Type() == types.TypeMoney || b.Type() == types.TypeMoney {
+		return money.Arithmetic(op, a, b)
+	}
+
+	ua, ub := a.Unit(), b.Unit()
+	if op == '+' || op == '-' {
+		verb := "add"
+		if op == '-' {
+			verb = "subtract"
+		}
+		x, y, err := Align(verb, a, b)
+		if err != nil {
+			return nil, err
+		}
+		if op == '-' {
+			y = -y
+		}
+		return types.NewQuantity(x+y, ua), nil
+	}
+
+	x, err := a.ToNumber()
+	if err != nil {
+		return nil, err
+	}
+	y, err := b.ToNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case '*':
+		unit, factor := ua.Multiply(ub, 1)
+		return types.NewQuantity(x*y*factor, unit), nil
+	case '/':
+		if y == 0 {
+			return nil, errors.New("cannot divide by zero")
+		}
+		unit, factor := ua.Multiply(ub, -1)
+		return types.NewQuantity(x/y*factor, unit), nil
+	default:
+		return nil, fmt.Errorf("unknown operator `%c`", op)
+	}
+}
+
+// Align returns the values of two numbers measured in the unit of the first. It's an error when their units measure
+// different things; verb names what was being attempted, such as "compare", for the message.
+func Align(verb string, a, b *types.Object) (float64, float64, error) {
+	x, err := a.ToNumber()
+	if err != nil {
+		return 0, 0, err
+	}
+	y, err := b.ToNumber()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ua, ub := a.Unit(), b.Unit()
+	if !ua.Compatible(ub) {
+		return 0, 0, fmt.Errorf("cannot %s %s and %s since they measure different things", verb, ua.Describe(), ub.Describe())
+	}
+	if ua != nil {
+		y *= ub.ConversionFactor(ua)
+	}
+	return x, y, nil
+}
+
+func unitParam(obj *types.Object) (*types.Unit, error) {
+	s, err := obj.ToString()
+	if err != nil {
+		return nil, fmt.Errorf("expected the unit to be a string; found a %s", obj.Type())
+	}
+
+	return types.ParseUnit(s)
+}
@@ -0,0 +1,100 @@
+package units
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/internal/libtest"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+func TestParseUnit(t *testing.T) {
+	cases := map[string]string{
+		"km":       "km",
+		"km/h":     "km/h",
+		"kg*m/s^2": "kg*m/s^2",
+		"m * m":    "m^2",
+		"1/s":      "1/s",
+		"s^-1":     "1/s",
+		"m/s*s":    "m/s^2",
+		"mi/h*h":   "mi/h^2",
+		"m*s/s":    "m",
+	}
+
+	for input, expected := range cases {
+		u, err := types.ParseUnit(input)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %v", input, err)
+		}
+		if actual := u.String(); actual != expected {
+			t.Errorf("Expected %q to format as %q; got %q", input, expected, actual)
+		}
+	}
+
+	for _, input := range []string{"", "furlong", "m^x", "km/"} {
+		if _, err := types.ParseUnit(input); err == nil {
+			t.Errorf("Expected an error parsing %q", input)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	res, err := Convert([]*types.Object{libtest.Quantity(t, 5, "km"), types.NewString("mi")})
+	libtest.ExpectQuantity(t, "CONVERT", res, err, 3.1068559611866697, "mi")
+
+	res, err = Convert([]*types.Object{libtest.Quantity(t, 100, "km/h"), types.NewString("m/s")})
+	libtest.ExpectQuantity(t, "CONVERT", res, err, 27.77777777777778, "m/s")
+
+	res, err = Convert([]*types.Object{libtest.Quantity(t, 1, "kWh"), types.NewString("J")})
+	libtest.ExpectQuantity(t, "CONVERT", res, err, 3.6e6, "J")
+
+	_, err = Convert([]*types.Object{libtest.Quantity(t, 5, "km"), types.NewString("kg")})
+	if err == nil || !strings.Contains(err.Error(), "cannot convert km to kg") {
+		t.Errorf("Expected an error converting between incompatible units; got %v", err)
+	}
+	_, err = Convert([]*types.Object{types.NewNumber(5), types.NewString("kg")})
+	if err == nil {
+		t.Error("Expected an error converting a number without a unit")
+	}
+}
+
+func TestUnit(t *testing.T) {
+	res, err := Unit([]*types.Object{types.NewNumber(3), types.NewString("h")})
+	libtest.ExpectQuantity(t, "UNIT", res, err, 3, "h")
+
+	if _, err := Unit([]*types.Object{res, types.NewString("min")}); err == nil {
+		t.Error("Expected an error giving a unit to a number which already has one")
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	res, err := Arithmetic('+', libtest.Quantity(t, 1, "km"), libtest.Quantity(t, 500, "m"))
+	libtest.ExpectQuantity(t, "ADD", res, err, 1.5, "km")
+
+	res, err = Arithmetic('-', libtest.Quantity(t, 1, "h"), libtest.Quantity(t, 15, "min"))
+	libtest.ExpectQuantity(t, "SUBTRACT", res, err, 0.75, "h")
+
+	res, err = Arithmetic('/', libtest.Quantity(t, 25, "km"), libtest.Quantity(t, 2, "h"))
+	libtest.ExpectQuantity(t, "DIVIDE", res, err, 12.5, "km/h")
+
+	res, err = Arithmetic('*', libtest.Quantity(t, 12.5, "km/h"), libtest.Quantity(t, 30, "min"))
+	libtest.ExpectQuantity(t, "MULTIPLY", res, err, 6.25, "km")
+
+	res, err = Arithmetic('*', libtest.Quantity(t, 2, "m"), libtest.Quantity(t, 50, "cm"))
+	libtest.ExpectQuantity(t, "MULTIPLY", res, err, 1, "m^2")
+
+	res, err = Arithmetic('/', libtest.Quantity(t, 3, "km"), libtest.Quantity(t, 1500, "m"))
+	libtest.ExpectQuantity(t, "DIVIDE", res, err, 2, "")
+
+	res, err = Arithmetic('*', libtest.Quantity(t, 3, "kg"), types.NewNumber(2))
+	libtest.ExpectQuantity(t, "MULTIPLY", res, err, 6, "kg")
+
+	_, err = Arithmetic('+', libtest.Quantity(t, 1, "km"), libtest.Quantity(t, 1, "kg"))
+	if err == nil || err.Error() != "cannot add km and kg since they measure different things" {
+		t.Errorf("Expected an error adding incompatible units; got %v", err)
+	}
+	_, err = Arithmetic('-', libtest.Quantity(t, 1, "km"), types.NewNumber(1))
+	if err == nil || err.Error() != "cannot subtract km and a number without a unit since they measure different things" {
+		t.Errorf("Expected an error subtracting a number without a unit; got %v", err)
+	}
+}
@@ -0,0 +1,215 @@
+package engine
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tobyjsullivan/chalk/monolith"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// Result is the outcome of resolving a single formula as part of a larger query.
+type Result struct {
+	Value *types.Object
+	Err   error
+}
+
+// VariableResult is the outcome of resolving a single page variable.
+type VariableResult struct {
+	Variable *monolith.Variable
+	Result
+}
+
+// QueryMany resolves several formulas against the same page. Variables referenced by more than one formula are
+// fetched and resolved only once.
+func (e *Engine) QueryMany(ctx context.Context, pageId string, formulas []string) ([]*Result, error) {
+	if pageId == "" {
+		return nil, errors.New("pageId must be provided")
+	}
+
+	qc := e.startQuery(pageId)
+	ctx = setQueryContext(ctx, qc)
+
+	out := make([]*Result, len(formulas))
+	objs := make([]*types.Object, 0, len(formulas))
+	for i, formula := range formulas {
+		obj, err := e.parseFormula(formula)
+		if err != nil {
+			out[i] = &Result{Err: err}
+			continue
+		}
+		objs = append(objs, obj)
+		out[i] = &Result{Value: obj}
+	}
+
+	if err := e.prefetchVariables(ctx, qc, objs...); err != nil {
+		return nil, err
+	}
+
+	for _, res := range out {
+		if res.Err != nil {
+			continue
+		}
+		res.Value, res.Err = e.resolve(ctx, res.Value, []string{})
+	}
+
+	return out, nil
+}
+
+// QueryPage resolves every variable on the page. Variables are resolved in dependency order and each is resolved
+// exactly once, no matter how many other variables refer to it. Results are returned in page order.
+func (e *Engine) QueryPage(ctx context.Context, pageId string) ([]*VariableResult, error) {
+	return e.QueryPageWithOverrides(ctx, pageId, nil)
+}
+
+// QueryPageWithOverrides resolves every variable on the page as if the overrides had replaced their formulas. The
+// results of overridden variables carry their replacement formulas.
+func (e *Engine) QueryPageWithOverrides(ctx context.Context, pageId string, overrides Overrides) ([]*VariableResult, error) {
+	if pageId == "" {
+		return nil, errors.New("pageId must be provided")
+	}
+
+	qc := e.startQuery(pageId)
+	qc.override(overrides)
+	ctx = setQueryContext(ctx, qc)
+
+	vars, err := e.fetchPageVariables(ctx, qc)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := e.buildDependencyGraph(vars)
+	return e.resolvePageVariables(ctx, qc, vars, graph.topologicalOrder()), nil
+}
+
+// resolvePageVariables resolves the named variables in the order given and returns their results in page order.
+func (e *Engine) resolvePageVariables(ctx context.Context, qc *queryContext, vars []*monolith.Variable, names []string) []*VariableResult {
+	included := make(map[string]bool)
+	for _, name := range names {
+		included[name] = true
+		// Results are kept by the query context.
+		e.resolveVariable(ctx, &types.Variable{Name: name}, []string{}, true)
+	}
+
+	out := make([]*VariableResult, 0, len(names))
+	for _, v := range vars {
+		if !included[normaliseVarName(v.Name)] {
+			continue
+		}
+
+		res := &VariableResult{Variable: v}
+		if r, ok := qc.lookupValue(v.Name); ok {
+			res.Value, res.Err = r.value, r.err
+		}
+		out = append(out, res)
+	}
+
+	return out
+}
+
+// dependencyGraph records which page variables refer to which others.
+type dependencyGraph struct {
+	// Normalised variable names in page order.
+	names []string
+	// Maps each variable to the page variables it refers to.
+	dependencies map[string][]string
+	// Maps each name, whether or not it's defined on the page, to the page variables which refer to it.
+	dependents map[string][]string
+}
+
+func (e *Engine) buildDependencyGraph(vars []*monolith.Variable) *dependencyGraph {
+	g := &dependencyGraph{
+		dependencies: make(map[string][]string),
+		dependents:   make(map[string][]string),
+	}
+
+	onPage := make(map[string]bool)
+	for _, v := range vars {
+		name := normaliseVarName(v.Name)
+		g.names = append(g.names, name)
+		onPage[name] = true
+	}
+
+	for _, v := range vars {
+		name := normaliseVarName(v.Name)
+		obj, err := e.parseFormula(v.Formula)
+		if err != nil {
+			// The variable has no usable dependencies; the parse error is reported when it's resolved.
+			continue
+		}
+
+		for _, ref := range referencedNames(obj) {
+			g.dependents[ref] = append(g.dependents[ref], name)
+			if onPage[ref] {
+				g.dependencies[name] = append(g.dependencies[name], ref)
+			}
+		}
+	}
+
+	return g
+}
+
+// topologicalOrder lists every variable after all of its dependencies. Variables which are part of a cycle can't
+// be ordered and are listed last, in page order.
+func (g *dependencyGraph) topologicalOrder() []string {
+	remaining := make(map[string]int)
+	for _, name := range g.names {
+		remaining[name] = len(g.dependencies[name])
+	}
+
+	out := make([]string, 0, len(g.names))
+	done := make(map[string]bool)
+	for progress := true; progress; {
+		progress = false
+		for _, name := range g.names {
+			if done[name] || remaining[name] > 0 {
+				continue
+			}
+
+			done[name] = true
+			progress = true
+			out = append(out, name)
+			for _, dependent := range g.dependents[name] {
+				remaining[dependent]--
+			}
+		}
+	}
+
+	for _, name := range g.names {
+		if !done[name] {
+			out = append(out, name)
+		}
+	}
+
+	return out
+}
+
+// downstream lists the given variables along with every page variable which depends on them, directly or
+// indirectly, in topological order. Names which aren't defined on the page may be included in order to find the
+// variables which refer to them.
+func (g *dependencyGraph) downstream(names []string) []string {
+	affected := make(map[string]bool)
+	pending := make([]string, 0, len(names))
+	for _, name := range names {
+		pending = append(pending, normaliseVarName(name))
+	}
+
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if affected[name] {
+			continue
+		}
+		affected[name] = true
+		pending = append(pending, g.dependents[name]...)
+	}
+
+	var out []string
+	for _, name := range g.topologicalOrder() {
+		if affected[name] {
+			out = append(out, name)
+		}
+	}
+
+	return out
+}
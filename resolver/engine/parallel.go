@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// acquireWorker claims a slot for a new goroutine if one is free. It never blocks so nested calls can't deadlock
+// waiting on slots held by their parents; when the pool is exhausted the caller does the work itself.
+func (qc *queryContext) acquireWorker() bool {
+	if qc.workers == nil {
+		return false
+	}
+
+	select {
+	case qc.workers <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (qc *queryContext) releaseWorker() {
+	<-qc.workers
+}
+
+// resolveAll resolves each of the objects, in parallel where the query allows it. Results are returned in the
+// same order as the input. If any objects fail, the error of the first by position is returned regardless of
+// which failed first in time.
+func (e *Engine) resolveAll(ctx context.Context, objs []*types.Object, varHistory []string) ([]*types.Object, error) {
+	results := make([]*types.Object, len(objs))
+	errs := make([]error, len(objs))
+
+	qc, _ := getQueryContext(ctx)
+
+	var wg sync.WaitGroup
+	for i, obj := range objs {
+		if qc != nil && qc.acquireWorker() {
+			wg.Add(1)
+			go func(i int, obj *types.Object) {
+				defer wg.Done()
+				defer qc.releaseWorker()
+				results[i], errs[i] = e.resolve(ctx, obj, varHistory)
+			}(i, obj)
+			continue
+		}
+
+		results[i], errs[i] = e.resolve(ctx, obj, varHistory)
+		if errs[i] != nil {
+			// Nothing after this position can change the outcome.
+			break
+		}
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,180 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/tobyjsullivan/chalk/monolith"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// The variables service refuses to look up more names than this in a single request.
+const maxFindBatchSize = 100
+
+// prefetchVariables loads the transitive closure of the variables referenced by the formulas. Each round of lookups
+// fetches every newly discovered name at once so the number of calls grows with the depth of the dependency
+// chain rather than with the number of references.
+func (e *Engine) prefetchVariables(ctx context.Context, qc *queryContext, formulas ...*types.Object) error {
+	pending := e.unknownReferences(qc, formulas)
+	for len(pending) > 0 {
+		if len(pending) > maxFindBatchSize {
+			// Ranges can refer to a great many cells so it's cheaper to fetch the whole page at once.
+			if _, err := e.fetchPageVariables(ctx, qc); err != nil {
+				return err
+			}
+		} else if err := e.fetchVariables(ctx, qc, pending); err != nil {
+			return err
+		}
+
+		var discovered []*types.Object
+		for _, name := range pending {
+			v, _ := qc.lookupVariable(name)
+			if v == nil {
+				continue
+			}
+
+			o, err := e.parseFormula(v.Formula)
+			if err != nil {
+				// Leave the error to be reported if the variable is actually resolved.
+				continue
+			}
+			discovered = append(discovered, o)
+		}
+
+		pending = e.unknownReferences(qc, discovered)
+	}
+
+	return nil
+}
+
+// unknownReferences lists the names referenced by any of the objects which haven't yet been fetched.
+func (e *Engine) unknownReferences(qc *queryContext, objs []*types.Object) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, o := range objs {
+		for _, name := range referencedNames(o) {
+			if seen[name] || qc.isKnown(name) {
+				continue
+			}
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+
+	return out
+}
+
+// fetchVariables looks up the named variables in batches and records the outcome for each name, including those
+// which don't exist.
+func (e *Engine) fetchVariables(ctx context.Context, qc *queryContext, names []string) error {
+	for start := 0; start < len(names); start += maxFindBatchSize {
+		end := start + maxFindBatchSize
+		if end > len(names) {
+			end = len(names)
+		}
+		batch := names[start:end]
+
+		resp, err := e.varSvc.FindVariables(ctx, &monolith.FindVariablesRequest{
+			PageId: qc.pageId,
+			Names:  batch,
+		})
+		if err != nil {
+			return err
+		}
+
+		qc.storeVariables(batch, resp.Values)
+	}
+
+	return nil
+}
+
+// fetchPageVariables loads every variable on the page in a single call. Overridden variables are returned with
+// their replacement formulas.
+func (e *Engine) fetchPageVariables(ctx context.Context, qc *queryContext) ([]*monolith.Variable, error) {
+	resp, err := e.varSvc.FindVariables(ctx, &monolith.FindVariablesRequest{
+		PageId: qc.pageId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	qc.storeVariables(nil, resp.Values)
+	qc.markComplete()
+
+	return qc.applyOverrides(resp.Values), nil
+}
+
+// referencedNames returns the normalised names of all variables referenced by obj which aren't bound as lambda
+// parameters, in the order they first appear.
+func referencedNames(obj *types.Object) []string {
+	var out []string
+	seen := make(map[string]bool)
+	collectReferences(obj, make(map[string]bool), seen, &out)
+	return out
+}
+
+func collectReferences(obj *types.Object, bound map[string]bool, seen map[string]bool, out *[]string) {
+	if obj == nil {
+		return
+	}
+
+	switch obj.Type() {
+	case types.TypeApplication:
+		a, _ := obj.ToApplication()
+		collectReferences(a.Expression, bound, seen, out)
+		for _, arg := range a.Arguments {
+			collectReferences(arg, bound, seen, out)
+		}
+	case types.TypeLambda:
+		l, _ := obj.ToLambda()
+		subBound := make(map[string]bool)
+		for k := range bound {
+			subBound[k] = true
+		}
+		for _, v := range l.FreeVariables {
+			subBound[normaliseVarName(v)] = true
+		}
+		if l.RestVariable != "" {
+			subBound[normaliseVarName(l.RestVariable)] = true
+		}
+		for k := range l.Bindings {
+			subBound[k] = true
+		}
+		for _, v := range l.FreeVariables {
+			collectReferences(l.Defaults[normaliseVarName(v)], subBound, seen, out)
+		}
+		collectReferences(l.Expression, subBound, seen, out)
+	case types.TypeList:
+		l, _ := obj.ToList()
+		for _, el := range l.Elements {
+			collectReferences(el, bound, seen, out)
+		}
+	case types.TypeNamedArgument:
+		n, _ := obj.ToNamedArgument()
+		collectReferences(n.Value, bound, seen, out)
+	case types.TypeRecord:
+		r, _ := obj.ToRecord()
+		for _, prop := range r.Properties {
+			collectReferences(prop.Value, bound, seen, out)
+		}
+	case types.TypeSpread:
+		v, _ := obj.ToSpread()
+		collectReferences(v, bound, seen, out)
+	case types.TypeRange:
+		r, _ := obj.ToRange()
+		for _, name := range rangeCells(r) {
+			if bound[name] || seen[name] {
+				continue
+			}
+			seen[name] = true
+			*out = append(*out, name)
+		}
+	case types.TypeVariable:
+		v, _ := obj.ToVariable()
+		name := normaliseVarName(v.Name)
+		if bound[name] || seen[name] {
+			return
+		}
+		seen[name] = true
+		*out = append(*out, name)
+	}
+}
@@ -0,0 +1,225 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/tobyjsullivan/chalk/parsing"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// FormatObject prints an object as formula source. Functions are printed as the name of the builtin and lambdas
+// are printed without the values of any variables they've captured.
+func FormatObject(obj *types.Object) (string, error) {
+	ast, err := toAst(obj)
+	if err != nil {
+		return "", err
+	}
+
+	return parsing.Format(ast), nil
+}
+
+func toAst(obj *types.Object) (*parsing.ASTNode, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	switch obj.Type() {
+	case types.TypeApplication:
+		a, _ := obj.ToApplication()
+		exp, err := toAst(a.Expression)
+		if err != nil {
+			return nil, err
+		}
+		if exp.LambdaVal != nil {
+			// A lambda can only be applied directly when it's wrapped in parentheses.
+			exp = &parsing.ASTNode{
+				TupleVal: &parsing.Tuple{
+					Elements: []*parsing.ASTNode{exp},
+				},
+			}
+		}
+
+		args, err := toAstAll(a.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		return &parsing.ASTNode{
+			ApplicationVal: &parsing.Application{
+				Expression: exp,
+				Argument: &parsing.Tuple{
+					Elements: args,
+				},
+			},
+		}, nil
+	case types.TypeBlank:
+		// Blanks have no literal, so they're printed as the empty string which spreadsheets show in their place.
+		s := ""
+		return &parsing.ASTNode{
+			StringVal: &s,
+		}, nil
+	case types.TypeBoolean:
+		b, _ := obj.ToBoolean()
+		return &parsing.ASTNode{
+			BooleanVal: &b,
+		}, nil
+	case types.TypeFunction:
+		name, _ := obj.FunctionName()
+		if name == "" {
+			return nil, fmt.Errorf("function has no name")
+		}
+		return &parsing.ASTNode{
+			VariableVal: &name,
+		}, nil
+	case types.TypeLambda:
+		l, _ := obj.ToLambda()
+		params := make([]*parsing.ASTNode, len(l.FreeVariables))
+		for i := range l.FreeVariables {
+			params[i] = &parsing.ASTNode{
+				VariableVal: &l.FreeVariables[i],
+			}
+
+			def, ok := l.Defaults[normaliseVarName(l.FreeVariables[i])]
+			if !ok {
+				continue
+			}
+			value, err := toAst(def)
+			if err != nil {
+				return nil, err
+			}
+			params[i] = &parsing.ASTNode{
+				NamedVal: &parsing.NamedArgument{
+					Name:  l.FreeVariables[i],
+					Value: value,
+				},
+			}
+		}
+		if l.RestVariable != "" {
+			rest := l.RestVariable
+			params = append(params, &parsing.ASTNode{
+				SpreadVal: &parsing.ASTNode{
+					VariableVal: &rest,
+				},
+			})
+		}
+
+		exp, err := toAst(l.Expression)
+		if err != nil {
+			return nil, err
+		}
+		return &parsing.ASTNode{
+			LambdaVal: &parsing.Lambda{
+				FreeVariables: &parsing.Tuple{
+					Elements: params,
+				},
+				Expression: exp,
+			},
+		}, nil
+	case types.TypeList:
+		l, _ := obj.ToList()
+		elements, err := toAstAll(l.Elements)
+		if err != nil {
+			return nil, err
+		}
+		return &parsing.ASTNode{
+			ListVal: &parsing.List{
+				Elements: elements,
+			},
+		}, nil
+	case types.TypeMoney:
+		m, _ := obj.ToMoney()
+		return &parsing.ASTNode{
+			QuantityVal: &parsing.Quantity{
+				Number: m.Amount.String(),
+				Unit:   m.Currency,
+			},
+		}, nil
+	case types.TypeNamedArgument:
+		n, _ := obj.ToNamedArgument()
+		value, err := toAst(n.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &parsing.ASTNode{
+			NamedVal: &parsing.NamedArgument{
+				Name:  n.Name,
+				Value: value,
+			},
+		}, nil
+	case types.TypeNumber:
+		n, _ := obj.ToNumber()
+		s := strconv.FormatFloat(n, 'f', -1, 64)
+		if unit := obj.Unit(); unit != nil {
+			return &parsing.ASTNode{
+				QuantityVal: &parsing.Quantity{
+					Number: s,
+					Unit:   unit.String(),
+				},
+			}, nil
+		}
+		return &parsing.ASTNode{
+			NumberVal: &s,
+		}, nil
+	case types.TypeRange:
+		r, _ := obj.ToRange()
+		return &parsing.ASTNode{
+			RangeVal: &parsing.Range{
+				From: r.From,
+				To:   r.To,
+			},
+		}, nil
+	case types.TypeRecord:
+		r, _ := obj.ToRecord()
+		props := make([]*parsing.RecordProperty, len(r.Properties))
+		for i, prop := range r.Properties {
+			v, err := toAst(prop.Value)
+			if err != nil {
+				return nil, err
+			}
+			props[i] = &parsing.RecordProperty{
+				Name:  prop.Name,
+				Value: v,
+			}
+		}
+		return &parsing.ASTNode{
+			RecordVal: &parsing.Record{
+				Properties: props,
+			},
+		}, nil
+	case types.TypeSpread:
+		v, _ := obj.ToSpread()
+		value, err := toAst(v)
+		if err != nil {
+			return nil, err
+		}
+		return &parsing.ASTNode{
+			SpreadVal: value,
+		}, nil
+	case types.TypeString:
+		s, _ := obj.ToString()
+		return &parsing.ASTNode{
+			StringVal: &s,
+		}, nil
+	case types.TypeVariable:
+		v, _ := obj.ToVariable()
+		name := v.Name
+		return &parsing.ASTNode{
+			VariableVal: &name,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unexpected object type: %v", obj.Type())
+	}
+}
+
+func toAstAll(objs []*types.Object) ([]*parsing.ASTNode, error) {
+	out := make([]*parsing.ASTNode, len(objs))
+	for i, o := range objs {
+		var err error
+		out[i], err = toAst(o)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,197 @@
+package engine
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+
+	"github.com/tobyjsullivan/chalk/monolith"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+const maxCachedFormulas = 1000
+
+type contextKey string
+
+const contextKeyQuery = contextKey("query")
+
+// queryContext holds the state shared by everything resolved as part of a single query.
+type queryContext struct {
+	pageId string
+
+	// Slots for goroutines beyond the one running the query. Nil when the query is resolved sequentially.
+	workers chan struct{}
+
+	mx sync.RWMutex
+	// Page variables keyed by normalised name. A nil value records that the name is known not to exist on the page.
+	variables map[string]*monolith.Variable
+	// Set once every variable on the page has been fetched so any other name is known not to exist.
+	complete bool
+	// Formulas which replace those of page variables for this query alone, keyed by normalised name.
+	overrides map[string]string
+	// Resolved values of page variables keyed by normalised name.
+	values map[string]*resolution
+
+	// Seeds simulations which aren't given one.
+	seed int64
+	// Source for distributions to sample from. Nil outside of a simulation's trials.
+	random *rand.Rand
+}
+
+type resolution struct {
+	value *types.Object
+	err   error
+}
+
+func newQueryContext(pageId string, parallelism int) *queryContext {
+	qc := &queryContext{
+		pageId:    pageId,
+		variables: make(map[string]*monolith.Variable),
+		values:    make(map[string]*resolution),
+	}
+	if parallelism > 1 {
+		qc.workers = make(chan struct{}, parallelism-1)
+	}
+
+	return qc
+}
+
+// override makes the named variables resolve to the given formulas instead of their own. Names which aren't on the
+// page are defined for the query as if they were.
+func (qc *queryContext) override(overrides Overrides) {
+	qc.mx.Lock()
+	defer qc.mx.Unlock()
+	qc.overrides = make(map[string]string, len(overrides))
+	for name, formula := range overrides {
+		qc.overrides[normaliseVarName(name)] = formula
+		qc.variables[normaliseVarName(name)] = &monolith.Variable{
+			Page:    qc.pageId,
+			Name:    name,
+			Formula: formula,
+		}
+	}
+}
+
+// applyOverrides returns the variables with the formulas of any overridden ones replaced. The page's own variables
+// are left untouched since they may be shared.
+func (qc *queryContext) applyOverrides(vars []*monolith.Variable) []*monolith.Variable {
+	if len(qc.overrides) == 0 {
+		return vars
+	}
+
+	out := make([]*monolith.Variable, len(vars))
+	for i, v := range vars {
+		out[i] = v
+		if formula, ok := qc.overrides[normaliseVarName(v.Name)]; ok {
+			out[i] = &monolith.Variable{
+				VariableId: v.VariableId,
+				Page:       v.Page,
+				Name:       v.Name,
+				Formula:    formula,
+			}
+		}
+	}
+
+	return out
+}
+
+// fork creates a context for resolving the page again under different conditions, such as a simulation's trials.
+// It shares the page variables fetched so far but not their values. Forks are resolved sequentially so that any
+// random samples are drawn in the same order every time.
+func (qc *queryContext) fork(rng *rand.Rand) *queryContext {
+	qc.mx.RLock()
+	defer qc.mx.RUnlock()
+	t := &queryContext{
+		pageId:    qc.pageId,
+		variables: make(map[string]*monolith.Variable, len(qc.variables)),
+		complete:  qc.complete,
+		overrides: qc.overrides,
+		values:    make(map[string]*resolution),
+		seed:      qc.seed,
+		random:    rng,
+	}
+	for k, v := range qc.variables {
+		t.variables[k] = v
+	}
+
+	return t
+}
+
+// keepValues copies the values resolved by another context, except those of the named variables.
+func (qc *queryContext) keepValues(from *queryContext, except map[string]bool) {
+	from.mx.RLock()
+	defer from.mx.RUnlock()
+	qc.mx.Lock()
+	defer qc.mx.Unlock()
+	for name, r := range from.values {
+		if !except[name] {
+			qc.values[name] = r
+		}
+	}
+}
+
+// resetValues forgets every resolved value so page variables are resolved again.
+func (qc *queryContext) resetValues() {
+	qc.mx.Lock()
+	defer qc.mx.Unlock()
+	qc.values = make(map[string]*resolution)
+}
+
+// lookupVariable returns the page variable with the given name and whether the name has been fetched at all.
+func (qc *queryContext) lookupVariable(name string) (*monolith.Variable, bool) {
+	qc.mx.RLock()
+	defer qc.mx.RUnlock()
+	v, ok := qc.variables[normaliseVarName(name)]
+	return v, ok || qc.complete
+}
+
+func (qc *queryContext) isKnown(name string) bool {
+	_, ok := qc.lookupVariable(name)
+	return ok
+}
+
+// storeVariables records the variables found by a lookup of names. Any names which weren't found are recorded
+// as missing.
+func (qc *queryContext) storeVariables(names []string, found []*monolith.Variable) {
+	qc.mx.Lock()
+	defer qc.mx.Unlock()
+	for _, name := range names {
+		if _, ok := qc.variables[normaliseVarName(name)]; !ok {
+			qc.variables[normaliseVarName(name)] = nil
+		}
+	}
+	for _, v := range qc.applyOverrides(found) {
+		qc.variables[normaliseVarName(v.Name)] = v
+	}
+}
+
+func (qc *queryContext) markComplete() {
+	qc.mx.Lock()
+	defer qc.mx.Unlock()
+	qc.complete = true
+}
+
+func (qc *queryContext) lookupValue(name string) (*resolution, bool) {
+	qc.mx.RLock()
+	defer qc.mx.RUnlock()
+	r, ok := qc.values[normaliseVarName(name)]
+	return r, ok
+}
+
+func (qc *queryContext) storeValue(name string, value *types.Object, err error) {
+	qc.mx.Lock()
+	defer qc.mx.Unlock()
+	qc.values[normaliseVarName(name)] = &resolution{
+		value: value,
+		err:   err,
+	}
+}
+
+func setQueryContext(ctx context.Context, qc *queryContext) context.Context {
+	return context.WithValue(ctx, contextKeyQuery, qc)
+}
+
+func getQueryContext(ctx context.Context) (*queryContext, bool) {
+	qc, ok := ctx.Value(contextKeyQuery).(*queryContext)
+	return qc, ok
+}
@@ -0,0 +1,346 @@
+package sheets
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/tobyjsullivan/chalk/parsing"
+)
+
+type tokenType int
+
+const (
+	tokenNumber tokenType = iota
+	tokenString
+	tokenBoolean
+	// A cell reference such as `B4`, with any `$` markers removed.
+	tokenCell
+	// A range of cells such as `B4:C10`.
+	tokenRange
+	// A function name, which is always followed by `(`.
+	tokenFunction
+	// A defined name, such as a named range.
+	tokenName
+	tokenOperator
+	tokenPunctuation
+	// A construct which is valid in a spreadsheet but has no chalk equivalent. The token's message explains why.
+	tokenUnsupported
+)
+
+type token struct {
+	typ   tokenType
+	value string
+	// The offset of the token in the formula, in characters, and its length.
+	pos int
+	len int
+	// Only set for tokenUnsupported.
+	message string
+	// Only set for tokenRange.
+	from, to string
+}
+
+var (
+	reCell   = regexp.MustCompile(`^\$?([A-Za-z]{1,3})\$?([0-9]+)$`)
+	reColumn = regexp.MustCompile(`^\$?[A-Za-z]{1,3}$`)
+	reRow    = regexp.MustCompile(`^\$?[0-9]+$`)
+)
+
+// Error values, such as `#N/A`, in the order they should be matched.
+var errorValues = []string{"#NULL!", "#DIV/0!", "#VALUE!", "#REF!", "#NAME?", "#NUM!", "#N/A", "#GETTING_DATA"}
+
+// lex splits a spreadsheet formula, without its leading `=`, into tokens. offset is the position of the formula
+// within the text the user entered so that positions can be reported relative to it.
+func lex(formula []rune, offset int) ([]*token, error) {
+	l := &lexer{input: formula, offset: offset}
+	var out []*token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == nil {
+			return out, nil
+		}
+		out = append(out, tok)
+	}
+}
+
+type lexer struct {
+	input  []rune
+	pos    int
+	offset int
+}
+
+func (l *lexer) peek(n int) rune {
+	if l.pos+n >= len(l.input) {
+		return 0
+	}
+	return l.input[l.pos+n]
+}
+
+func (l *lexer) token(typ tokenType, start int, value string) *token {
+	return &token{
+		typ:   typ,
+		value: value,
+		pos:   start + l.offset,
+		len:   l.pos - start,
+	}
+}
+
+func (l *lexer) unsupported(start int, format string, args ...interface{}) *token {
+	tok := l.token(tokenUnsupported, start, string(l.input[start:l.pos]))
+	tok.message = fmt.Sprintf(format, args...)
+	return tok
+}
+
+func (l *lexer) next() (*token, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return nil, nil
+	}
+
+	start := l.pos
+	ch := l.input[l.pos]
+	switch {
+	case ch == '"':
+		return l.readString()
+	case ch == '\'':
+		return l.readQuotedSheet()
+	case ch == '#':
+		return l.readErrorValue()
+	case ch == '{':
+		for l.pos < len(l.input) && l.input[l.pos] != '}' {
+			l.pos++
+		}
+		if l.pos >= len(l.input) {
+			return nil, l.errorf(start, 1, "array constant is never closed")
+		}
+		l.pos++
+		return l.unsupported(start, "array constants aren't supported; use a list such as `[1, 2, 3]` instead"), nil
+	case isDigit(ch) || (ch == '.' && isDigit(l.peek(1))):
+		return l.readNumber()
+	case ch == '$' || isWordStart(ch):
+		return l.readWord()
+	case ch == '<' || ch == '>':
+		l.pos++
+		if n := l.peek(0); n == '=' || (ch == '<' && n == '>') {
+			l.pos++
+		}
+		return l.token(tokenOperator, start, string(l.input[start:l.pos])), nil
+	case strings.ContainsRune("+-*/^&=%", ch):
+		l.pos++
+		return l.token(tokenOperator, start, string(ch)), nil
+	case strings.ContainsRune("(),;", ch):
+		l.pos++
+		if ch == ';' {
+			// Some locales separate arguments with semicolons.
+			ch = ','
+		}
+		return l.token(tokenPunctuation, start, string(ch)), nil
+	}
+
+	return nil, l.errorf(start, 1, "unexpected character `%c`", ch)
+}
+
+func (l *lexer) errorf(start, length int, format string, args ...interface{}) *Diagnostic {
+	return &Diagnostic{
+		Column:  start + l.offset + 1,
+		Length:  length,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// readString reads a string literal, in which quotes are escaped by doubling them.
+func (l *lexer) readString() (*token, error) {
+	start := l.pos
+	l.pos++
+	var str []rune
+	for {
+		if l.pos >= len(l.input) {
+			return nil, l.errorf(start, l.pos-start, "string is never closed")
+		}
+		ch := l.input[l.pos]
+		l.pos++
+		if ch != '"' {
+			str = append(str, ch)
+			continue
+		}
+		if l.peek(0) != '"' {
+			break
+		}
+		str = append(str, '"')
+		l.pos++
+	}
+
+	return l.token(tokenString, start, string(str)), nil
+}
+
+// readQuotedSheet reads a reference to another sheet whose name is quoted, such as `'Q1 Sales'!B4`.
+func (l *lexer) readQuotedSheet() (*token, error) {
+	start := l.pos
+	l.pos++
+	for {
+		if l.pos >= len(l.input) {
+			return nil, l.errorf(start, l.pos-start, "sheet name is never closed")
+		}
+		ch := l.input[l.pos]
+		l.pos++
+		if ch != '\'' {
+			continue
+		}
+		if l.peek(0) != '\'' {
+			break
+		}
+		l.pos++
+	}
+	if l.peek(0) != '!' {
+		return nil, l.errorf(start, l.pos-start, "expected `!` after sheet name")
+	}
+
+	return l.readSheetReference(start)
+}
+
+// readSheetReference consumes the `!` following a sheet name along with the reference which follows it.
+func (l *lexer) readSheetReference(start int) (*token, error) {
+	l.pos++
+	l.readWhile(isWordPart)
+	if l.peek(0) == ':' && (l.peek(1) == '$' || isWordPart(l.peek(1))) {
+		l.pos++
+		l.readWhile(isWordPart)
+	}
+
+	return l.unsupported(start, "references to other sheets aren't supported; chalk pages have a single grid"), nil
+}
+
+func (l *lexer) readErrorValue() (*token, error) {
+	start := l.pos
+	for _, v := range errorValues {
+		if l.pos+len(v) <= len(l.input) && strings.EqualFold(string(l.input[l.pos:l.pos+len(v)]), v) {
+			l.pos += len(v)
+			return l.unsupported(start, "error value `%s` has no chalk equivalent", v), nil
+		}
+	}
+
+	return nil, l.errorf(start, 1, "unexpected character `#`")
+}
+
+func (l *lexer) readNumber() (*token, error) {
+	start := l.pos
+	l.readWhile(isDigit)
+	if l.peek(0) == ':' && (l.peek(1) == '$' || isDigit(l.peek(1))) {
+		return l.readFullRange(start)
+	}
+	if l.peek(0) == '.' {
+		l.pos++
+		l.readWhile(isDigit)
+	}
+	if e := l.peek(0); (e == 'e' || e == 'E') && (isDigit(l.peek(1)) || ((l.peek(1) == '+' || l.peek(1) == '-') && isDigit(l.peek(2)))) {
+		l.pos += 2
+		l.readWhile(isDigit)
+	}
+
+	return l.token(tokenNumber, start, string(l.input[start:l.pos])), nil
+}
+
+// readWord reads a cell reference, range, name, function or boolean.
+func (l *lexer) readWord() (*token, error) {
+	start := l.pos
+	if l.peek(0) == '$' {
+		l.pos++
+	}
+	word := l.readWhile(isWordPart)
+	full := string(l.input[start:l.pos])
+
+	if l.peek(0) == '!' {
+		return l.readSheetReference(start)
+	}
+	if l.peek(0) == '(' && !strings.Contains(full, "$") {
+		return l.token(tokenFunction, start, word), nil
+	}
+	if l.peek(0) == ':' && reColumn.MatchString(full) {
+		return l.readFullRange(start)
+	}
+
+	cell, ok := toCell(full)
+	if !ok {
+		if strings.Contains(full, "$") {
+			return nil, l.errorf(start, l.pos-start, "`%s` is not a cell reference", full)
+		}
+		if strings.EqualFold(word, "true") || strings.EqualFold(word, "false") {
+			return l.token(tokenBoolean, start, strings.ToUpper(word)), nil
+		}
+		return l.token(tokenName, start, word), nil
+	}
+
+	if l.peek(0) != ':' {
+		return l.token(tokenCell, start, cell), nil
+	}
+	l.pos++
+	cornerStart := l.pos
+	if l.peek(0) == '$' {
+		l.pos++
+	}
+	l.readWhile(isWordPart)
+	to, ok := toCell(string(l.input[cornerStart:l.pos]))
+	if !ok {
+		return nil, l.errorf(start, l.pos-start, "`%s` is not a valid range", string(l.input[start:l.pos]))
+	}
+
+	tok := l.token(tokenRange, start, cell+":"+to)
+	tok.from = cell
+	tok.to = to
+	return tok, nil
+}
+
+// readFullRange reads a range of whole rows or columns, such as `A:C` or `2:5`, which chalk can't express since
+// its grid has no fixed size.
+func (l *lexer) readFullRange(start int) (*token, error) {
+	l.pos++
+	l.readWhile(isWordPart)
+	text := string(l.input[start:l.pos])
+	corners := strings.SplitN(text, ":", 2)
+	if !(reColumn.MatchString(corners[0]) && reColumn.MatchString(corners[1])) &&
+		!(reRow.MatchString(corners[0]) && reRow.MatchString(corners[1])) {
+		return nil, l.errorf(start, l.pos-start, "`%s` is not a valid range", text)
+	}
+
+	return l.unsupported(start, "whole row and column ranges aren't supported; use a bounded range such as `A1:A100`"), nil
+}
+
+func (l *lexer) readWhile(p func(rune) bool) string {
+	start := l.pos
+	for l.pos < len(l.input) && p(l.input[l.pos]) {
+		l.pos++
+	}
+	return string(l.input[start:l.pos])
+}
+
+// toCell returns the chalk address of a spreadsheet cell reference, such as `B4` for `$b$4`.
+func toCell(ref string) (string, bool) {
+	m := reCell.FindStringSubmatch(ref)
+	if m == nil {
+		return "", false
+	}
+
+	cell := strings.ToUpper(m[1]) + m[2]
+	if _, _, ok := parsing.ParseCell(cell); !ok {
+		return "", false
+	}
+	return cell, true
+}
+
+func isDigit(ch rune) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isWordStart(ch rune) bool {
+	return unicode.IsLetter(ch) || ch == '_' || ch == '\\'
+}
+
+func isWordPart(ch rune) bool {
+	return isWordStart(ch) || isDigit(ch) || ch == '.' || ch == '$'
+}
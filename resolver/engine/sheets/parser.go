@@ -0,0 +1,243 @@
+package sheets
+
+type nodeType int
+
+const (
+	nodeNumber nodeType = iota
+	nodeString
+	nodeBoolean
+	nodeCell
+	nodeRange
+	nodeName
+	nodeCall
+	// A binary operation such as `A1+B1`. The operator is the node's value.
+	nodeBinary
+	// A leading `-` or `+`.
+	nodeUnary
+	// A trailing `%`.
+	nodePercent
+	// An argument left out of a call, such as the second in `PMT(r, , pv)`.
+	nodeEmpty
+	nodeUnsupported
+)
+
+type node struct {
+	typ   nodeType
+	tok   *token
+	value string
+	args  []*node
+}
+
+// Operators from lowest to highest precedence. Operators of equal precedence are left associative.
+var binaryPrecedence = map[string]int{
+	"=":  1,
+	"<>": 1,
+	"<":  1,
+	">":  1,
+	"<=": 1,
+	">=": 1,
+	"&":  2,
+	"+":  3,
+	"-":  3,
+	"*":  4,
+	"/":  4,
+	"^":  5,
+}
+
+type parser struct {
+	tokens []*token
+	pos    int
+	// The position just past the end of the formula, for reporting errors at the end of input.
+	end int
+}
+
+func parse(tokens []*token, end int) (*node, error) {
+	p := &parser{tokens: tokens, end: end}
+	n, err := p.parseExpression(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok != nil {
+		return nil, errorAt(tok, "unexpected `%s`", tok.value)
+	}
+
+	return n, nil
+}
+
+func (p *parser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() *token {
+	tok := p.peek()
+	if tok != nil {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) isPunctuation(value string) bool {
+	tok := p.peek()
+	return tok != nil && tok.typ == tokenPunctuation && tok.value == value
+}
+
+func (p *parser) endOfInput() error {
+	return &Diagnostic{
+		Column:  p.end + 1,
+		Length:  0,
+		Message: "unexpected end of formula",
+	}
+}
+
+// parseExpression parses operations whose operators have at least the given precedence.
+func (p *parser) parseExpression(precedence int) (*node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok == nil || tok.typ != tokenOperator {
+			return left, nil
+		}
+		prec, ok := binaryPrecedence[tok.value]
+		if !ok || prec <= precedence {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseExpression(prec)
+		if err != nil {
+			return nil, err
+		}
+		left = &node{
+			typ:   nodeBinary,
+			tok:   tok,
+			value: tok.value,
+			args:  []*node{left, right},
+		}
+	}
+}
+
+// parseUnary parses an operand along with any leading signs and trailing percentages, which bind more tightly
+// than any binary operator.
+func (p *parser) parseUnary() (*node, error) {
+	tok := p.peek()
+	if tok != nil && tok.typ == tokenOperator && (tok.value == "-" || tok.value == "+") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &node{
+			typ:   nodeUnary,
+			tok:   tok,
+			value: tok.value,
+			args:  []*node{operand},
+		}, nil
+	}
+
+	n, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok == nil || tok.typ != tokenOperator || tok.value != "%" {
+			return n, nil
+		}
+		p.next()
+		n = &node{
+			typ:  nodePercent,
+			tok:  tok,
+			args: []*node{n},
+		}
+	}
+}
+
+func (p *parser) parseOperand() (*node, error) {
+	tok := p.next()
+	if tok == nil {
+		return nil, p.endOfInput()
+	}
+
+	switch tok.typ {
+	case tokenNumber:
+		return &node{typ: nodeNumber, tok: tok, value: tok.value}, nil
+	case tokenString:
+		return &node{typ: nodeString, tok: tok, value: tok.value}, nil
+	case tokenBoolean:
+		return &node{typ: nodeBoolean, tok: tok, value: tok.value}, nil
+	case tokenCell:
+		return &node{typ: nodeCell, tok: tok, value: tok.value}, nil
+	case tokenRange:
+		return &node{typ: nodeRange, tok: tok, value: tok.value}, nil
+	case tokenName:
+		return &node{typ: nodeName, tok: tok, value: tok.value}, nil
+	case tokenUnsupported:
+		return &node{typ: nodeUnsupported, tok: tok, value: tok.message}, nil
+	case tokenFunction:
+		return p.parseCall(tok)
+	case tokenPunctuation:
+		if tok.value == "(" {
+			n, err := p.parseExpression(0)
+			if err != nil {
+				return nil, err
+			}
+			if !p.isPunctuation(")") {
+				return nil, p.expected(")")
+			}
+			p.next()
+			return n, nil
+		}
+	}
+
+	return nil, errorAt(tok, "unexpected `%s`", tok.value)
+}
+
+func (p *parser) parseCall(name *token) (*node, error) {
+	// Consume the `(`.
+	p.next()
+	call := &node{
+		typ:   nodeCall,
+		tok:   name,
+		value: name.value,
+	}
+	if p.isPunctuation(")") {
+		p.next()
+		return call, nil
+	}
+
+	for {
+		if p.isPunctuation(",") || p.isPunctuation(")") {
+			call.args = append(call.args, &node{typ: nodeEmpty, tok: p.peek()})
+		} else {
+			arg, err := p.parseExpression(0)
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+		}
+
+		if p.isPunctuation(")") {
+			p.next()
+			return call, nil
+		}
+		if !p.isPunctuation(",") {
+			return nil, p.expected(", or )")
+		}
+		p.next()
+	}
+}
+
+func (p *parser) expected(what string) error {
+	tok := p.peek()
+	if tok == nil {
+		return p.endOfInput()
+	}
+	return errorAt(tok, "expected %s; found `%s`", what, tok.value)
+}
@@ -0,0 +1,401 @@
+// Package sheets translates spreadsheet formulas, as written in Excel or Google Sheets, into chalk formulas.
+package sheets
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/tobyjsullivan/chalk/parsing"
+)
+
+// Diagnostic explains why part of a spreadsheet formula couldn't be translated.
+type Diagnostic struct {
+	// The position of the construct in the formula, counting from 1, and its length in characters.
+	Column  int
+	Length  int
+	Message string
+}
+
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("column %d: %s", d.Column, d.Message)
+}
+
+func errorAt(tok *token, format string, args ...interface{}) *Diagnostic {
+	return &Diagnostic{
+		Column:  tok.pos + 1,
+		Length:  tok.len,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// Translate returns the chalk source equivalent to a spreadsheet formula. The leading `=` is optional. If any
+// part of the formula can't be translated, no source is returned and there's a diagnostic for each such part.
+func Translate(formula string) (string, []*Diagnostic) {
+	input := []rune(formula)
+	offset := 0
+	for offset < len(input) && unicode.IsSpace(input[offset]) {
+		offset++
+	}
+	if offset < len(input) && input[offset] == '=' {
+		offset++
+	}
+
+	tokens, err := lex(input[offset:], offset)
+	if err != nil {
+		return "", []*Diagnostic{err.(*Diagnostic)}
+	}
+	if len(tokens) == 0 {
+		return "", []*Diagnostic{{Column: 1, Message: "formula is empty"}}
+	}
+
+	n, err := parse(tokens, len(input))
+	if err != nil {
+		return "", []*Diagnostic{err.(*Diagnostic)}
+	}
+
+	t := &translator{}
+	ast := t.translate(n)
+	if len(t.diagnostics) > 0 {
+		return "", t.diagnostics
+	}
+
+	return parsing.Format(ast), nil
+}
+
+type translator struct {
+	diagnostics []*Diagnostic
+}
+
+func (t *translator) errorf(n *node, format string, args ...interface{}) *parsing.ASTNode {
+	t.diagnostics = append(t.diagnostics, errorAt(n.tok, format, args...))
+	return nil
+}
+
+func (t *translator) translate(n *node) *parsing.ASTNode {
+	switch n.typ {
+	case nodeNumber:
+		f, err := strconv.ParseFloat(n.value, 64)
+		if err != nil {
+			return t.errorf(n, "`%s` is not a valid number", n.value)
+		}
+		return number(f)
+	case nodeString:
+		return str(n.value)
+	case nodeBoolean:
+		b := n.value == "TRUE"
+		return &parsing.ASTNode{BooleanVal: &b}
+	case nodeCell:
+		return variable(n.value)
+	case nodeRange:
+		return &parsing.ASTNode{RangeVal: &parsing.Range{From: n.tok.from, To: n.tok.to}}
+	case nodeName:
+		if !isChalkName(n.value) {
+			return t.errorf(n, "`%s` isn't a valid chalk variable name; names may only contain letters and digits", n.value)
+		}
+		return variable(n.value)
+	case nodeUnary:
+		return t.translateUnary(n)
+	case nodePercent:
+		if arg := n.args[0]; arg.typ == nodeNumber {
+			f, err := strconv.ParseFloat(arg.value, 64)
+			if err != nil {
+				return t.errorf(arg, "`%s` is not a valid number", arg.value)
+			}
+			return number(f / 100)
+		}
+		return call("DIVIDE", t.translate(n.args[0]), number(100))
+	case nodeBinary:
+		return t.translateBinary(n)
+	case nodeCall:
+		return t.translateCall(n)
+	case nodeEmpty:
+		return t.errorf(n, "omitted arguments aren't supported; give every argument a value")
+	case nodeUnsupported:
+		return t.errorf(n, "%s", n.value)
+	}
+
+	return t.errorf(n, "unexpected `%s`", n.tok.value)
+}
+
+func (t *translator) translateUnary(n *node) *parsing.ASTNode {
+	operand := t.translate(n.args[0])
+	if n.value == "+" {
+		return operand
+	}
+
+	// Negative numbers can be written directly.
+	if operand != nil && operand.NumberVal != nil {
+		f, _ := strconv.ParseFloat(*operand.NumberVal, 64)
+		return number(-f)
+	}
+	return call("MULTIPLY", number(-1), operand)
+}
+
+func (t *translator) translateBinary(n *node) *parsing.ASTNode {
+	switch n.value {
+	case "+":
+		return call("ADD", t.translate(n.args[0]), t.translate(n.args[1]))
+	case "-":
+		return call("SUBTRACT", t.translate(n.args[0]), t.translate(n.args[1]))
+	case "*":
+		return call("MULTIPLY", t.translate(n.args[0]), t.translate(n.args[1]))
+	case "/":
+		return call("DIVIDE", t.translate(n.args[0]), t.translate(n.args[1]))
+	case "&":
+		// Chains such as `a&b&c` become a single call.
+		var parts []*parsing.ASTNode
+		for _, arg := range n.args {
+			part := t.translate(arg)
+			if arg.typ == nodeBinary && arg.value == "&" && part != nil {
+				parts = append(parts, part.ApplicationVal.Argument.Elements...)
+				continue
+			}
+			parts = append(parts, part)
+		}
+		return call("CONCATENATE", parts...)
+	case "=":
+		return call("EQUAL", t.translate(n.args[0]), t.translate(n.args[1]))
+	case "<>":
+		return call("NOT", call("EQUAL", t.translate(n.args[0]), t.translate(n.args[1])))
+	}
+
+	t.translate(n.args[0])
+	t.translate(n.args[1])
+	if n.value == "^" {
+		return t.errorf(n, "the `^` operator has no chalk equivalent")
+	}
+	return t.errorf(n, "the `%s` comparison has no chalk equivalent; only `=` and `<>` can be translated", n.value)
+}
+
+// Spreadsheet functions which chalk provides under the same or another name. Functions with special handling,
+// such as IF, are translated separately.
+var functionNames = map[string]string{
+	"AVERAGE":        "AVERAGE",
+	"CONCAT":         "CONCATENATE",
+	"CONCATENATE":    "CONCATENATE",
+	"CORREL":         "CORREL",
+	"COUNT":          "COUNT",
+	"COVAR":          "COVAR",
+	"COVARIANCE.P":   "COVAR",
+	"DDB":            "DDB",
+	"FV":             "FV",
+	"IRR":            "IRR",
+	"MDETERM":        "MDETERM",
+	"MEDIAN":         "MEDIAN",
+	"MINVERSE":       "MINVERSE",
+	"MMULT":          "MMULT",
+	"MODE":           "MODE",
+	"MODE.SNGL":      "MODE",
+	"NOT":            "NOT",
+	"NPER":           "NPER",
+	"NPV":            "NPV",
+	"PERCENTILE":     "PERCENTILE",
+	"PERCENTILE.INC": "PERCENTILE",
+	"PMT":            "PMT",
+	"PV":             "PV",
+	"QUARTILE":       "QUARTILE",
+	"QUARTILE.INC":   "QUARTILE",
+	"RATE":           "RATE",
+	"ROUND":          "ROUND",
+	"SLN":            "SLN",
+	"STDEV":          "STDEV",
+	"STDEV.P":        "STDEVP",
+	"STDEV.S":        "STDEV",
+	"STDEVP":         "STDEVP",
+	"SUM":            "SUM",
+	"TRANSPOSE":      "TRANSPOSE",
+	"VAR":            "VAR",
+	"VAR.P":          "VARP",
+	"VAR.S":          "VAR",
+	"VARP":           "VARP",
+	"XIRR":           "XIRR",
+	"XNPV":           "XNPV",
+}
+
+// Chalk functions which take their values as separate arguments rather than as lists, so any ranges passed to
+// them must be spread.
+var spreadsRanges = map[string]bool{
+	"CONCATENATE": true,
+	"SUM":         true,
+}
+
+func (t *translator) translateCall(n *node) *parsing.ASTNode {
+	// Excel prefixes newer functions when saving them.
+	name := strings.TrimPrefix(strings.ToUpper(n.value), "_XLFN.")
+	switch name {
+	case "IF":
+		return t.translateIf(n)
+	case "VLOOKUP":
+		return t.translateVLookup(n)
+	case "COUNTIF":
+		return t.translateCountIf(n)
+	}
+
+	chalkName, ok := functionNames[name]
+	if !ok {
+		t.errorf(n, "the function `%s` has no chalk equivalent", name)
+		// Report any problems with the arguments too.
+		for _, arg := range n.args {
+			t.translate(arg)
+		}
+		return nil
+	}
+
+	var args []*parsing.ASTNode
+	for _, arg := range n.args {
+		if arg.typ == nodeRange && spreadsRanges[chalkName] {
+			args = append(args, spreadRange(arg.tok)...)
+			continue
+		}
+		args = append(args, t.translate(arg))
+	}
+
+	return call(chalkName, args...)
+}
+
+func (t *translator) translateIf(n *node) *parsing.ASTNode {
+	if l := len(n.args); l != 2 && l != 3 {
+		return t.errorf(n, "IF expects 2 or 3 arguments; found %d", l)
+	}
+
+	args := []*parsing.ASTNode{t.translate(n.args[0]), t.translate(n.args[1])}
+	if len(n.args) == 3 {
+		args = append(args, t.translate(n.args[2]))
+	} else {
+		// Spreadsheets return FALSE when the condition fails and there's no else value.
+		f := false
+		args = append(args, &parsing.ASTNode{BooleanVal: &f})
+	}
+
+	return call("IF", args...)
+}
+
+// translateVLookup translates an exact match VLOOKUP into an XLOOKUP between the first column of the range and the
+// column holding the results.
+func (t *translator) translateVLookup(n *node) *parsing.ASTNode {
+	if l := len(n.args); l != 3 && l != 4 {
+		return t.errorf(n, "VLOOKUP expects 3 or 4 arguments; found %d", l)
+	}
+	value := t.translate(n.args[0])
+
+	exact := false
+	if len(n.args) == 4 {
+		switch arg := n.args[3]; {
+		case arg.typ == nodeBoolean:
+			exact = arg.value == "FALSE"
+		case arg.typ == nodeNumber:
+			f, err := strconv.ParseFloat(arg.value, 64)
+			exact = err == nil && f == 0
+		default:
+			return t.errorf(arg, "VLOOKUP's match type must be TRUE or FALSE")
+		}
+	}
+	if !exact {
+		return t.errorf(n, "approximate VLOOKUP has no chalk equivalent; pass FALSE as the fourth argument for an exact match")
+	}
+
+	table := n.args[1]
+	if table.typ != nodeRange {
+		return t.errorf(table, "VLOOKUP's table must be a range such as `A2:C10`")
+	}
+	rows, err := parsing.ExpandRange(table.tok.from, table.tok.to)
+	if err != nil {
+		return t.errorf(table, "%v", err)
+	}
+
+	index := n.args[2]
+	col, err := strconv.Atoi(index.value)
+	if index.typ != nodeNumber || err != nil {
+		return t.errorf(index, "VLOOKUP's column index must be a whole number")
+	}
+	if col < 1 || col > len(rows[0]) {
+		return t.errorf(index, "VLOOKUP's column index must be between 1 and %d", len(rows[0]))
+	}
+
+	last := rows[len(rows)-1]
+	keys := &parsing.Range{From: rows[0][0], To: last[0]}
+	results := &parsing.Range{From: rows[0][col-1], To: last[col-1]}
+	return call("XLOOKUP", value, &parsing.ASTNode{RangeVal: keys}, &parsing.ASTNode{RangeVal: results})
+}
+
+// translateCountIf translates COUNTIF when its criterion is a value to match exactly. Criteria which compare,
+// such as `">5"`, or which contain wildcards have no equivalent.
+func (t *translator) translateCountIf(n *node) *parsing.ASTNode {
+	if l := len(n.args); l != 2 {
+		return t.errorf(n, "COUNTIF expects 2 arguments; found %d", l)
+	}
+
+	criterion := n.args[1]
+	if criterion.typ == nodeString {
+		if strings.ContainsAny(criterion.value, "*?~") {
+			return t.errorf(criterion, "COUNTIF criteria with wildcards have no chalk equivalent")
+		}
+		if strings.IndexAny(criterion.value, "<>=") == 0 {
+			return t.errorf(criterion, "COUNTIF criteria with comparisons have no chalk equivalent")
+		}
+	}
+
+	return call("COUNTIF", t.translate(n.args[0]), t.translate(criterion))
+}
+
+// spreadRange spreads the cells of a range into separate arguments. Since a range of several rows and columns
+// resolves to a list of rows, each of its columns is spread in turn.
+func spreadRange(tok *token) []*parsing.ASTNode {
+	left, top, _ := parsing.ParseCell(tok.from)
+	right, bottom, _ := parsing.ParseCell(tok.to)
+	if left > right {
+		left, right = right, left
+	}
+	if top > bottom {
+		top, bottom = bottom, top
+	}
+	if left == right || top == bottom {
+		return []*parsing.ASTNode{spread(tok.from, tok.to)}
+	}
+
+	var out []*parsing.ASTNode
+	for col := left; col <= right; col++ {
+		out = append(out, spread(parsing.CellName(col, top), parsing.CellName(col, bottom)))
+	}
+	return out
+}
+
+func spread(from, to string) *parsing.ASTNode {
+	return &parsing.ASTNode{
+		SpreadVal: &parsing.ASTNode{RangeVal: &parsing.Range{From: from, To: to}},
+	}
+}
+
+// isChalkName returns whether a spreadsheet name can be used as a chalk variable name.
+func isChalkName(name string) bool {
+	for i, ch := range name {
+		if !(ch >= 'a' && ch <= 'z') && !(ch >= 'A' && ch <= 'Z') && !(i > 0 && isDigit(ch)) {
+			return false
+		}
+	}
+	return true
+}
+
+func call(name string, args ...*parsing.ASTNode) *parsing.ASTNode {
+	return &parsing.ASTNode{
+		ApplicationVal: &parsing.Application{
+			Expression: variable(name),
+			Argument:   &parsing.Tuple{Elements: args},
+		},
+	}
+}
+
+func variable(name string) *parsing.ASTNode {
+	return &parsing.ASTNode{VariableVal: &name}
+}
+
+func number(f float64) *parsing.ASTNode {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	return &parsing.ASTNode{NumberVal: &s}
+}
+
+func str(s string) *parsing.ASTNode {
+	return &parsing.ASTNode{StringVal: &s}
+}
@@ -0,0 +1,107 @@
+package sheets
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tobyjsullivan/chalk/parsing"
+)
+
+func TestTranslate(t *testing.T) {
+	cases := map[string]string{
+		"=1+2":                          "ADD(1, 2)",
+		"A1 * (B2 - 3)":                 "MULTIPLY(A1, SUBTRACT(B2, 3))",
+		"=1+2*3":                        "ADD(1, MULTIPLY(2, 3))",
+		"=10-4-3":                       "SUBTRACT(SUBTRACT(10, 4), 3)",
+		"=$a$1/b$2":                     "DIVIDE(A1, B2)",
+		"=-A1":                          "MULTIPLY(-1, A1)",
+		"=-2.5e3":                       "-2500",
+		"=+.5":                          "0.5",
+		"=5%":                           "0.05",
+		"=A1%":                          "DIVIDE(A1, 100)",
+		`="say ""hi"""`:                 `"say \"hi\""`,
+		"=TRUE":                         "true",
+		"=growth":                       "growth",
+		`=A1&" "&B1`:                    `CONCATENATE(A1, " ", B1)`,
+		"=A1=B1":                        "EQUAL(A1, B1)",
+		"=A1<>B1":                       "NOT(EQUAL(A1, B1))",
+		`=IF(A1=1, "one", "other")`:     `IF(EQUAL(A1, 1), "one", "other")`,
+		"=if(A1,B1)":                    "IF(A1, B1, false)",
+		"=SUM(A1:A3, 4)":                "SUM(...A1:A3, 4)",
+		"=SUM(A1:B2)":                   "SUM(...A1:A2, ...B1:B2)",
+		"=AVERAGE(B2:$D$10)":            "AVERAGE(B2:D10)",
+		"=_xlfn.STDEV.S(A1:A9)":         "STDEV(A1:A9)",
+		"=CONCAT(A1:C1)":                "CONCATENATE(...A1:C1)",
+		"=NPV(0.1; A1:A5)":              "NPV(0.1, A1:A5)",
+		"=COUNTIF(A1:A9, \"apples\")":   `COUNTIF(A1:A9, "apples")`,
+		"=VLOOKUP(E1, A1:C2, 3, FALSE)": "XLOOKUP(E1, A1:A2, C1:C2)",
+		"=VLOOKUP(E1, $A$2:D500, 2, 0)": "XLOOKUP(E1, A2:A500, B2:B500)",
+	}
+
+	for input, expected := range cases {
+		actual, diagnostics := Translate(input)
+		if len(diagnostics) > 0 {
+			t.Errorf("Unexpected diagnostics translating %q: %v", input, diagnostics)
+			continue
+		}
+		if actual != expected {
+			t.Errorf("Expected %q to translate to %q; got %q", input, expected, actual)
+		}
+		if _, err := parsing.Parse(actual); err != nil {
+			t.Errorf("Expected translation of %q to parse; got %s", input, err)
+		}
+	}
+}
+
+func TestTranslate_Diagnostics(t *testing.T) {
+	cases := map[string][]Diagnostic{
+		"":               {{1, 0, "formula is empty"}},
+		"=2^3":           {{3, 1, "the `^` operator has no chalk equivalent"}},
+		"=IF(A1>0, 1)":   {{7, 1, "the `>` comparison has no chalk equivalent; only `=` and `<>` can be translated"}},
+		"=Sheet2!A1+1":   {{2, 9, "references to other sheets aren't supported; chalk pages have a single grid"}},
+		"='Q1 Sales'!B4": {{2, 13, "references to other sheets aren't supported; chalk pages have a single grid"}},
+		"=SUM(A:A)": {
+			{6, 3, "whole row and column ranges aren't supported; use a bounded range such as `A1:A100`"},
+		},
+		"=MAX(A1, #N/A)": {
+			{2, 3, "the function `MAX` has no chalk equivalent"},
+			{10, 4, "error value `#N/A` has no chalk equivalent"},
+		},
+		"=SUM(#N/A, {1,2})": {
+			{6, 4, "error value `#N/A` has no chalk equivalent"},
+			{12, 5, "array constants aren't supported; use a list such as `[1, 2, 3]` instead"},
+		},
+		"=VLOOKUP(E1, A1:C9, 2)": {
+			{2, 7, "approximate VLOOKUP has no chalk equivalent; pass FALSE as the fourth argument for an exact match"},
+		},
+		"=VLOOKUP(E1, A1:C9, 4, FALSE)": {{21, 1, "VLOOKUP's column index must be between 1 and 3"}},
+		"=COUNTIF(A1:A9, \">5\")":       {{17, 4, "COUNTIF criteria with comparisons have no chalk equivalent"}},
+		"=PMT(0.05, 10, , 1)":           {{16, 1, "omitted arguments aren't supported; give every argument a value"}},
+		"=unit_price*2": {
+			{2, 10, "`unit_price` isn't a valid chalk variable name; names may only contain letters and digits"},
+		},
+		"=SUM(1, 2":    {{10, 0, "unexpected end of formula"}},
+		"=(1 + 2))":    {{9, 1, "unexpected `)`"}},
+		`="unclosed`:   {{2, 9, "string is never closed"}},
+		"=1 @ 2":       {{4, 1, "unexpected character `@`"}},
+		"=SUM(1 2)":    {{8, 1, "expected , or ); found `2`"}},
+		"=A1:B":        {{2, 4, "`A1:B` is not a valid range"}},
+		"= \t=1":       {{4, 1, "unexpected `=`"}},
+		"=IF(1,2,3,4)": {{2, 2, "IF expects 2 or 3 arguments; found 4"}},
+	}
+
+	for input, expected := range cases {
+		actual, diagnostics := Translate(input)
+		if actual != "" {
+			t.Errorf("Expected no translation for %q; got %q", input, actual)
+		}
+
+		found := make([]Diagnostic, len(diagnostics))
+		for i, d := range diagnostics {
+			found[i] = *d
+		}
+		if !reflect.DeepEqual(found, expected) {
+			t.Errorf("Expected diagnostics for %q to be %v; got %v", input, expected, found)
+		}
+	}
+}
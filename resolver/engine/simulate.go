@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/lib/stats"
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+const (
+	simulateName = "simulate"
+	// Guards against a simulation tying up the resolver indefinitely.
+	maxTrials = 100000
+)
+
+// simulate calls expr, usually a lambda without params, n times and summarises the results. Distributions sample
+// afresh in every trial, including those in page variables expr refers to. Without a seed the query's seed is used
+// so the same formula gives the same summary every time it's resolved.
+func (e *Engine) simulate(ctx context.Context, values []*types.Object, varHistory []string) (*types.Object, error) {
+	expr := values[0]
+	if t := expr.Type(); t != types.TypeFunction && t != types.TypeLambda {
+		return nil, fmt.Errorf("SIMULATE expects a lambda such as () => NORMAL(0, 1); received a %s", t)
+	}
+	n, err := values[1].ToNumber()
+	if err != nil {
+		return nil, err
+	}
+	if n < 1 || n > maxTrials || n != math.Trunc(n) {
+		return nil, fmt.Errorf("SIMULATE expects n to be a whole number from 1 to %d; found %v", maxTrials, n)
+	}
+
+	qc, ok := getQueryContext(ctx)
+	if !ok {
+		return nil, errors.New("SIMULATE must be resolved as part of a query")
+	}
+	seed := qc.seed
+	if len(values) > 2 {
+		s, err := values[2].ToNumber()
+		if err != nil {
+			return nil, err
+		}
+		if s != math.Trunc(s) {
+			return nil, fmt.Errorf("SIMULATE expects seed to be a whole number; found %v", s)
+		}
+		seed = int64(s)
+	}
+
+	trial := qc.fork(rand.New(rand.NewSource(seed)))
+	trialCtx := setQueryContext(ctx, trial)
+
+	samples := make([]float64, int(n))
+	var unit *types.Unit
+	for i := range samples {
+		trial.resetValues()
+		res, err := e.call(trialCtx, expr, "", nil, nil, varHistory)
+		if err != nil {
+			return nil, err
+		}
+		x, err := res.ToNumber()
+		if err != nil {
+			return nil, fmt.Errorf("SIMULATE expects each trial to give a number; received a %s", res.Type())
+		}
+		if i == 0 {
+			unit = res.Unit()
+		} else if res.Unit().String() != unit.String() {
+			return nil, fmt.Errorf("SIMULATE expects each trial to give the same unit; received %s and %s", unit, res.Unit())
+		}
+		samples[i] = x
+	}
+
+	return stats.Summarise(samples, unit)
+}
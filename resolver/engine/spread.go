@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tobyjsullivan/chalk/resolver/engine/types"
+)
+
+// resolveElements resolves objs, any of which may be spread. The values of spreads are returned in their place
+// along with a record of which values were spread.
+func (e *Engine) resolveElements(ctx context.Context, objs []*types.Object, varHistory []string) ([]*types.Object, []bool, error) {
+	unwrapped := make([]*types.Object, len(objs))
+	spread := make([]bool, len(objs))
+	for i, o := range objs {
+		if o != nil && o.Type() == types.TypeSpread {
+			unwrapped[i], _ = o.ToSpread()
+			spread[i] = true
+		} else {
+			unwrapped[i] = o
+		}
+	}
+
+	resolved, err := e.resolveAll(ctx, unwrapped, varHistory)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resolved, spread, nil
+}
+
+// resolveSpreadable resolves objs and expands the elements of any which are spread lists in place.
+func (e *Engine) resolveSpreadable(ctx context.Context, objs []*types.Object, varHistory []string) ([]*types.Object, error) {
+	resolved, spread, err := e.resolveElements(ctx, objs, varHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	return spreadList(resolved, spread)
+}
+
+func spreadList(values []*types.Object, spread []bool) ([]*types.Object, error) {
+	out := make([]*types.Object, 0, len(values))
+	for i, v := range values {
+		if !spread[i] {
+			out = append(out, v)
+			continue
+		}
+
+		if v == nil || v.Type() != types.TypeList {
+			return nil, fmt.Errorf("cannot spread %s into a list; expected a list", describeType(v))
+		}
+		l, _ := v.ToList()
+		out = append(out, l.Elements...)
+	}
+
+	return out, nil
+}
+
+func toSpreadRecord(v *types.Object) (*types.Record, error) {
+	if v == nil || v.Type() != types.TypeRecord {
+		return nil, fmt.Errorf("cannot spread %s into a record; expected a record", describeType(v))
+	}
+
+	return v.ToRecord()
+}
+
+func describeType(v *types.Object) string {
+	if v == nil {
+		return "an empty value"
+	}
+
+	return fmt.Sprintf("a %s", v.Type())
+}
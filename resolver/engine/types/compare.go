@@ -0,0 +1,96 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Compare orders two booleans, numbers, money or strings of the same type. It returns a negative number if l sorts
+// before r, a positive number if after and zero if they're equal. False sorts before true, numbers are compared in a
+// common unit and money can only be compared within a currency.
+func Compare(l, r *Object) (int, error) {
+	if l.Type() != r.Type() {
+		return 0, fmt.Errorf("cannot compare a %s with a %s", l.Type(), r.Type())
+	}
+
+	switch l.Type() {
+	case TypeBoolean:
+		a, _ := l.ToBoolean()
+		b, _ := r.ToBoolean()
+		switch {
+		case a == b:
+			return 0, nil
+		case b:
+			return -1, nil
+		default:
+			return 1, nil
+		}
+	case TypeMoney:
+		a, _ := l.ToMoney()
+		b, _ := r.ToMoney()
+		if a.Currency != b.Currency {
+			return 0, fmt.Errorf("cannot compare %s with %s", a.Currency, b.Currency)
+		}
+		return a.Amount.Cmp(b.Amount), nil
+	case TypeNumber:
+		a, _ := l.ToNumber()
+		b, _ := r.ToNumber()
+		ua, ub := l.Unit(), r.Unit()
+		if !ua.Compatible(ub) {
+			return 0, fmt.Errorf("cannot compare %s and %s since they measure different things", ua.Describe(), ub.Describe())
+		}
+		if ua != nil {
+			b *= ub.ConversionFactor(ua)
+		}
+		switch {
+		case a < b:
+			return -1, nil
+		case a > b:
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	case TypeString:
+		a, _ := l.ToString()
+		b, _ := r.ToString()
+		return strings.Compare(a, b), nil
+	default:
+		return 0, fmt.Errorf("cannot compare a %s with a %s", l.Type(), r.Type())
+	}
+}
+
+// Key identifies a boolean, number, money or string so that values can be grouped or matched. Values which Compare
+// finds equal share a key, such as 1 km and 1000 m, while values of different types or dimensions never do.
+func Key(v *Object) (string, error) {
+	switch v.Type() {
+	case TypeBoolean:
+		b, _ := v.ToBoolean()
+		return strconv.Quote("boolean:" + strconv.FormatBool(b)), nil
+	case TypeMoney:
+		m, _ := v.ToMoney()
+		amount := m.Amount.String()
+		if strings.Contains(amount, ".") {
+			// Trailing zeros don't change the amount.
+			amount = strings.TrimRight(strings.TrimRight(amount, "0"), ".")
+		}
+		if amount == "-0" {
+			amount = "0"
+		}
+		return strconv.Quote("money:" + amount + " " + m.Currency), nil
+	case TypeNumber:
+		n, _ := v.ToNumber()
+		u := v.Unit()
+		if u == nil {
+			return strconv.Quote("number:" + strconv.FormatFloat(n, 'g', -1, 64)), nil
+		}
+		// Quantities are keyed in base units, rounded so that converting between units doesn't separate equal values.
+		base := strconv.FormatFloat(n*u.scale(), 'g', 12, 64)
+		return strconv.Quote(fmt.Sprintf("number:%s %v", base, u.dimension())), nil
+	case TypeString:
+		s, _ := v.ToString()
+		return strconv.Quote("string:" + s), nil
+	default:
+		return "", fmt.Errorf("expected a boolean, money, number or string; found a %s", v.Type())
+	}
+}
@@ -0,0 +1,165 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number: unscaled × 10^-scale.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// ParseDecimal parses numbers written like "-1234.50".
+func ParseDecimal(s string) (Decimal, error) {
+	digits := s
+	var scale int
+	if dot := strings.Index(s, "."); dot >= 0 {
+		digits = s[:dot] + s[dot+1:]
+		scale = len(s) - dot - 1
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok || strings.ContainsAny(digits, "+_") {
+		return Decimal{}, fmt.Errorf("invalid decimal `%s`", s)
+	}
+
+	return Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+// DecimalFromFloat converts a float to the shortest decimal which converts back to it.
+func DecimalFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, errors.New("cannot represent an infinite or undefined number as a decimal")
+	}
+
+	return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+func (d Decimal) String() string {
+	s := new(big.Int).Abs(d.unscaled).String()
+	if d.scale > 0 {
+		if len(s) <= d.scale {
+			s = strings.Repeat("0", d.scale-len(s)+1) + s
+		}
+		s = s[:len(s)-d.scale] + "." + s[len(s)-d.scale:]
+	}
+	if d.unscaled.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+func (d Decimal) Sign() int {
+	return d.unscaled.Sign()
+}
+
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := align(d, other)
+	return a.unscaled.Cmp(b.unscaled)
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b := align(d, other)
+	return Decimal{unscaled: new(big.Int).Add(a.unscaled, b.unscaled), scale: a.scale}
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b := align(d, other)
+	return Decimal{unscaled: new(big.Int).Sub(a.unscaled, b.unscaled), scale: a.scale}
+}
+
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.unscaled, other.unscaled), scale: d.scale + other.scale}
+}
+
+// Quo divides by other, rounding half to even to the given number of decimal places. other must not be zero.
+func (d Decimal) Quo(other Decimal, places int) Decimal {
+	num := new(big.Int).Set(d.unscaled)
+	den := new(big.Int).Set(other.unscaled)
+
+	// The quotient of the unscaled values is scaled by d.scale - other.scale.
+	if shift := places - d.scale + other.scale; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+
+	return Decimal{unscaled: divRound(num, den), scale: places}
+}
+
+// Round rounds half to even to the given number of decimal places.
+func (d Decimal) Round(places int) Decimal {
+	if places >= d.scale {
+		return d.rescale(places)
+	}
+
+	return Decimal{unscaled: divRound(d.unscaled, pow10(d.scale-places)), scale: places}
+}
+
+// Trim drops trailing zeros after the decimal point, keeping at least the given number of places.
+func (d Decimal) Trim(places int) Decimal {
+	if d.scale < places {
+		return d.rescale(places)
+	}
+
+	ten := big.NewInt(10)
+	unscaled, scale := new(big.Int).Set(d.unscaled), d.scale
+	for scale > places {
+		q, r := new(big.Int).QuoRem(unscaled, ten, new(big.Int))
+		if r.Sign() != 0 {
+			break
+		}
+		unscaled, scale = q, scale-1
+	}
+
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+// rescale increases the number of decimal places without changing the value.
+func (d Decimal) rescale(places int) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.unscaled, pow10(places-d.scale)), scale: places}
+}
+
+func align(a, b Decimal) (Decimal, Decimal) {
+	if a.scale < b.scale {
+		return a.rescale(b.scale), b
+	}
+	return a, b.rescale(a.scale)
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// divRound divides num by den, rounding half to even.
+func divRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	// Compare twice the remainder with the divisor to decide which way to round.
+	twice := new(big.Int).Abs(r)
+	twice.Mul(twice, big.NewInt(2))
+	cmp := twice.Cmp(new(big.Int).Abs(den))
+	if cmp > 0 || (cmp == 0 && q.Bit(0) == 1) {
+		if (num.Sign() < 0) != (den.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return q
+}
@@ -1,3 +1,15 @@
 package types
 
+import "math/rand"
+
 type Function func(paramTuple []*Object) (*Object, error)
+
+// Call applies a function or lambda to arguments.
+type Call func(f *Object, args []*Object) (*Object, error)
+
+// HigherOrderFunction is a builtin which is given a Call so it can apply the functions and lambdas passed to it.
+type HigherOrderFunction func(call Call, paramTuple []*Object) (*Object, error)
+
+// RandomFunction is a builtin which samples from a distribution. The rng is nil outside of a simulation, in which
+// case the function returns the distribution's mean.
+type RandomFunction func(rng *rand.Rand, paramTuple []*Object) (*Object, error)
@@ -0,0 +1,61 @@
+package types
+
+import "fmt"
+
+// Money is an exact amount in a currency identified by its ISO 4217 code.
+type Money struct {
+	Amount   Decimal
+	Currency string
+}
+
+// currencyDigits maps the supported currency codes to the number of digits in their minor unit.
+var currencyDigits = map[string]int{
+	"AUD": 2,
+	"BHD": 3,
+	"BRL": 2,
+	"CAD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"DKK": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HKD": 2,
+	"INR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"MXN": 2,
+	"NOK": 2,
+	"NZD": 2,
+	"SEK": 2,
+	"SGD": 2,
+	"USD": 2,
+	"ZAR": 2,
+}
+
+// IsCurrency reports whether code is a supported ISO 4217 currency code.
+func IsCurrency(code string) bool {
+	_, ok := currencyDigits[code]
+	return ok
+}
+
+// CurrencyDigits returns the number of decimal places in the minor unit of the currency, such as 2 for cents.
+func CurrencyDigits(code string) int {
+	return currencyDigits[code]
+}
+
+// NewMoney creates an amount of money. Amounts are written with at least as many decimal places as the currency's
+// minor unit and without any further trailing zeros.
+func NewMoney(amount Decimal, currency string) (*Object, error) {
+	if !IsCurrency(currency) {
+		return nil, fmt.Errorf("unknown currency `%s`", currency)
+	}
+
+	return &Object{
+		objectType: TypeMoney,
+		moneyValue: &Money{
+			Amount:   amount.Trim(CurrencyDigits(currency)),
+			Currency: currency,
+		},
+	}, nil
+}
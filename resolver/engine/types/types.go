@@ -7,15 +7,20 @@ import (
 )
 
 const (
-	TypeApplication TypeName = "application"
-	TypeBoolean              = "boolean"
-	TypeFunction             = "function" // A function differs from a lambda in that it executes code to resolve.
-	TypeList                 = "list"
-	TypeNumber               = "number"
-	TypeLambda               = "lambda"
-	TypeRecord               = "record"
-	TypeString               = "string"
-	TypeVariable             = "variable"
+	TypeApplication   TypeName = "application"
+	TypeBlank                  = "blank" // A blank grid cell, which only appears within ranges to keep their shape.
+	TypeBoolean                = "boolean"
+	TypeFunction               = "function" // A function differs from a lambda in that it executes code to resolve.
+	TypeList                   = "list"
+	TypeNumber                 = "number"
+	TypeLambda                 = "lambda"
+	TypeMoney                  = "money"
+	TypeNamedArgument          = "named argument" // Only appears, unresolved, within arguments.
+	TypeRange                  = "range"          // Only appears, unresolved, within formulas.
+	TypeRecord                 = "record"
+	TypeSpread                 = "spread" // Only appears, unresolved, within lists, records and arguments.
+	TypeString                 = "string"
+	TypeVariable               = "variable"
 )
 
 type TypeName string
@@ -31,11 +36,91 @@ type List struct {
 
 type Lambda struct {
 	FreeVariables []string
-	Expression    *Object
+	// Name of the parameter which collects any arguments beyond FreeVariables into a list. Empty if there's none.
+	RestVariable string
+	Expression   *Object
+	// Expressions for the default values of any FreeVariables which have one, keyed by normalised name.
+	Defaults map[string]*Object
+	// Values captured from enclosing lambdas, keyed by normalised name.
+	Bindings map[string]*Object
 }
 
+// Range refers to a rectangular block of grid cells between two corners, such as B2 and D10.
+type Range struct {
+	From string
+	To   string
+}
+
+type NamedArgument struct {
+	Name  string
+	Value *Object
+}
+
+// Param describes a parameter of a builtin function. A parameter with a Default, or which is Optional, may be
+// omitted by callers; only a Default is passed in its place.
+type Param struct {
+	Name     string
+	Default  *Object
+	Optional bool
+}
+
+// Record properties are kept in the order they were defined. Before it's resolved, a record may also contain
+// spread properties, which have no name and a spread value.
 type Record struct {
-	Properties map[string]*Object
+	Properties []*RecordProperty
+}
+
+type RecordProperty struct {
+	Name  string
+	Value *Object
+}
+
+// Get returns the value of the named property.
+func (r *Record) Get(name string) (*Object, bool) {
+	for _, p := range r.Properties {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Keys returns the property names in order.
+func (r *Record) Keys() []string {
+	out := make([]string, len(r.Properties))
+	for i, p := range r.Properties {
+		out[i] = p.Name
+	}
+
+	return out
+}
+
+// Set returns a copy of the record with the named property set to value. An existing property keeps its position;
+// a new one is added at the end.
+func (r *Record) Set(name string, value *Object) *Record {
+	out := &Record{
+		Properties: make([]*RecordProperty, 0, len(r.Properties)+1),
+	}
+	found := false
+	for _, p := range r.Properties {
+		if p.Name == name {
+			p = &RecordProperty{
+				Name:  name,
+				Value: value,
+			}
+			found = true
+		}
+		out.Properties = append(out.Properties, p)
+	}
+	if !found {
+		out.Properties = append(out.Properties, &RecordProperty{
+			Name:  name,
+			Value: value,
+		})
+	}
+
+	return out
 }
 
 type Variable struct {
@@ -46,12 +131,21 @@ type Object struct {
 	objectType       TypeName
 	applicationValue *Application
 	booleanValue     bool
+	functionName     string
+	functionParams   []Param
 	functionValue    Function
+	higherOrderValue HigherOrderFunction
 	listValue        *List
+	moneyValue       *Money
 	numberValue      float64
+	randomValue      RandomFunction
+	rangeValue       *Range
 	lambdaValue      *Lambda
+	namedValue       *NamedArgument
 	recordValue      *Record
+	spreadValue      *Object
 	stringValue      string
+	unitValue        *Unit
 	variableValue    *Variable
 }
 
@@ -69,6 +163,22 @@ func NewNumber(n float64) *Object {
 	}
 }
 
+// NewQuantity creates a number measured in the given unit. A nil unit creates a plain number.
+func NewQuantity(n float64, unit *Unit) *Object {
+	return &Object{
+		objectType:  TypeNumber,
+		numberValue: n,
+		unitValue:   unit.normalise(),
+	}
+}
+
+// NewBlank creates a placeholder for a blank cell within a range.
+func NewBlank() *Object {
+	return &Object{
+		objectType: TypeBlank,
+	}
+}
+
 func NewBoolean(b bool) *Object {
 	return &Object{
 		objectType:   TypeBoolean,
@@ -86,19 +196,70 @@ func NewApplication(expression *Object, args []*Object) *Object {
 	}
 }
 
-func NewFunction(f Function) *Object {
+// NewFunction creates a builtin function. Functions created without params only accept positional arguments and
+// leave checking them to f.
+func NewFunction(name string, f Function, params ...Param) *Object {
 	return &Object{
-		objectType:    TypeFunction,
-		functionValue: f,
+		objectType:     TypeFunction,
+		functionName:   name,
+		functionParams: params,
+		functionValue:  f,
+	}
+}
+
+// NewHigherOrderFunction creates a builtin function which calls other functions or lambdas.
+func NewHigherOrderFunction(name string, f HigherOrderFunction, params ...Param) *Object {
+	return &Object{
+		objectType:       TypeFunction,
+		functionName:     name,
+		functionParams:   params,
+		higherOrderValue: f,
+	}
+}
+
+// NewRandomFunction creates a builtin function which samples from a distribution.
+func NewRandomFunction(name string, f RandomFunction, params ...Param) *Object {
+	return &Object{
+		objectType:     TypeFunction,
+		functionName:   name,
+		functionParams: params,
+		randomValue:    f,
 	}
 }
 
 func NewLambda(freeVariables []string, expression *Object) *Object {
+	return NewClosure(freeVariables, "", nil, expression, nil)
+}
+
+// forwardedArguments names the rest param of closures which forward their arguments. It can't be written in a
+// formula, so it never captures a variable of the same name in the lambdas the arguments are forwarded to.
+const forwardedArguments = "$args"
+
+// NewForwardingClosure creates a lambda which collects all of its arguments and evaluates the expression build
+// returns when given a spread of them.
+func NewForwardingClosure(build func(args *Object) *Object) *Object {
+	return NewClosure(nil, forwardedArguments, nil, build(NewSpread(NewVariable(forwardedArguments))), nil)
+}
+
+func NewClosure(freeVariables []string, restVariable string, defaults map[string]*Object, expression *Object, bindings map[string]*Object) *Object {
 	return &Object{
 		objectType: TypeLambda,
 		lambdaValue: &Lambda{
 			FreeVariables: freeVariables,
+			RestVariable:  restVariable,
+			Defaults:      defaults,
 			Expression:    expression,
+			Bindings:      bindings,
+		},
+	}
+}
+
+func NewNamedArgument(name string, value *Object) *Object {
+	return &Object{
+		objectType: TypeNamedArgument,
+		namedValue: &NamedArgument{
+			Name:  name,
+			Value: value,
 		},
 	}
 }
@@ -112,7 +273,7 @@ func NewList(elements []*Object) *Object {
 	}
 }
 
-func NewRecord(properties map[string]*Object) *Object {
+func NewRecord(properties []*RecordProperty) *Object {
 	return &Object{
 		objectType: TypeRecord,
 		recordValue: &Record{
@@ -121,6 +282,23 @@ func NewRecord(properties map[string]*Object) *Object {
 	}
 }
 
+func NewRange(from, to string) *Object {
+	return &Object{
+		objectType: TypeRange,
+		rangeValue: &Range{
+			From: from,
+			To:   to,
+		},
+	}
+}
+
+func NewSpread(value *Object) *Object {
+	return &Object{
+		objectType:  TypeSpread,
+		spreadValue: value,
+	}
+}
+
 func NewVariable(varName string) *Object {
 	return &Object{
 		objectType: TypeVariable,
@@ -143,6 +321,9 @@ func (o *Object) ToString() (string, error) {
 	if o.objectType == TypeNumber {
 		return strconv.FormatFloat(o.numberValue, 'f', -1, 64), nil
 	}
+	if o.objectType == TypeBlank {
+		return "", nil
+	}
 
 	return "", fmt.Errorf("value is not a string: %+v", o)
 }
@@ -160,6 +341,11 @@ func (o *Object) ToNumber() (float64, error) {
 	return 0, errors.New("value is not a number")
 }
 
+// Unit returns the unit a number is measured in, or nil if it has none.
+func (o *Object) Unit() *Unit {
+	return o.unitValue
+}
+
 func (o *Object) ToApplication() (*Application, error) {
 	if o.objectType != TypeApplication {
 		return nil, errors.New("value is not an application")
@@ -184,6 +370,44 @@ func (o *Object) ToFunction() (Function, error) {
 	return o.functionValue, nil
 }
 
+// ToHigherOrderFunction returns the implementation of a higher order function. It's nil for other functions.
+func (o *Object) ToHigherOrderFunction() (HigherOrderFunction, error) {
+	if o.objectType != TypeFunction {
+		return nil, errors.New("value is not a function")
+	}
+
+	return o.higherOrderValue, nil
+}
+
+// ToRandomFunction returns the implementation of a function which samples from a distribution. It's nil for other
+// functions.
+func (o *Object) ToRandomFunction() (RandomFunction, error) {
+	if o.objectType != TypeFunction {
+		return nil, errors.New("value is not a function")
+	}
+
+	return o.randomValue, nil
+}
+
+// FunctionName returns the name by which a function is referred to in formulas.
+func (o *Object) FunctionName() (string, error) {
+	if o.objectType != TypeFunction {
+		return "", errors.New("value is not a function")
+	}
+
+	return o.functionName, nil
+}
+
+// FunctionParams returns the parameters a function declares. It's empty for functions which only accept positional
+// arguments.
+func (o *Object) FunctionParams() ([]Param, error) {
+	if o.objectType != TypeFunction {
+		return nil, errors.New("value is not a function")
+	}
+
+	return o.functionParams, nil
+}
+
 func (o *Object) ToLambda() (*Lambda, error) {
 	if o.objectType != TypeLambda {
 		return nil, errors.New("value is not a lambda")
@@ -200,6 +424,22 @@ func (o *Object) ToList() (*List, error) {
 	return o.listValue, nil
 }
 
+func (o *Object) ToMoney() (*Money, error) {
+	if o.objectType != TypeMoney {
+		return nil, errors.New("value is not money")
+	}
+
+	return o.moneyValue, nil
+}
+
+func (o *Object) ToNamedArgument() (*NamedArgument, error) {
+	if o.objectType != TypeNamedArgument {
+		return nil, errors.New("value is not a named argument")
+	}
+
+	return o.namedValue, nil
+}
+
 func (o *Object) ToRecord() (*Record, error) {
 	if o.objectType != TypeRecord {
 		return nil, errors.New("value is not a record")
@@ -208,6 +448,22 @@ func (o *Object) ToRecord() (*Record, error) {
 	return o.recordValue, nil
 }
 
+func (o *Object) ToSpread() (*Object, error) {
+	if o.objectType != TypeSpread {
+		return nil, errors.New("value is not a spread")
+	}
+
+	return o.spreadValue, nil
+}
+
+func (o *Object) ToRange() (*Range, error) {
+	if o.objectType != TypeRange {
+		return nil, errors.New("value is not a range")
+	}
+
+	return o.rangeValue, nil
+}
+
 func (o *Object) ToVariable() (*Variable, error) {
 	if o.objectType != TypeVariable {
 		return nil, errors.New("value is not a variable")
@@ -0,0 +1,253 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// dimension holds the powers of length, mass and time which make up a unit.
+type dimension [3]int
+
+func (d dimension) add(other dimension, power int) dimension {
+	for i := range d {
+		d[i] += other[i] * power
+	}
+	return d
+}
+
+type unitDefinition struct {
+	// The size of the unit in metres, kilograms and seconds.
+	scale     float64
+	dimension dimension
+}
+
+var (
+	length = dimension{1, 0, 0}
+	mass   = dimension{0, 1, 0}
+	period = dimension{0, 0, 1}
+)
+
+// knownUnits maps each unit symbol to its definition. Symbols are case-sensitive.
+var knownUnits = map[string]unitDefinition{
+	"m":    {1, length},
+	"km":   {1000, length},
+	"cm":   {0.01, length},
+	"mm":   {0.001, length},
+	"in":   {0.0254, length},
+	"ft":   {0.3048, length},
+	"yd":   {0.9144, length},
+	"mi":   {1609.344, length},
+	"nmi":  {1852, length},
+	"kg":   {1, mass},
+	"g":    {0.001, mass},
+	"mg":   {1e-6, mass},
+	"t":    {1000, mass},
+	"oz":   {0.028349523125, mass},
+	"lb":   {0.45359237, mass},
+	"s":    {1, period},
+	"ms":   {0.001, period},
+	"min":  {60, period},
+	"h":    {3600, period},
+	"day":  {86400, period},
+	"wk":   {604800, period},
+	"ha":   {1e4, dimension{2, 0, 0}},
+	"acre": {4046.8564224, dimension{2, 0, 0}},
+	"L":    {0.001, dimension{3, 0, 0}},
+	"mL":   {1e-6, dimension{3, 0, 0}},
+	"gal":  {0.003785411784, dimension{3, 0, 0}},
+	"N":    {1, dimension{1, 1, -2}},
+	"Pa":   {1, dimension{-1, 1, -2}},
+	"kPa":  {1000, dimension{-1, 1, -2}},
+	"J":    {1, dimension{2, 1, -2}},
+	"kWh":  {3.6e6, dimension{2, 1, -2}},
+	"W":    {1, dimension{2, 1, -3}},
+	"kW":   {1000, dimension{2, 1, -3}},
+}
+
+// Unit is a product of known units raised to non-zero powers, such as km/h or kg*m/s^2.
+type Unit struct {
+	Factors []UnitFactor
+}
+
+type UnitFactor struct {
+	Symbol string
+	Power  int
+}
+
+// ParseUnit parses units such as "km", "m^2" and "kg*m/s^2". Every factor after a `/` is in the denominator.
+func ParseUnit(s string) (*Unit, error) {
+	out := &Unit{}
+	sign := 1
+	for i, part := range strings.Split(s, "/") {
+		if i > 0 {
+			sign = -1
+		}
+		for _, factor := range strings.Split(part, "*") {
+			symbol, power := strings.TrimSpace(factor), 1
+			if caret := strings.Index(symbol, "^"); caret >= 0 {
+				p, err := strconv.Atoi(strings.TrimSpace(symbol[caret+1:]))
+				if err != nil {
+					return nil, fmt.Errorf("invalid power in unit `%s`", s)
+				}
+				symbol, power = strings.TrimSpace(symbol[:caret]), p
+			}
+			if i == 0 && strings.TrimSpace(part) == "1" {
+				// Units like 1/s have nothing in the numerator.
+				continue
+			}
+			if _, ok := knownUnits[symbol]; !ok {
+				return nil, fmt.Errorf("unknown unit `%s`", symbol)
+			}
+			out.Factors = appendFactor(out.Factors, symbol, sign*power)
+		}
+	}
+
+	return out.normalise(), nil
+}
+
+func hasFactor(factors []UnitFactor, symbol string) bool {
+	for _, f := range factors {
+		if f.Symbol == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+func appendFactor(factors []UnitFactor, symbol string, power int) []UnitFactor {
+	for i, f := range factors {
+		if f.Symbol == symbol {
+			out := append([]UnitFactor(nil), factors...)
+			out[i].Power += power
+			return out
+		}
+	}
+	return append(factors, UnitFactor{Symbol: symbol, Power: power})
+}
+
+// normalise drops factors which have cancelled out. A unit with no factors left is nil.
+func (u *Unit) normalise() *Unit {
+	if u == nil {
+		return nil
+	}
+
+	var factors []UnitFactor
+	for _, f := range u.Factors {
+		if f.Power != 0 {
+			factors = append(factors, f)
+		}
+	}
+	if len(factors) == 0 {
+		return nil
+	}
+	return &Unit{Factors: factors}
+}
+
+// Describe names the unit for messages, including when there's no unit at all.
+func (u *Unit) Describe() string {
+	if u == nil {
+		return "a number without a unit"
+	}
+	return u.String()
+}
+
+// String formats the unit with the factors with positive powers first, such as "kg*m/s^2".
+func (u *Unit) String() string {
+	if u == nil {
+		return ""
+	}
+
+	var num, den []string
+	for _, f := range u.Factors {
+		power := f.Power
+		if power < 0 {
+			power = -power
+		}
+		s := f.Symbol
+		if power != 1 {
+			s += "^" + strconv.Itoa(power)
+		}
+		if f.Power > 0 {
+			num = append(num, s)
+		} else {
+			den = append(den, s)
+		}
+	}
+
+	out := strings.Join(num, "*")
+	if len(den) == 0 {
+		return out
+	}
+	if out == "" {
+		out = "1"
+	}
+	return out + "/" + strings.Join(den, "*")
+}
+
+func (u *Unit) dimension() dimension {
+	var d dimension
+	if u == nil {
+		return d
+	}
+	for _, f := range u.Factors {
+		d = d.add(knownUnits[f.Symbol].dimension, f.Power)
+	}
+	return d
+}
+
+// scale returns the size of the unit in metres, kilograms and seconds.
+func (u *Unit) scale() float64 {
+	s := 1.0
+	if u == nil {
+		return s
+	}
+	for _, f := range u.Factors {
+		s *= math.Pow(knownUnits[f.Symbol].scale, float64(f.Power))
+	}
+	return s
+}
+
+// Compatible reports whether quantities in the two units measure the same thing, so one can be converted to the
+// other. Nil units are only compatible with each other.
+func (u *Unit) Compatible(other *Unit) bool {
+	return u.dimension() == other.dimension() && (u == nil) == (other == nil)
+}
+
+// ConversionFactor returns what to multiply a quantity in the unit by to express it in other. The units must be
+// compatible.
+func (u *Unit) ConversionFactor(other *Unit) float64 {
+	return u.scale() / other.scale()
+}
+
+// Multiply returns the product of the units raised to the given power, so a power of -1 divides by other. Factors
+// of other are expressed in any factor of u with the same dimension, such as m in km, so that they combine or
+// cancel; the returned factor is what to multiply the product of the quantities by to account for the conversion.
+func (u *Unit) Multiply(other *Unit, power int) (*Unit, float64) {
+	factors := []UnitFactor(nil)
+	if u != nil {
+		factors = append(factors, u.Factors...)
+	}
+	factor := 1.0
+
+	if other != nil {
+		for _, f := range other.Factors {
+			symbol := f.Symbol
+			if !hasFactor(factors, symbol) {
+				def := knownUnits[symbol]
+				for _, existing := range factors {
+					target := knownUnits[existing.Symbol]
+					if target.dimension == def.dimension {
+						factor *= math.Pow(def.scale/target.scale, float64(f.Power*power))
+						symbol = existing.Symbol
+						break
+					}
+				}
+			}
+			factors = appendFactor(factors, symbol, f.Power*power)
+		}
+	}
+
+	return (&Unit{Factors: factors}).normalise(), factor
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/tobyjsullivan/chalk/monolith"
+)
+
+// Changes arriving within this long of the first are combined into a single update.
+const watchCoalesceWindow = 100 * time.Millisecond
+
+// WatchPage calls send with the result of every variable on the page and then, each time variables change, with
+// the new results of the changed variables and every variable downstream of them. It returns nil once ctx is done
+// and an error if the subscription to variable changes fails.
+func (e *Engine) WatchPage(ctx context.Context, pageId string, send func([]*VariableResult) error) error {
+	if pageId == "" {
+		return errors.New("pageId must be provided")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Subscribe before taking the initial snapshot so no change can be missed in between.
+	stream, err := e.varSvc.WatchVariables(ctx, &monolith.WatchVariablesRequest{
+		PageId: pageId,
+	})
+	if err != nil {
+		return err
+	}
+
+	events := make(chan *monolith.VariableEvent)
+	failed := make(chan error, 1)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				failed <- err
+				return
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	initial, err := e.QueryPage(ctx, pageId)
+	if err != nil {
+		return err
+	}
+	if err := send(initial); err != nil {
+		return err
+	}
+
+	var changed []string
+	var flush <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-failed:
+			if err == io.EOF || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		case event := <-events:
+			if event.Variable != nil {
+				changed = append(changed, event.Variable.Name)
+			}
+			if event.PreviousName != "" {
+				// Anything which referred to the old name is affected too.
+				changed = append(changed, event.PreviousName)
+			}
+			if flush == nil {
+				flush = time.After(watchCoalesceWindow)
+			}
+		case <-flush:
+			flush = nil
+			results, err := e.queryDownstream(ctx, pageId, changed)
+			changed = nil
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+
+			if len(results) == 0 {
+				continue
+			}
+			if err := send(results); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// queryDownstream resolves the named variables and everything which depends on them.
+func (e *Engine) queryDownstream(ctx context.Context, pageId string, names []string) ([]*VariableResult, error) {
+	qc := e.startQuery(pageId)
+	ctx = setQueryContext(ctx, qc)
+
+	vars, err := e.fetchPageVariables(ctx, qc)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := e.buildDependencyGraph(vars)
+	return e.resolvePageVariables(ctx, qc, vars, graph.downstream(names)), nil
+}
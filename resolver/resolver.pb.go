@@ -25,12 +25,14 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type ObjectType int32
 
 const (
-	ObjectType_BOOLEAN ObjectType = 0
-	ObjectType_LAMBDA  ObjectType = 1
-	ObjectType_LIST    ObjectType = 2
-	ObjectType_NUMBER  ObjectType = 3
-	ObjectType_STRING  ObjectType = 4
-	ObjectType_RECORD  ObjectType = 5
+	ObjectType_BOOLEAN  ObjectType = 0
+	ObjectType_LAMBDA   ObjectType = 1
+	ObjectType_LIST     ObjectType = 2
+	ObjectType_NUMBER   ObjectType = 3
+	ObjectType_STRING   ObjectType = 4
+	ObjectType_RECORD   ObjectType = 5
+	ObjectType_FUNCTION ObjectType = 6
+	ObjectType_MONEY    ObjectType = 8
 )
 
 var ObjectType_name = map[int32]string{
@@ -40,15 +42,19 @@ var ObjectType_name = map[int32]string{
 	3: "NUMBER",
 	4: "STRING",
 	5: "RECORD",
+	6: "FUNCTION",
+	8: "MONEY",
 }
 
 var ObjectType_value = map[string]int32{
-	"BOOLEAN": 0,
-	"LAMBDA":  1,
-	"LIST":    2,
-	"NUMBER":  3,
-	"STRING":  4,
-	"RECORD":  5,
+	"BOOLEAN":  0,
+	"LAMBDA":   1,
+	"LIST":     2,
+	"NUMBER":   3,
+	"STRING":   4,
+	"RECORD":   5,
+	"FUNCTION": 6,
+	"MONEY":    8,
 }
 
 func (x ObjectType) String() string {
@@ -60,11 +66,14 @@ func (ObjectType) EnumDescriptor() ([]byte, []int) {
 }
 
 type ResolveRequest struct {
-	PageId               string   `protobuf:"bytes,1,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
-	Formula              string   `protobuf:"bytes,2,opt,name=formula,proto3" json:"formula,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PageId  string `protobuf:"bytes,1,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	Formula string `protobuf:"bytes,2,opt,name=formula,proto3" json:"formula,omitempty"`
+	// Formulas which replace those of the named page variables for this request alone. Names which aren't on the
+	// page are defined as if they were.
+	Overrides            map[string]string `protobuf:"bytes,3,rep,name=overrides,proto3" json:"overrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ResolveRequest) Reset()         { *m = ResolveRequest{} }
@@ -106,6 +115,13 @@ func (m *ResolveRequest) GetFormula() string {
 	return ""
 }
 
+func (m *ResolveRequest) GetOverrides() map[string]string {
+	if m != nil {
+		return m.Overrides
+	}
+	return nil
+}
+
 type ResolveResponse struct {
 	Result               *Object  `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
 	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
@@ -153,375 +169,1624 @@ func (m *ResolveResponse) GetError() string {
 	return ""
 }
 
-type Object struct {
-	Type                 ObjectType `protobuf:"varint,1,opt,name=type,proto3,enum=resolver.ObjectType" json:"type,omitempty"`
-	BoolValue            bool       `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3" json:"bool_value,omitempty"`
-	StringValue          string     `protobuf:"bytes,3,opt,name=string_value,json=stringValue,proto3" json:"string_value,omitempty"`
-	NumberValue          float64    `protobuf:"fixed64,4,opt,name=number_value,json=numberValue,proto3" json:"number_value,omitempty"`
-	ListValue            *List      `protobuf:"bytes,5,opt,name=list_value,json=listValue,proto3" json:"list_value,omitempty"`
-	RecordValue          *Record    `protobuf:"bytes,6,opt,name=record_value,json=recordValue,proto3" json:"record_value,omitempty"`
-	TupleValue           *Tuple     `protobuf:"bytes,7,opt,name=tuple_value,json=tupleValue,proto3" json:"tuple_value,omitempty"`
-	LambdaValue          *Lambda    `protobuf:"bytes,8,opt,name=lambda_value,json=lambdaValue,proto3" json:"lambda_value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type ResolveManyRequest struct {
+	PageId               string   `protobuf:"bytes,1,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	Formulas             []string `protobuf:"bytes,2,rep,name=formulas,proto3" json:"formulas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Object) Reset()         { *m = Object{} }
-func (m *Object) String() string { return proto.CompactTextString(m) }
-func (*Object) ProtoMessage()    {}
-func (*Object) Descriptor() ([]byte, []int) {
+func (m *ResolveManyRequest) Reset()         { *m = ResolveManyRequest{} }
+func (m *ResolveManyRequest) String() string { return proto.CompactTextString(m) }
+func (*ResolveManyRequest) ProtoMessage()    {}
+func (*ResolveManyRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_f5838971722c666f, []int{2}
 }
 
-func (m *Object) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Object.Unmarshal(m, b)
+func (m *ResolveManyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveManyRequest.Unmarshal(m, b)
 }
-func (m *Object) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Object.Marshal(b, m, deterministic)
+func (m *ResolveManyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveManyRequest.Marshal(b, m, deterministic)
 }
-func (m *Object) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Object.Merge(m, src)
+func (m *ResolveManyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveManyRequest.Merge(m, src)
 }
-func (m *Object) XXX_Size() int {
-	return xxx_messageInfo_Object.Size(m)
+func (m *ResolveManyRequest) XXX_Size() int {
+	return xxx_messageInfo_ResolveManyRequest.Size(m)
 }
-func (m *Object) XXX_DiscardUnknown() {
-	xxx_messageInfo_Object.DiscardUnknown(m)
+func (m *ResolveManyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveManyRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Object proto.InternalMessageInfo
+var xxx_messageInfo_ResolveManyRequest proto.InternalMessageInfo
 
-func (m *Object) GetType() ObjectType {
+func (m *ResolveManyRequest) GetPageId() string {
 	if m != nil {
-		return m.Type
+		return m.PageId
 	}
-	return ObjectType_BOOLEAN
+	return ""
 }
 
-func (m *Object) GetBoolValue() bool {
+func (m *ResolveManyRequest) GetFormulas() []string {
 	if m != nil {
-		return m.BoolValue
+		return m.Formulas
 	}
-	return false
+	return nil
 }
 
-func (m *Object) GetStringValue() string {
-	if m != nil {
-		return m.StringValue
-	}
-	return ""
+type ResolveManyResponse struct {
+	// One result for each formula, in the same order as the request.
+	Results              []*ResolveResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Error                string             `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
-func (m *Object) GetNumberValue() float64 {
-	if m != nil {
-		return m.NumberValue
-	}
-	return 0
+func (m *ResolveManyResponse) Reset()         { *m = ResolveManyResponse{} }
+func (m *ResolveManyResponse) String() string { return proto.CompactTextString(m) }
+func (*ResolveManyResponse) ProtoMessage()    {}
+func (*ResolveManyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{3}
 }
 
-func (m *Object) GetListValue() *List {
-	if m != nil {
-		return m.ListValue
-	}
-	return nil
+func (m *ResolveManyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolveManyResponse.Unmarshal(m, b)
 }
-
-func (m *Object) GetRecordValue() *Record {
-	if m != nil {
-		return m.RecordValue
-	}
-	return nil
+func (m *ResolveManyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolveManyResponse.Marshal(b, m, deterministic)
+}
+func (m *ResolveManyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolveManyResponse.Merge(m, src)
+}
+func (m *ResolveManyResponse) XXX_Size() int {
+	return xxx_messageInfo_ResolveManyResponse.Size(m)
+}
+func (m *ResolveManyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolveManyResponse.DiscardUnknown(m)
 }
 
-func (m *Object) GetTupleValue() *Tuple {
+var xxx_messageInfo_ResolveManyResponse proto.InternalMessageInfo
+
+func (m *ResolveManyResponse) GetResults() []*ResolveResponse {
 	if m != nil {
-		return m.TupleValue
+		return m.Results
 	}
 	return nil
 }
 
-func (m *Object) GetLambdaValue() *Lambda {
+func (m *ResolveManyResponse) GetError() string {
 	if m != nil {
-		return m.LambdaValue
+		return m.Error
 	}
-	return nil
+	return ""
 }
 
-type List struct {
-	Elements             []*Object `protobuf:"bytes,1,rep,name=elements,proto3" json:"elements,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type ResolvePageRequest struct {
+	PageId string `protobuf:"bytes,1,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	// Formulas which replace those of the named page variables for this request alone.
+	Overrides            map[string]string `protobuf:"bytes,2,rep,name=overrides,proto3" json:"overrides,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *List) Reset()         { *m = List{} }
-func (m *List) String() string { return proto.CompactTextString(m) }
-func (*List) ProtoMessage()    {}
-func (*List) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f5838971722c666f, []int{3}
+func (m *ResolvePageRequest) Reset()         { *m = ResolvePageRequest{} }
+func (m *ResolvePageRequest) String() string { return proto.CompactTextString(m) }
+func (*ResolvePageRequest) ProtoMessage()    {}
+func (*ResolvePageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{4}
 }
 
-func (m *List) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_List.Unmarshal(m, b)
+func (m *ResolvePageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolvePageRequest.Unmarshal(m, b)
 }
-func (m *List) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_List.Marshal(b, m, deterministic)
+func (m *ResolvePageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolvePageRequest.Marshal(b, m, deterministic)
 }
-func (m *List) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_List.Merge(m, src)
+func (m *ResolvePageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolvePageRequest.Merge(m, src)
 }
-func (m *List) XXX_Size() int {
-	return xxx_messageInfo_List.Size(m)
+func (m *ResolvePageRequest) XXX_Size() int {
+	return xxx_messageInfo_ResolvePageRequest.Size(m)
 }
-func (m *List) XXX_DiscardUnknown() {
-	xxx_messageInfo_List.DiscardUnknown(m)
+func (m *ResolvePageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolvePageRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_List proto.InternalMessageInfo
+var xxx_messageInfo_ResolvePageRequest proto.InternalMessageInfo
 
-func (m *List) GetElements() []*Object {
+func (m *ResolvePageRequest) GetPageId() string {
 	if m != nil {
-		return m.Elements
+		return m.PageId
+	}
+	return ""
+}
+
+func (m *ResolvePageRequest) GetOverrides() map[string]string {
+	if m != nil {
+		return m.Overrides
 	}
 	return nil
 }
 
-type Tuple struct {
-	Elements             []*Object `protobuf:"bytes,2,rep,name=elements,proto3" json:"elements,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type ResolvePageResponse struct {
+	// One result for each page variable, in page order.
+	Results              []*VariableResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Error                string            `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *Tuple) Reset()         { *m = Tuple{} }
-func (m *Tuple) String() string { return proto.CompactTextString(m) }
-func (*Tuple) ProtoMessage()    {}
-func (*Tuple) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f5838971722c666f, []int{4}
+func (m *ResolvePageResponse) Reset()         { *m = ResolvePageResponse{} }
+func (m *ResolvePageResponse) String() string { return proto.CompactTextString(m) }
+func (*ResolvePageResponse) ProtoMessage()    {}
+func (*ResolvePageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{5}
 }
 
-func (m *Tuple) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Tuple.Unmarshal(m, b)
+func (m *ResolvePageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResolvePageResponse.Unmarshal(m, b)
 }
-func (m *Tuple) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Tuple.Marshal(b, m, deterministic)
+func (m *ResolvePageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResolvePageResponse.Marshal(b, m, deterministic)
 }
-func (m *Tuple) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Tuple.Merge(m, src)
+func (m *ResolvePageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResolvePageResponse.Merge(m, src)
 }
-func (m *Tuple) XXX_Size() int {
-	return xxx_messageInfo_Tuple.Size(m)
+func (m *ResolvePageResponse) XXX_Size() int {
+	return xxx_messageInfo_ResolvePageResponse.Size(m)
 }
-func (m *Tuple) XXX_DiscardUnknown() {
-	xxx_messageInfo_Tuple.DiscardUnknown(m)
+func (m *ResolvePageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResolvePageResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Tuple proto.InternalMessageInfo
+var xxx_messageInfo_ResolvePageResponse proto.InternalMessageInfo
 
-func (m *Tuple) GetElements() []*Object {
+func (m *ResolvePageResponse) GetResults() []*VariableResult {
 	if m != nil {
-		return m.Elements
+		return m.Results
 	}
 	return nil
 }
 
-type Lambda struct {
-	FreeVariables        []string `protobuf:"bytes,1,rep,name=free_variables,json=freeVariables,proto3" json:"free_variables,omitempty"`
+func (m *ResolvePageResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type WatchPageRequest struct {
+	PageId               string   `protobuf:"bytes,1,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Lambda) Reset()         { *m = Lambda{} }
-func (m *Lambda) String() string { return proto.CompactTextString(m) }
-func (*Lambda) ProtoMessage()    {}
-func (*Lambda) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f5838971722c666f, []int{5}
+func (m *WatchPageRequest) Reset()         { *m = WatchPageRequest{} }
+func (m *WatchPageRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchPageRequest) ProtoMessage()    {}
+func (*WatchPageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{6}
 }
 
-func (m *Lambda) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Lambda.Unmarshal(m, b)
+func (m *WatchPageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchPageRequest.Unmarshal(m, b)
 }
-func (m *Lambda) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Lambda.Marshal(b, m, deterministic)
+func (m *WatchPageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchPageRequest.Marshal(b, m, deterministic)
 }
-func (m *Lambda) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Lambda.Merge(m, src)
+func (m *WatchPageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchPageRequest.Merge(m, src)
 }
-func (m *Lambda) XXX_Size() int {
-	return xxx_messageInfo_Lambda.Size(m)
+func (m *WatchPageRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchPageRequest.Size(m)
 }
-func (m *Lambda) XXX_DiscardUnknown() {
-	xxx_messageInfo_Lambda.DiscardUnknown(m)
+func (m *WatchPageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchPageRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Lambda proto.InternalMessageInfo
+var xxx_messageInfo_WatchPageRequest proto.InternalMessageInfo
 
-func (m *Lambda) GetFreeVariables() []string {
+func (m *WatchPageRequest) GetPageId() string {
 	if m != nil {
-		return m.FreeVariables
+		return m.PageId
 	}
-	return nil
+	return ""
 }
 
-type Record struct {
-	Properties           []*RecordProperty `protobuf:"bytes,1,rep,name=properties,proto3" json:"properties,omitempty"`
+type PageUpdate struct {
+	// The first update covers every page variable. Later updates only include variables whose result changed.
+	Results              []*VariableResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *Record) Reset()         { *m = Record{} }
-func (m *Record) String() string { return proto.CompactTextString(m) }
-func (*Record) ProtoMessage()    {}
-func (*Record) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f5838971722c666f, []int{6}
+func (m *PageUpdate) Reset()         { *m = PageUpdate{} }
+func (m *PageUpdate) String() string { return proto.CompactTextString(m) }
+func (*PageUpdate) ProtoMessage()    {}
+func (*PageUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{7}
 }
 
-func (m *Record) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Record.Unmarshal(m, b)
+func (m *PageUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PageUpdate.Unmarshal(m, b)
 }
-func (m *Record) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Record.Marshal(b, m, deterministic)
+func (m *PageUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PageUpdate.Marshal(b, m, deterministic)
 }
-func (m *Record) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Record.Merge(m, src)
+func (m *PageUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PageUpdate.Merge(m, src)
 }
-func (m *Record) XXX_Size() int {
-	return xxx_messageInfo_Record.Size(m)
+func (m *PageUpdate) XXX_Size() int {
+	return xxx_messageInfo_PageUpdate.Size(m)
 }
-func (m *Record) XXX_DiscardUnknown() {
-	xxx_messageInfo_Record.DiscardUnknown(m)
+func (m *PageUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_PageUpdate.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Record proto.InternalMessageInfo
+var xxx_messageInfo_PageUpdate proto.InternalMessageInfo
 
-func (m *Record) GetProperties() []*RecordProperty {
+func (m *PageUpdate) GetResults() []*VariableResult {
 	if m != nil {
-		return m.Properties
+		return m.Results
 	}
 	return nil
 }
 
-type RecordProperty struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Value                *Object  `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+type FormatFormulaRequest struct {
+	Formula              string   `protobuf:"bytes,1,opt,name=formula,proto3" json:"formula,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RecordProperty) Reset()         { *m = RecordProperty{} }
-func (m *RecordProperty) String() string { return proto.CompactTextString(m) }
-func (*RecordProperty) ProtoMessage()    {}
-func (*RecordProperty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f5838971722c666f, []int{7}
+func (m *FormatFormulaRequest) Reset()         { *m = FormatFormulaRequest{} }
+func (m *FormatFormulaRequest) String() string { return proto.CompactTextString(m) }
+func (*FormatFormulaRequest) ProtoMessage()    {}
+func (*FormatFormulaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{8}
 }
 
-func (m *RecordProperty) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RecordProperty.Unmarshal(m, b)
+func (m *FormatFormulaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FormatFormulaRequest.Unmarshal(m, b)
 }
-func (m *RecordProperty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RecordProperty.Marshal(b, m, deterministic)
+func (m *FormatFormulaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FormatFormulaRequest.Marshal(b, m, deterministic)
 }
-func (m *RecordProperty) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RecordProperty.Merge(m, src)
+func (m *FormatFormulaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FormatFormulaRequest.Merge(m, src)
 }
-func (m *RecordProperty) XXX_Size() int {
-	return xxx_messageInfo_RecordProperty.Size(m)
+func (m *FormatFormulaRequest) XXX_Size() int {
+	return xxx_messageInfo_FormatFormulaRequest.Size(m)
 }
-func (m *RecordProperty) XXX_DiscardUnknown() {
-	xxx_messageInfo_RecordProperty.DiscardUnknown(m)
+func (m *FormatFormulaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FormatFormulaRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RecordProperty proto.InternalMessageInfo
+var xxx_messageInfo_FormatFormulaRequest proto.InternalMessageInfo
 
-func (m *RecordProperty) GetName() string {
+func (m *FormatFormulaRequest) GetFormula() string {
 	if m != nil {
-		return m.Name
+		return m.Formula
 	}
 	return ""
 }
 
-func (m *RecordProperty) GetValue() *Object {
-	if m != nil {
-		return m.Value
-	}
-	return nil
+type FormatFormulaResponse struct {
+	// Canonical source for the formula. Empty if the formula can't be parsed.
+	Formula              string   `protobuf:"bytes,1,opt,name=formula,proto3" json:"formula,omitempty"`
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterEnum("resolver.ObjectType", ObjectType_name, ObjectType_value)
-	proto.RegisterType((*ResolveRequest)(nil), "resolver.ResolveRequest")
-	proto.RegisterType((*ResolveResponse)(nil), "resolver.ResolveResponse")
-	proto.RegisterType((*Object)(nil), "resolver.Object")
-	proto.RegisterType((*List)(nil), "resolver.List")
-	proto.RegisterType((*Tuple)(nil), "resolver.Tuple")
-	proto.RegisterType((*Lambda)(nil), "resolver.Lambda")
-	proto.RegisterType((*Record)(nil), "resolver.Record")
-	proto.RegisterType((*RecordProperty)(nil), "resolver.RecordProperty")
+func (m *FormatFormulaResponse) Reset()         { *m = FormatFormulaResponse{} }
+func (m *FormatFormulaResponse) String() string { return proto.CompactTextString(m) }
+func (*FormatFormulaResponse) ProtoMessage()    {}
+func (*FormatFormulaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{9}
 }
 
-func init() { proto.RegisterFile("resolver.proto", fileDescriptor_f5838971722c666f) }
-
-var fileDescriptor_f5838971722c666f = []byte{
-	// 514 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x6d, 0x6b, 0x13, 0x41,
-	0x10, 0xee, 0xa5, 0x97, 0xcb, 0x65, 0xae, 0xa6, 0xc7, 0x52, 0x30, 0x0a, 0x42, 0x3c, 0x50, 0x82,
-	0x68, 0x95, 0x54, 0xc1, 0x8f, 0x26, 0x6d, 0x90, 0xc0, 0x35, 0xd1, 0x4d, 0xec, 0xd7, 0x72, 0xd7,
-	0x4c, 0xcb, 0xc9, 0xe6, 0xf6, 0xdc, 0xdb, 0x2b, 0xe4, 0xef, 0xfa, 0x4b, 0x64, 0x5f, 0x72, 0x49,
-	0x43, 0xc0, 0x6f, 0x33, 0xf3, 0x3c, 0xcf, 0xbc, 0xec, 0xce, 0x40, 0x47, 0x60, 0xc9, 0xd9, 0x23,
-	0x8a, 0xf3, 0x42, 0x70, 0xc9, 0x89, 0xbf, 0xf1, 0xa3, 0x4b, 0xe8, 0x50, 0x63, 0x53, 0xfc, 0x53,
-	0x61, 0x29, 0xc9, 0x73, 0x68, 0x15, 0xc9, 0x03, 0xde, 0x66, 0xcb, 0xae, 0xd3, 0x73, 0xfa, 0x6d,
-	0xea, 0x29, 0x77, 0xb2, 0x24, 0x5d, 0x68, 0xdd, 0x73, 0xb1, 0xaa, 0x58, 0xd2, 0x6d, 0x68, 0x60,
-	0xe3, 0x46, 0x3f, 0xe1, 0xb4, 0x4e, 0x52, 0x16, 0x3c, 0x2f, 0x91, 0xf4, 0xc1, 0x13, 0x58, 0x56,
-	0x4c, 0xea, 0x24, 0xc1, 0x20, 0x3c, 0xaf, 0x5b, 0x98, 0xa5, 0xbf, 0xf1, 0x4e, 0x52, 0x8b, 0x93,
-	0x33, 0x68, 0xa2, 0x10, 0x5c, 0xd8, 0xa4, 0xc6, 0x89, 0xfe, 0x36, 0xc0, 0x33, 0x44, 0xd2, 0x07,
-	0x57, 0xae, 0x0b, 0xd4, 0x89, 0x3a, 0x83, 0xb3, 0xfd, 0x44, 0x8b, 0x75, 0x81, 0x54, 0x33, 0xc8,
-	0x2b, 0x80, 0x94, 0x73, 0x76, 0xfb, 0x98, 0xb0, 0x0a, 0x75, 0x3e, 0x9f, 0xb6, 0x55, 0xe4, 0x46,
-	0x05, 0xc8, 0x6b, 0x38, 0x29, 0xa5, 0xc8, 0xf2, 0x07, 0x4b, 0x38, 0xd6, 0x05, 0x03, 0x13, 0xab,
-	0x29, 0x79, 0xb5, 0x4a, 0x51, 0x58, 0x8a, 0xdb, 0x73, 0xfa, 0x0e, 0x0d, 0x4c, 0xcc, 0x50, 0x3e,
-	0x00, 0xb0, 0xac, 0x94, 0x96, 0xd0, 0xd4, 0xd3, 0x75, 0xb6, 0x4d, 0xc5, 0x59, 0x29, 0x69, 0x5b,
-	0x31, 0x0c, 0xfd, 0x02, 0x4e, 0x04, 0xde, 0x71, 0xb1, 0xb4, 0x02, 0x6f, 0xff, 0x39, 0xa8, 0x46,
-	0x69, 0x60, 0x58, 0x46, 0xf4, 0x09, 0x02, 0x59, 0x15, 0x0c, 0xad, 0xa6, 0xa5, 0x35, 0xa7, 0x5b,
-	0xcd, 0x42, 0x81, 0x14, 0x34, 0xa7, 0x2e, 0xc3, 0x92, 0x55, 0xba, 0x4c, 0xac, 0xc4, 0xdf, 0x2f,
-	0x13, 0x6b, 0x94, 0x06, 0x86, 0xa5, 0x45, 0xd1, 0x67, 0x70, 0x55, 0xbb, 0xe4, 0x3d, 0xf8, 0xc8,
-	0x70, 0x85, 0xb9, 0x2c, 0xbb, 0x4e, 0xef, 0xf8, 0xe0, 0x77, 0xd5, 0x8c, 0xe8, 0x0b, 0x34, 0x75,
-	0xfd, 0x27, 0xb2, 0xc6, 0x7f, 0x65, 0x1f, 0xc1, 0x33, 0x3d, 0x90, 0x37, 0xd0, 0xb9, 0x17, 0xa8,
-	0x86, 0x13, 0x59, 0x92, 0x32, 0x34, 0x45, 0xdb, 0xf4, 0x99, 0x8a, 0xde, 0x6c, 0x82, 0xd1, 0x08,
-	0x3c, 0xf3, 0x36, 0xe4, 0x2b, 0x40, 0x21, 0x78, 0x81, 0x42, 0x66, 0xb8, 0xe9, 0xb0, 0xbb, 0xff,
-	0x82, 0x3f, 0x0c, 0x63, 0x4d, 0x77, 0xb8, 0x51, 0xac, 0xd6, 0x7b, 0x17, 0x25, 0x04, 0xdc, 0x3c,
-	0x59, 0xa1, 0xdd, 0x6d, 0x6d, 0x93, 0xb7, 0xd0, 0xdc, 0xae, 0xcc, 0xa1, 0x29, 0x0c, 0xfc, 0x6e,
-	0x0e, 0xb0, 0xdd, 0x39, 0x12, 0x40, 0x6b, 0x34, 0x9b, 0xc5, 0xe3, 0xe1, 0x34, 0x3c, 0x22, 0x00,
-	0x5e, 0x3c, 0xbc, 0x1e, 0x5d, 0x0d, 0x43, 0x87, 0xf8, 0xe0, 0xc6, 0x93, 0xf9, 0x22, 0x6c, 0xa8,
-	0xe8, 0xf4, 0xd7, 0xf5, 0x68, 0x4c, 0xc3, 0x63, 0x65, 0xcf, 0x17, 0x74, 0x32, 0xfd, 0x1e, 0xba,
-	0xca, 0xa6, 0xe3, 0xcb, 0x19, 0xbd, 0x0a, 0x9b, 0x83, 0x18, 0x7c, 0x7b, 0x3c, 0x82, 0x7c, 0x83,
-	0x96, 0xb5, 0xc9, 0x93, 0xf9, 0x76, 0x0f, 0xf4, 0xe5, 0x8b, 0x03, 0x88, 0xb9, 0xba, 0xe8, 0x28,
-	0xf5, 0xf4, 0x81, 0x5f, 0xfc, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x84, 0xe7, 0xab, 0xf8, 0xf2, 0x03,
-	0x00, 0x00,
+func (m *FormatFormulaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FormatFormulaResponse.Unmarshal(m, b)
+}
+func (m *FormatFormulaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FormatFormulaResponse.Marshal(b, m, deterministic)
+}
+func (m *FormatFormulaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FormatFormulaResponse.Merge(m, src)
+}
+func (m *FormatFormulaResponse) XXX_Size() int {
+	return xxx_messageInfo_FormatFormulaResponse.Size(m)
+}
+func (m *FormatFormulaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FormatFormulaResponse.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_FormatFormulaResponse proto.InternalMessageInfo
 
-// ResolverClient is the client API for Resolver service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ResolverClient interface {
-	Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*ResolveResponse, error)
+func (m *FormatFormulaResponse) GetFormula() string {
+	if m != nil {
+		return m.Formula
+	}
+	return ""
 }
 
-type resolverClient struct {
-	cc *grpc.ClientConn
+func (m *FormatFormulaResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
 }
 
-func NewResolverClient(cc *grpc.ClientConn) ResolverClient {
+// Translates a spreadsheet formula, as written in Excel or Google Sheets, into chalk source.
+type TranslateFormulaRequest struct {
+	Formula              string   `protobuf:"bytes,1,opt,name=formula,proto3" json:"formula,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TranslateFormulaRequest) Reset()         { *m = TranslateFormulaRequest{} }
+func (m *TranslateFormulaRequest) String() string { return proto.CompactTextString(m) }
+func (*TranslateFormulaRequest) ProtoMessage()    {}
+func (*TranslateFormulaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{10}
+}
+
+func (m *TranslateFormulaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TranslateFormulaRequest.Unmarshal(m, b)
+}
+func (m *TranslateFormulaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TranslateFormulaRequest.Marshal(b, m, deterministic)
+}
+func (m *TranslateFormulaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TranslateFormulaRequest.Merge(m, src)
+}
+func (m *TranslateFormulaRequest) XXX_Size() int {
+	return xxx_messageInfo_TranslateFormulaRequest.Size(m)
+}
+func (m *TranslateFormulaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TranslateFormulaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TranslateFormulaRequest proto.InternalMessageInfo
+
+func (m *TranslateFormulaRequest) GetFormula() string {
+	if m != nil {
+		return m.Formula
+	}
+	return ""
+}
+
+type TranslateFormulaResponse struct {
+	// Chalk source for the formula. Empty if any part of it can't be translated.
+	Formula              string                   `protobuf:"bytes,1,opt,name=formula,proto3" json:"formula,omitempty"`
+	Diagnostics          []*TranslationDiagnostic `protobuf:"bytes,2,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *TranslateFormulaResponse) Reset()         { *m = TranslateFormulaResponse{} }
+func (m *TranslateFormulaResponse) String() string { return proto.CompactTextString(m) }
+func (*TranslateFormulaResponse) ProtoMessage()    {}
+func (*TranslateFormulaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{11}
+}
+
+func (m *TranslateFormulaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TranslateFormulaResponse.Unmarshal(m, b)
+}
+func (m *TranslateFormulaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TranslateFormulaResponse.Marshal(b, m, deterministic)
+}
+func (m *TranslateFormulaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TranslateFormulaResponse.Merge(m, src)
+}
+func (m *TranslateFormulaResponse) XXX_Size() int {
+	return xxx_messageInfo_TranslateFormulaResponse.Size(m)
+}
+func (m *TranslateFormulaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TranslateFormulaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TranslateFormulaResponse proto.InternalMessageInfo
+
+func (m *TranslateFormulaResponse) GetFormula() string {
+	if m != nil {
+		return m.Formula
+	}
+	return ""
+}
+
+func (m *TranslateFormulaResponse) GetDiagnostics() []*TranslationDiagnostic {
+	if m != nil {
+		return m.Diagnostics
+	}
+	return nil
+}
+
+// Explains why part of a spreadsheet formula can't be translated.
+type TranslationDiagnostic struct {
+	// The position of the construct in the formula, counting from 1, and its length in characters.
+	Column               int32    `protobuf:"varint,1,opt,name=column,proto3" json:"column,omitempty"`
+	Length               int32    `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TranslationDiagnostic) Reset()         { *m = TranslationDiagnostic{} }
+func (m *TranslationDiagnostic) String() string { return proto.CompactTextString(m) }
+func (*TranslationDiagnostic) ProtoMessage()    {}
+func (*TranslationDiagnostic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{12}
+}
+
+func (m *TranslationDiagnostic) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TranslationDiagnostic.Unmarshal(m, b)
+}
+func (m *TranslationDiagnostic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TranslationDiagnostic.Marshal(b, m, deterministic)
+}
+func (m *TranslationDiagnostic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TranslationDiagnostic.Merge(m, src)
+}
+func (m *TranslationDiagnostic) XXX_Size() int {
+	return xxx_messageInfo_TranslationDiagnostic.Size(m)
+}
+func (m *TranslationDiagnostic) XXX_DiscardUnknown() {
+	xxx_messageInfo_TranslationDiagnostic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TranslationDiagnostic proto.InternalMessageInfo
+
+func (m *TranslationDiagnostic) GetColumn() int32 {
+	if m != nil {
+		return m.Column
+	}
+	return 0
+}
+
+func (m *TranslationDiagnostic) GetLength() int32 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *TranslationDiagnostic) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+// Finds the value of the input variable, between low and high, for which the target variable resolves to value.
+type GoalSeekRequest struct {
+	PageId string  `protobuf:"bytes,1,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	Target string  `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	Value  float64 `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	Input  string  `protobuf:"bytes,4,opt,name=input,proto3" json:"input,omitempty"`
+	Low    float64 `protobuf:"fixed64,5,opt,name=low,proto3" json:"low,omitempty"`
+	High   float64 `protobuf:"fixed64,6,opt,name=high,proto3" json:"high,omitempty"`
+	// Defaults to 100 when zero.
+	MaxIterations        int32    `protobuf:"varint,7,opt,name=max_iterations,json=maxIterations,proto3" json:"max_iterations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GoalSeekRequest) Reset()         { *m = GoalSeekRequest{} }
+func (m *GoalSeekRequest) String() string { return proto.CompactTextString(m) }
+func (*GoalSeekRequest) ProtoMessage()    {}
+func (*GoalSeekRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{13}
+}
+
+func (m *GoalSeekRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GoalSeekRequest.Unmarshal(m, b)
+}
+func (m *GoalSeekRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GoalSeekRequest.Marshal(b, m, deterministic)
+}
+func (m *GoalSeekRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GoalSeekRequest.Merge(m, src)
+}
+func (m *GoalSeekRequest) XXX_Size() int {
+	return xxx_messageInfo_GoalSeekRequest.Size(m)
+}
+func (m *GoalSeekRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GoalSeekRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GoalSeekRequest proto.InternalMessageInfo
+
+func (m *GoalSeekRequest) GetPageId() string {
+	if m != nil {
+		return m.PageId
+	}
+	return ""
+}
+
+func (m *GoalSeekRequest) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *GoalSeekRequest) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *GoalSeekRequest) GetInput() string {
+	if m != nil {
+		return m.Input
+	}
+	return ""
+}
+
+func (m *GoalSeekRequest) GetLow() float64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *GoalSeekRequest) GetHigh() float64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *GoalSeekRequest) GetMaxIterations() int32 {
+	if m != nil {
+		return m.MaxIterations
+	}
+	return 0
+}
+
+type GoalSeekResponse struct {
+	// The best value found for the input.
+	Solution float64 `protobuf:"fixed64,1,opt,name=solution,proto3" json:"solution,omitempty"`
+	// The value of the target when the input is the solution.
+	Value                float64  `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	Iterations           int32    `protobuf:"varint,3,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	Converged            bool     `protobuf:"varint,4,opt,name=converged,proto3" json:"converged,omitempty"`
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GoalSeekResponse) Reset()         { *m = GoalSeekResponse{} }
+func (m *GoalSeekResponse) String() string { return proto.CompactTextString(m) }
+func (*GoalSeekResponse) ProtoMessage()    {}
+func (*GoalSeekResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{14}
+}
+
+func (m *GoalSeekResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GoalSeekResponse.Unmarshal(m, b)
+}
+func (m *GoalSeekResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GoalSeekResponse.Marshal(b, m, deterministic)
+}
+func (m *GoalSeekResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GoalSeekResponse.Merge(m, src)
+}
+func (m *GoalSeekResponse) XXX_Size() int {
+	return xxx_messageInfo_GoalSeekResponse.Size(m)
+}
+func (m *GoalSeekResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GoalSeekResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GoalSeekResponse proto.InternalMessageInfo
+
+func (m *GoalSeekResponse) GetSolution() float64 {
+	if m != nil {
+		return m.Solution
+	}
+	return 0
+}
+
+func (m *GoalSeekResponse) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *GoalSeekResponse) GetIterations() int32 {
+	if m != nil {
+		return m.Iterations
+	}
+	return 0
+}
+
+func (m *GoalSeekResponse) GetConverged() bool {
+	if m != nil {
+		return m.Converged
+	}
+	return false
+}
+
+func (m *GoalSeekResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// Resolves the output variable with the row input set to each row value and, for a two-variable table, the column
+// input set to each column value. Values are formulas.
+type DataTableRequest struct {
+	PageId    string   `protobuf:"bytes,1,opt,name=page_id,json=pageId,proto3" json:"page_id,omitempty"`
+	Output    string   `protobuf:"bytes,2,opt,name=output,proto3" json:"output,omitempty"`
+	RowInput  string   `protobuf:"bytes,3,opt,name=row_input,json=rowInput,proto3" json:"row_input,omitempty"`
+	RowValues []string `protobuf:"bytes,4,rep,name=row_values,json=rowValues,proto3" json:"row_values,omitempty"`
+	// Empty for a one-variable table.
+	ColumnInput  string   `protobuf:"bytes,5,opt,name=column_input,json=columnInput,proto3" json:"column_input,omitempty"`
+	ColumnValues []string `protobuf:"bytes,6,rep,name=column_values,json=columnValues,proto3" json:"column_values,omitempty"`
+	// Limits how long the whole table may take. Defaults to, and can't exceed, 30 seconds.
+	TimeoutMs            int32    `protobuf:"varint,7,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataTableRequest) Reset()         { *m = DataTableRequest{} }
+func (m *DataTableRequest) String() string { return proto.CompactTextString(m) }
+func (*DataTableRequest) ProtoMessage()    {}
+func (*DataTableRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{15}
+}
+
+func (m *DataTableRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataTableRequest.Unmarshal(m, b)
+}
+func (m *DataTableRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataTableRequest.Marshal(b, m, deterministic)
+}
+func (m *DataTableRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataTableRequest.Merge(m, src)
+}
+func (m *DataTableRequest) XXX_Size() int {
+	return xxx_messageInfo_DataTableRequest.Size(m)
+}
+func (m *DataTableRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataTableRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataTableRequest proto.InternalMessageInfo
+
+func (m *DataTableRequest) GetPageId() string {
+	if m != nil {
+		return m.PageId
+	}
+	return ""
+}
+
+func (m *DataTableRequest) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *DataTableRequest) GetRowInput() string {
+	if m != nil {
+		return m.RowInput
+	}
+	return ""
+}
+
+func (m *DataTableRequest) GetRowValues() []string {
+	if m != nil {
+		return m.RowValues
+	}
+	return nil
+}
+
+func (m *DataTableRequest) GetColumnInput() string {
+	if m != nil {
+		return m.ColumnInput
+	}
+	return ""
+}
+
+func (m *DataTableRequest) GetColumnValues() []string {
+	if m != nil {
+		return m.ColumnValues
+	}
+	return nil
+}
+
+func (m *DataTableRequest) GetTimeoutMs() int32 {
+	if m != nil {
+		return m.TimeoutMs
+	}
+	return 0
+}
+
+type DataTableResponse struct {
+	// One row for each row value. Each has a cell for each column value, or a single cell for a one-variable table.
+	Rows                 []*DataTableRow `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	Error                string          `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *DataTableResponse) Reset()         { *m = DataTableResponse{} }
+func (m *DataTableResponse) String() string { return proto.CompactTextString(m) }
+func (*DataTableResponse) ProtoMessage()    {}
+func (*DataTableResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{16}
+}
+
+func (m *DataTableResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataTableResponse.Unmarshal(m, b)
+}
+func (m *DataTableResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataTableResponse.Marshal(b, m, deterministic)
+}
+func (m *DataTableResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataTableResponse.Merge(m, src)
+}
+func (m *DataTableResponse) XXX_Size() int {
+	return xxx_messageInfo_DataTableResponse.Size(m)
+}
+func (m *DataTableResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataTableResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataTableResponse proto.InternalMessageInfo
+
+func (m *DataTableResponse) GetRows() []*DataTableRow {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+func (m *DataTableResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type DataTableRow struct {
+	Cells                []*ResolveResponse `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *DataTableRow) Reset()         { *m = DataTableRow{} }
+func (m *DataTableRow) String() string { return proto.CompactTextString(m) }
+func (*DataTableRow) ProtoMessage()    {}
+func (*DataTableRow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{17}
+}
+
+func (m *DataTableRow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataTableRow.Unmarshal(m, b)
+}
+func (m *DataTableRow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataTableRow.Marshal(b, m, deterministic)
+}
+func (m *DataTableRow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataTableRow.Merge(m, src)
+}
+func (m *DataTableRow) XXX_Size() int {
+	return xxx_messageInfo_DataTableRow.Size(m)
+}
+func (m *DataTableRow) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataTableRow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataTableRow proto.InternalMessageInfo
+
+func (m *DataTableRow) GetCells() []*ResolveResponse {
+	if m != nil {
+		return m.Cells
+	}
+	return nil
+}
+
+type VariableResult struct {
+	VariableId           string   `protobuf:"bytes,1,opt,name=variable_id,json=variableId,proto3" json:"variable_id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Formula              string   `protobuf:"bytes,3,opt,name=formula,proto3" json:"formula,omitempty"`
+	Result               *Object  `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VariableResult) Reset()         { *m = VariableResult{} }
+func (m *VariableResult) String() string { return proto.CompactTextString(m) }
+func (*VariableResult) ProtoMessage()    {}
+func (*VariableResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{18}
+}
+
+func (m *VariableResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VariableResult.Unmarshal(m, b)
+}
+func (m *VariableResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VariableResult.Marshal(b, m, deterministic)
+}
+func (m *VariableResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VariableResult.Merge(m, src)
+}
+func (m *VariableResult) XXX_Size() int {
+	return xxx_messageInfo_VariableResult.Size(m)
+}
+func (m *VariableResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_VariableResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VariableResult proto.InternalMessageInfo
+
+func (m *VariableResult) GetVariableId() string {
+	if m != nil {
+		return m.VariableId
+	}
+	return ""
+}
+
+func (m *VariableResult) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *VariableResult) GetFormula() string {
+	if m != nil {
+		return m.Formula
+	}
+	return ""
+}
+
+func (m *VariableResult) GetResult() *Object {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *VariableResult) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type Object struct {
+	Type          ObjectType `protobuf:"varint,1,opt,name=type,proto3,enum=resolver.ObjectType" json:"type,omitempty"`
+	BoolValue     bool       `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3" json:"bool_value,omitempty"`
+	StringValue   string     `protobuf:"bytes,3,opt,name=string_value,json=stringValue,proto3" json:"string_value,omitempty"`
+	NumberValue   float64    `protobuf:"fixed64,4,opt,name=number_value,json=numberValue,proto3" json:"number_value,omitempty"`
+	ListValue     *List      `protobuf:"bytes,5,opt,name=list_value,json=listValue,proto3" json:"list_value,omitempty"`
+	RecordValue   *Record    `protobuf:"bytes,6,opt,name=record_value,json=recordValue,proto3" json:"record_value,omitempty"`
+	TupleValue    *Tuple     `protobuf:"bytes,7,opt,name=tuple_value,json=tupleValue,proto3" json:"tuple_value,omitempty"`
+	LambdaValue   *Lambda    `protobuf:"bytes,8,opt,name=lambda_value,json=lambdaValue,proto3" json:"lambda_value,omitempty"`
+	FunctionValue *Function  `protobuf:"bytes,9,opt,name=function_value,json=functionValue,proto3" json:"function_value,omitempty"`
+	// Only set for lists which can be shown as a table, alongside list_value.
+	TableValue *Table `protobuf:"bytes,10,opt,name=table_value,json=tableValue,proto3" json:"table_value,omitempty"`
+	// The unit a number is measured in, such as "km/h". Empty if it has none.
+	Unit                 string   `protobuf:"bytes,11,opt,name=unit,proto3" json:"unit,omitempty"`
+	MoneyValue           *Money   `protobuf:"bytes,12,opt,name=money_value,json=moneyValue,proto3" json:"money_value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Object) Reset()         { *m = Object{} }
+func (m *Object) String() string { return proto.CompactTextString(m) }
+func (*Object) ProtoMessage()    {}
+func (*Object) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{19}
+}
+
+func (m *Object) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Object.Unmarshal(m, b)
+}
+func (m *Object) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Object.Marshal(b, m, deterministic)
+}
+func (m *Object) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Object.Merge(m, src)
+}
+func (m *Object) XXX_Size() int {
+	return xxx_messageInfo_Object.Size(m)
+}
+func (m *Object) XXX_DiscardUnknown() {
+	xxx_messageInfo_Object.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Object proto.InternalMessageInfo
+
+func (m *Object) GetType() ObjectType {
+	if m != nil {
+		return m.Type
+	}
+	return ObjectType_BOOLEAN
+}
+
+func (m *Object) GetBoolValue() bool {
+	if m != nil {
+		return m.BoolValue
+	}
+	return false
+}
+
+func (m *Object) GetStringValue() string {
+	if m != nil {
+		return m.StringValue
+	}
+	return ""
+}
+
+func (m *Object) GetNumberValue() float64 {
+	if m != nil {
+		return m.NumberValue
+	}
+	return 0
+}
+
+func (m *Object) GetListValue() *List {
+	if m != nil {
+		return m.ListValue
+	}
+	return nil
+}
+
+func (m *Object) GetRecordValue() *Record {
+	if m != nil {
+		return m.RecordValue
+	}
+	return nil
+}
+
+func (m *Object) GetTupleValue() *Tuple {
+	if m != nil {
+		return m.TupleValue
+	}
+	return nil
+}
+
+func (m *Object) GetLambdaValue() *Lambda {
+	if m != nil {
+		return m.LambdaValue
+	}
+	return nil
+}
+
+func (m *Object) GetFunctionValue() *Function {
+	if m != nil {
+		return m.FunctionValue
+	}
+	return nil
+}
+
+func (m *Object) GetTableValue() *Table {
+	if m != nil {
+		return m.TableValue
+	}
+	return nil
+}
+
+func (m *Object) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+func (m *Object) GetMoneyValue() *Money {
+	if m != nil {
+		return m.MoneyValue
+	}
+	return nil
+}
+
+type List struct {
+	Elements             []*Object `protobuf:"bytes,1,rep,name=elements,proto3" json:"elements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *List) Reset()         { *m = List{} }
+func (m *List) String() string { return proto.CompactTextString(m) }
+func (*List) ProtoMessage()    {}
+func (*List) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{20}
+}
+
+func (m *List) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_List.Unmarshal(m, b)
+}
+func (m *List) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_List.Marshal(b, m, deterministic)
+}
+func (m *List) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_List.Merge(m, src)
+}
+func (m *List) XXX_Size() int {
+	return xxx_messageInfo_List.Size(m)
+}
+func (m *List) XXX_DiscardUnknown() {
+	xxx_messageInfo_List.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_List proto.InternalMessageInfo
+
+func (m *List) GetElements() []*Object {
+	if m != nil {
+		return m.Elements
+	}
+	return nil
+}
+
+type Tuple struct {
+	Elements             []*Object `protobuf:"bytes,2,rep,name=elements,proto3" json:"elements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Tuple) Reset()         { *m = Tuple{} }
+func (m *Tuple) String() string { return proto.CompactTextString(m) }
+func (*Tuple) ProtoMessage()    {}
+func (*Tuple) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{21}
+}
+
+func (m *Tuple) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tuple.Unmarshal(m, b)
+}
+func (m *Tuple) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tuple.Marshal(b, m, deterministic)
+}
+func (m *Tuple) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tuple.Merge(m, src)
+}
+func (m *Tuple) XXX_Size() int {
+	return xxx_messageInfo_Tuple.Size(m)
+}
+func (m *Tuple) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tuple.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tuple proto.InternalMessageInfo
+
+func (m *Tuple) GetElements() []*Object {
+	if m != nil {
+		return m.Elements
+	}
+	return nil
+}
+
+type Lambda struct {
+	FreeVariables []string `protobuf:"bytes,1,rep,name=free_variables,json=freeVariables,proto3" json:"free_variables,omitempty"`
+	// Source of the lambda's body.
+	Body string `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	// Values the lambda has captured from the lambdas which enclose it.
+	Bindings []*RecordProperty `protobuf:"bytes,3,rep,name=bindings,proto3" json:"bindings,omitempty"`
+	// Name of the parameter which collects any remaining arguments. Empty if there's none.
+	RestVariable string `protobuf:"bytes,4,opt,name=rest_variable,json=restVariable,proto3" json:"rest_variable,omitempty"`
+	// Source of the default value of each free variable which has one, keyed by name.
+	Defaults             map[string]string `protobuf:"bytes,5,rep,name=defaults,proto3" json:"defaults,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Lambda) Reset()         { *m = Lambda{} }
+func (m *Lambda) String() string { return proto.CompactTextString(m) }
+func (*Lambda) ProtoMessage()    {}
+func (*Lambda) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{22}
+}
+
+func (m *Lambda) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Lambda.Unmarshal(m, b)
+}
+func (m *Lambda) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Lambda.Marshal(b, m, deterministic)
+}
+func (m *Lambda) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Lambda.Merge(m, src)
+}
+func (m *Lambda) XXX_Size() int {
+	return xxx_messageInfo_Lambda.Size(m)
+}
+func (m *Lambda) XXX_DiscardUnknown() {
+	xxx_messageInfo_Lambda.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Lambda proto.InternalMessageInfo
+
+func (m *Lambda) GetFreeVariables() []string {
+	if m != nil {
+		return m.FreeVariables
+	}
+	return nil
+}
+
+func (m *Lambda) GetBody() string {
+	if m != nil {
+		return m.Body
+	}
+	return ""
+}
+
+func (m *Lambda) GetBindings() []*RecordProperty {
+	if m != nil {
+		return m.Bindings
+	}
+	return nil
+}
+
+func (m *Lambda) GetRestVariable() string {
+	if m != nil {
+		return m.RestVariable
+	}
+	return ""
+}
+
+func (m *Lambda) GetDefaults() map[string]string {
+	if m != nil {
+		return m.Defaults
+	}
+	return nil
+}
+
+// A builtin function.
+type Function struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Function) Reset()         { *m = Function{} }
+func (m *Function) String() string { return proto.CompactTextString(m) }
+func (*Function) ProtoMessage()    {}
+func (*Function) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{23}
+}
+
+func (m *Function) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Function.Unmarshal(m, b)
+}
+func (m *Function) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Function.Marshal(b, m, deterministic)
+}
+func (m *Function) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Function.Merge(m, src)
+}
+func (m *Function) XXX_Size() int {
+	return xxx_messageInfo_Function.Size(m)
+}
+func (m *Function) XXX_DiscardUnknown() {
+	xxx_messageInfo_Function.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Function proto.InternalMessageInfo
+
+func (m *Function) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// An exact amount, written as a decimal such as "-1234.50", in the currency with the given ISO 4217 code.
+type Money struct {
+	Amount               string   `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency             string   `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Money) Reset()         { *m = Money{} }
+func (m *Money) String() string { return proto.CompactTextString(m) }
+func (*Money) ProtoMessage()    {}
+func (*Money) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{24}
+}
+
+func (m *Money) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Money.Unmarshal(m, b)
+}
+func (m *Money) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Money.Marshal(b, m, deterministic)
+}
+func (m *Money) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Money.Merge(m, src)
+}
+func (m *Money) XXX_Size() int {
+	return xxx_messageInfo_Money.Size(m)
+}
+func (m *Money) XXX_DiscardUnknown() {
+	xxx_messageInfo_Money.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Money proto.InternalMessageInfo
+
+func (m *Money) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *Money) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+// A list of records which all have the same properties, arranged as rows of cells under those columns.
+type Table struct {
+	Columns              []string    `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	Rows                 []*TableRow `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Table) Reset()         { *m = Table{} }
+func (m *Table) String() string { return proto.CompactTextString(m) }
+func (*Table) ProtoMessage()    {}
+func (*Table) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{25}
+}
+
+func (m *Table) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Table.Unmarshal(m, b)
+}
+func (m *Table) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Table.Marshal(b, m, deterministic)
+}
+func (m *Table) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Table.Merge(m, src)
+}
+func (m *Table) XXX_Size() int {
+	return xxx_messageInfo_Table.Size(m)
+}
+func (m *Table) XXX_DiscardUnknown() {
+	xxx_messageInfo_Table.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Table proto.InternalMessageInfo
+
+func (m *Table) GetColumns() []string {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+func (m *Table) GetRows() []*TableRow {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+type TableRow struct {
+	Cells                []*Object `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *TableRow) Reset()         { *m = TableRow{} }
+func (m *TableRow) String() string { return proto.CompactTextString(m) }
+func (*TableRow) ProtoMessage()    {}
+func (*TableRow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{26}
+}
+
+func (m *TableRow) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TableRow.Unmarshal(m, b)
+}
+func (m *TableRow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TableRow.Marshal(b, m, deterministic)
+}
+func (m *TableRow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TableRow.Merge(m, src)
+}
+func (m *TableRow) XXX_Size() int {
+	return xxx_messageInfo_TableRow.Size(m)
+}
+func (m *TableRow) XXX_DiscardUnknown() {
+	xxx_messageInfo_TableRow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TableRow proto.InternalMessageInfo
+
+func (m *TableRow) GetCells() []*Object {
+	if m != nil {
+		return m.Cells
+	}
+	return nil
+}
+
+type Record struct {
+	Properties           []*RecordProperty `protobuf:"bytes,1,rep,name=properties,proto3" json:"properties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Record) Reset()         { *m = Record{} }
+func (m *Record) String() string { return proto.CompactTextString(m) }
+func (*Record) ProtoMessage()    {}
+func (*Record) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{27}
+}
+
+func (m *Record) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Record.Unmarshal(m, b)
+}
+func (m *Record) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Record.Marshal(b, m, deterministic)
+}
+func (m *Record) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Record.Merge(m, src)
+}
+func (m *Record) XXX_Size() int {
+	return xxx_messageInfo_Record.Size(m)
+}
+func (m *Record) XXX_DiscardUnknown() {
+	xxx_messageInfo_Record.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Record proto.InternalMessageInfo
+
+func (m *Record) GetProperties() []*RecordProperty {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+type RecordProperty struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value                *Object  `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RecordProperty) Reset()         { *m = RecordProperty{} }
+func (m *RecordProperty) String() string { return proto.CompactTextString(m) }
+func (*RecordProperty) ProtoMessage()    {}
+func (*RecordProperty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f5838971722c666f, []int{28}
+}
+
+func (m *RecordProperty) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecordProperty.Unmarshal(m, b)
+}
+func (m *RecordProperty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecordProperty.Marshal(b, m, deterministic)
+}
+func (m *RecordProperty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecordProperty.Merge(m, src)
+}
+func (m *RecordProperty) XXX_Size() int {
+	return xxx_messageInfo_RecordProperty.Size(m)
+}
+func (m *RecordProperty) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecordProperty.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecordProperty proto.InternalMessageInfo
+
+func (m *RecordProperty) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RecordProperty) GetValue() *Object {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("resolver.ObjectType", ObjectType_name, ObjectType_value)
+	proto.RegisterType((*ResolveRequest)(nil), "resolver.ResolveRequest")
+	proto.RegisterMapType((map[string]string)(nil), "resolver.ResolveRequest.OverridesEntry")
+	proto.RegisterType((*ResolveResponse)(nil), "resolver.ResolveResponse")
+	proto.RegisterType((*ResolveManyRequest)(nil), "resolver.ResolveManyRequest")
+	proto.RegisterType((*ResolveManyResponse)(nil), "resolver.ResolveManyResponse")
+	proto.RegisterType((*ResolvePageRequest)(nil), "resolver.ResolvePageRequest")
+	proto.RegisterMapType((map[string]string)(nil), "resolver.ResolvePageRequest.OverridesEntry")
+	proto.RegisterType((*ResolvePageResponse)(nil), "resolver.ResolvePageResponse")
+	proto.RegisterType((*WatchPageRequest)(nil), "resolver.WatchPageRequest")
+	proto.RegisterType((*PageUpdate)(nil), "resolver.PageUpdate")
+	proto.RegisterType((*FormatFormulaRequest)(nil), "resolver.FormatFormulaRequest")
+	proto.RegisterType((*FormatFormulaResponse)(nil), "resolver.FormatFormulaResponse")
+	proto.RegisterType((*TranslateFormulaRequest)(nil), "resolver.TranslateFormulaRequest")
+	proto.RegisterType((*TranslateFormulaResponse)(nil), "resolver.TranslateFormulaResponse")
+	proto.RegisterType((*TranslationDiagnostic)(nil), "resolver.TranslationDiagnostic")
+	proto.RegisterType((*GoalSeekRequest)(nil), "resolver.GoalSeekRequest")
+	proto.RegisterType((*GoalSeekResponse)(nil), "resolver.GoalSeekResponse")
+	proto.RegisterType((*DataTableRequest)(nil), "resolver.DataTableRequest")
+	proto.RegisterType((*DataTableResponse)(nil), "resolver.DataTableResponse")
+	proto.RegisterType((*DataTableRow)(nil), "resolver.DataTableRow")
+	proto.RegisterType((*VariableResult)(nil), "resolver.VariableResult")
+	proto.RegisterType((*Object)(nil), "resolver.Object")
+	proto.RegisterType((*List)(nil), "resolver.List")
+	proto.RegisterType((*Tuple)(nil), "resolver.Tuple")
+	proto.RegisterType((*Lambda)(nil), "resolver.Lambda")
+	proto.RegisterMapType((map[string]string)(nil), "resolver.Lambda.DefaultsEntry")
+	proto.RegisterType((*Function)(nil), "resolver.Function")
+	proto.RegisterType((*Money)(nil), "resolver.Money")
+	proto.RegisterType((*Table)(nil), "resolver.Table")
+	proto.RegisterType((*TableRow)(nil), "resolver.TableRow")
+	proto.RegisterType((*Record)(nil), "resolver.Record")
+	proto.RegisterType((*RecordProperty)(nil), "resolver.RecordProperty")
+}
+
+func init() { proto.RegisterFile("resolver.proto", fileDescriptor_f5838971722c666f) }
+
+var fileDescriptor_f5838971722c666f = []byte{
+	// 1477 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xeb, 0x6e, 0xdb, 0xc6,
+	0x12, 0x0e, 0xad, 0x1b, 0x35, 0xb2, 0x15, 0x9e, 0xcd, 0x8d, 0x87, 0x89, 0x73, 0xe1, 0xc1, 0xc9,
+	0x31, 0x92, 0x53, 0x37, 0xb0, 0x53, 0x20, 0x4d, 0x0a, 0x34, 0x76, 0x6c, 0x07, 0x02, 0x7c, 0x49,
+	0x37, 0x76, 0x8a, 0xa2, 0x3f, 0x54, 0x4a, 0x5a, 0xcb, 0x6c, 0x48, 0xae, 0xba, 0x5c, 0xda, 0xd1,
+	0x83, 0x14, 0xed, 0xbf, 0xbe, 0x45, 0xff, 0xf4, 0x05, 0xfa, 0x24, 0x05, 0xfa, 0x16, 0xc5, 0x5e,
+	0x78, 0xb5, 0x54, 0xbb, 0x05, 0xfa, 0x8f, 0x33, 0xf3, 0xcd, 0xec, 0xec, 0xdc, 0x76, 0x24, 0xe8,
+	0x32, 0x12, 0xd3, 0xe0, 0x94, 0xb0, 0xd5, 0x09, 0xa3, 0x9c, 0x22, 0x33, 0xa5, 0xdd, 0x5f, 0x0d,
+	0xe8, 0x62, 0x45, 0x60, 0xf2, 0x5d, 0x42, 0x62, 0x8e, 0x6e, 0x41, 0x6b, 0xe2, 0x8d, 0x49, 0xdf,
+	0x1f, 0xd9, 0xc6, 0x7d, 0x63, 0xa5, 0x8d, 0x9b, 0x82, 0xec, 0x8d, 0x90, 0x0d, 0xad, 0x63, 0xca,
+	0xc2, 0x24, 0xf0, 0xec, 0x05, 0x29, 0x48, 0x49, 0xb4, 0x0d, 0x6d, 0x7a, 0x4a, 0x18, 0xf3, 0x47,
+	0x24, 0xb6, 0x6b, 0xf7, 0x6b, 0x2b, 0x9d, 0xb5, 0xff, 0xad, 0x66, 0x67, 0x96, 0xed, 0xaf, 0x1e,
+	0xa4, 0xc8, 0xed, 0x88, 0xb3, 0x29, 0xce, 0x35, 0x9d, 0xcf, 0xa0, 0x5b, 0x16, 0x22, 0x0b, 0x6a,
+	0xef, 0xc9, 0x54, 0xfb, 0x21, 0x3e, 0xd1, 0x75, 0x68, 0x9c, 0x7a, 0x41, 0x42, 0xb4, 0x0b, 0x8a,
+	0x78, 0xbe, 0xf0, 0xcc, 0x70, 0xbf, 0x80, 0xab, 0xd9, 0x49, 0xf1, 0x84, 0x46, 0x31, 0x41, 0x2b,
+	0xd0, 0x64, 0x24, 0x4e, 0x02, 0x2e, 0x2d, 0x74, 0xd6, 0xac, 0xdc, 0xa9, 0x83, 0xc1, 0xb7, 0x64,
+	0xc8, 0xb1, 0x96, 0x0b, 0xb3, 0x84, 0x31, 0xca, 0x52, 0xb3, 0x92, 0x70, 0x7b, 0x80, 0xb4, 0xc9,
+	0x3d, 0x2f, 0x9a, 0x5e, 0x18, 0x20, 0x07, 0x4c, 0x1d, 0x91, 0xd8, 0x5e, 0xb8, 0x5f, 0x5b, 0x69,
+	0xe3, 0x8c, 0x76, 0xbf, 0x81, 0x6b, 0x25, 0x53, 0xda, 0xc3, 0x75, 0x68, 0x29, 0x0f, 0x62, 0xdb,
+	0x90, 0x71, 0xfb, 0xf7, 0x8c, 0xb8, 0x29, 0x2c, 0x4e, 0x91, 0x73, 0x9c, 0xfd, 0xd9, 0xc8, 0xbc,
+	0x7d, 0xe3, 0x8d, 0x2f, 0x4e, 0x67, 0xaf, 0x98, 0xb4, 0x05, 0x79, 0xf8, 0xe3, 0x73, 0x87, 0x17,
+	0x2c, 0xfd, 0x63, 0x89, 0xeb, 0xc3, 0xb5, 0xd2, 0x69, 0x3a, 0x34, 0x6b, 0xd5, 0xd0, 0xd8, 0xb9,
+	0x77, 0xef, 0x3c, 0xe6, 0x7b, 0x83, 0x40, 0x80, 0x93, 0x80, 0x5f, 0x14, 0x99, 0xc7, 0x60, 0x7d,
+	0xe9, 0xf1, 0xe1, 0xc9, 0x65, 0xc2, 0xe2, 0xbe, 0x04, 0x10, 0xb8, 0xa3, 0xc9, 0xc8, 0xe3, 0x7f,
+	0xcb, 0x09, 0xf7, 0x09, 0x5c, 0xdf, 0xa1, 0x2c, 0xf4, 0xf8, 0x8e, 0x4a, 0x7e, 0x7a, 0x64, 0xa1,
+	0x7f, 0x8c, 0x52, 0xff, 0xb8, 0xaf, 0xe1, 0x46, 0x45, 0x43, 0xc7, 0x60, 0xae, 0xca, 0x9c, 0x9b,
+	0xae, 0xc3, 0xad, 0x43, 0xe6, 0x45, 0x71, 0xe0, 0x71, 0x72, 0xe9, 0xd3, 0xcf, 0xc0, 0x3e, 0xaf,
+	0x74, 0xa1, 0x03, 0x1b, 0xd0, 0x19, 0xf9, 0xde, 0x38, 0xa2, 0x31, 0xf7, 0x87, 0x69, 0x01, 0xdd,
+	0xcb, 0xa3, 0x93, 0x9a, 0xf4, 0x69, 0xb4, 0x95, 0xe1, 0x70, 0x51, 0xc7, 0xf5, 0xe0, 0xc6, 0x4c,
+	0x14, 0xba, 0x09, 0xcd, 0x21, 0x0d, 0x92, 0x30, 0x92, 0x87, 0x36, 0xb0, 0xa6, 0x04, 0x3f, 0x20,
+	0xd1, 0x98, 0x9f, 0xc8, 0x5b, 0x37, 0xb0, 0xa6, 0x84, 0x97, 0x21, 0x89, 0x63, 0x6f, 0x4c, 0xec,
+	0x9a, 0xf2, 0x52, 0x93, 0xee, 0x2f, 0x06, 0x5c, 0x7d, 0x4d, 0xbd, 0xe0, 0x2d, 0x21, 0xef, 0x2f,
+	0xec, 0x88, 0x9b, 0xd0, 0xe4, 0x1e, 0x1b, 0x13, 0xae, 0x83, 0xaa, 0xa9, 0xbc, 0x74, 0x85, 0x71,
+	0x43, 0x97, 0xae, 0xe0, 0xfa, 0xd1, 0x24, 0xe1, 0x76, 0x5d, 0x65, 0x40, 0x12, 0xa2, 0xf0, 0x03,
+	0x7a, 0x66, 0x37, 0x24, 0x52, 0x7c, 0x22, 0x04, 0xf5, 0x13, 0x7f, 0x7c, 0x62, 0x37, 0x25, 0x4b,
+	0x7e, 0xa3, 0xff, 0x42, 0x37, 0xf4, 0x3e, 0xf4, 0x7d, 0x4e, 0x98, 0xbc, 0x7b, 0x6c, 0xb7, 0xe4,
+	0x85, 0x96, 0x42, 0xef, 0x43, 0x2f, 0x63, 0xba, 0x3f, 0x1a, 0x60, 0xe5, 0xde, 0xeb, 0x94, 0x38,
+	0x60, 0xc6, 0x34, 0x48, 0x04, 0x42, 0xfa, 0x6f, 0xe0, 0x8c, 0x2e, 0x37, 0x59, 0xe6, 0xe9, 0x5d,
+	0x80, 0xc2, 0x49, 0x35, 0x79, 0x52, 0x81, 0x83, 0xee, 0x40, 0x7b, 0x48, 0xa3, 0x53, 0xc2, 0xc6,
+	0x64, 0x24, 0x6f, 0x63, 0xe2, 0x9c, 0x91, 0x57, 0x5a, 0xa3, 0x58, 0x69, 0xbf, 0x19, 0x60, 0x6d,
+	0x79, 0xdc, 0x3b, 0x54, 0x1d, 0x70, 0x71, 0x64, 0x69, 0xc2, 0x45, 0xb0, 0x74, 0x64, 0x15, 0x85,
+	0x6e, 0x43, 0x9b, 0xd1, 0xb3, 0xbe, 0x8a, 0xa3, 0x4a, 0x9d, 0xc9, 0xe8, 0x59, 0x4f, 0x86, 0x72,
+	0x19, 0x40, 0x08, 0xe5, 0x1d, 0x62, 0xbb, 0x2e, 0x07, 0xaa, 0x80, 0xbf, 0x93, 0x0c, 0xf4, 0x00,
+	0x16, 0x55, 0x59, 0x68, 0x75, 0xe5, 0x5e, 0x47, 0xf1, 0x94, 0x85, 0xff, 0xc0, 0x92, 0x86, 0x68,
+	0x23, 0x4d, 0x69, 0x44, 0xeb, 0x69, 0x3b, 0xcb, 0x00, 0xdc, 0x0f, 0x09, 0x4d, 0x78, 0x3f, 0x4c,
+	0xf3, 0xd0, 0xd6, 0x9c, 0xbd, 0xd8, 0x3d, 0x82, 0x7f, 0x15, 0xee, 0xa9, 0x73, 0xf0, 0x08, 0xea,
+	0x8c, 0x9e, 0xa5, 0x33, 0xe1, 0x66, 0x5e, 0xf5, 0x39, 0x94, 0x9e, 0x61, 0x89, 0x99, 0xd3, 0xa9,
+	0x9f, 0xc3, 0x62, 0x11, 0x8b, 0x3e, 0x86, 0xc6, 0x90, 0x04, 0xc1, 0x25, 0x9e, 0x01, 0x85, 0x73,
+	0x7f, 0x32, 0xa0, 0x5b, 0x9e, 0x40, 0xe8, 0x1e, 0x74, 0x4e, 0x35, 0x27, 0x4f, 0x01, 0xa4, 0xac,
+	0xde, 0x48, 0x94, 0x62, 0xe4, 0x85, 0xe9, 0x08, 0x96, 0xdf, 0xc5, 0x0e, 0xaf, 0x95, 0x3b, 0x3c,
+	0x7f, 0x3d, 0xeb, 0x97, 0x7d, 0x3d, 0x4b, 0x25, 0xf2, 0x7d, 0x1d, 0x9a, 0x0a, 0x88, 0x56, 0xa0,
+	0xce, 0xa7, 0x13, 0x22, 0x5d, 0xea, 0xae, 0x5d, 0xaf, 0x1a, 0x3a, 0x9c, 0x4e, 0x08, 0x96, 0x08,
+	0x91, 0x8d, 0x01, 0xa5, 0x41, 0x3f, 0x2f, 0x63, 0x13, 0xb7, 0x05, 0x47, 0x66, 0x4b, 0x24, 0x3d,
+	0xe6, 0xcc, 0x8f, 0xc6, 0xfd, 0xbc, 0x23, 0xdb, 0xb8, 0xa3, 0x78, 0x19, 0x24, 0x4a, 0xc2, 0x01,
+	0x61, 0x1a, 0x52, 0x97, 0xad, 0xd0, 0x51, 0x3c, 0x05, 0xf9, 0x08, 0x20, 0xf0, 0x63, 0xae, 0x01,
+	0x0d, 0x79, 0xbb, 0x6e, 0xee, 0xd4, 0xae, 0x1f, 0x73, 0xdc, 0x16, 0x08, 0x05, 0x5f, 0x87, 0x45,
+	0x46, 0x86, 0x94, 0x8d, 0xb4, 0x42, 0xb3, 0x1a, 0x0e, 0x2c, 0xa5, 0xb8, 0xa3, 0x50, 0x4a, 0xe9,
+	0x09, 0x74, 0x78, 0x32, 0x09, 0x88, 0xd6, 0x69, 0x49, 0x9d, 0xab, 0x85, 0xf9, 0x28, 0x84, 0x18,
+	0x24, 0x26, 0x3b, 0x26, 0xf0, 0xc2, 0xc1, 0xc8, 0xd3, 0x2a, 0x66, 0xf5, 0x98, 0x5d, 0x29, 0xc5,
+	0x1d, 0x85, 0x52, 0x4a, 0x9f, 0x42, 0xf7, 0x38, 0x89, 0x86, 0xa2, 0x91, 0xb5, 0x5a, 0x5b, 0xaa,
+	0xa1, 0x5c, 0x6d, 0x47, 0xcb, 0xf1, 0x52, 0x8a, 0xcc, 0x3d, 0x94, 0xb5, 0xa2, 0xf4, 0xe0, 0x9c,
+	0x87, 0x42, 0x88, 0x41, 0x62, 0x94, 0x06, 0x82, 0x7a, 0x12, 0xf9, 0xdc, 0xee, 0xa8, 0xfa, 0x11,
+	0xdf, 0xc2, 0x4a, 0x48, 0x23, 0x32, 0xd5, 0x56, 0x16, 0xab, 0x56, 0xf6, 0x84, 0x10, 0x83, 0xc4,
+	0x48, 0x2b, 0xee, 0x53, 0xa8, 0x8b, 0x08, 0xa3, 0xff, 0x83, 0x49, 0x02, 0x12, 0x92, 0x28, 0x7b,
+	0x5c, 0xcf, 0x57, 0x58, 0x86, 0x70, 0x3f, 0x81, 0x86, 0x0c, 0x59, 0x49, 0x6d, 0xe1, 0x42, 0xb5,
+	0x1f, 0x16, 0xa0, 0xa9, 0xe2, 0x26, 0x86, 0xee, 0x31, 0x23, 0xa4, 0x9f, 0x36, 0x84, 0x3a, 0xb5,
+	0x8d, 0x97, 0x04, 0x37, 0x6d, 0xa5, 0x58, 0x5c, 0x72, 0x40, 0x47, 0xd3, 0xb4, 0x49, 0xc4, 0x37,
+	0x7a, 0x0a, 0xe6, 0xc0, 0x8f, 0x46, 0x7e, 0x34, 0x4e, 0xf7, 0x5b, 0xbb, 0x9a, 0xfd, 0x37, 0x8c,
+	0x4e, 0x08, 0xe3, 0x53, 0x9c, 0x21, 0xc5, 0xf8, 0x61, 0x44, 0x96, 0x99, 0xb2, 0xad, 0x5f, 0x8a,
+	0x45, 0xc1, 0x4c, 0xcf, 0x43, 0xcf, 0xc1, 0x1c, 0x91, 0x63, 0x4f, 0xae, 0x18, 0x0d, 0x69, 0xfa,
+	0x6e, 0x35, 0xe3, 0xab, 0x5b, 0x1a, 0xa0, 0x16, 0xaf, 0x0c, 0xef, 0xbc, 0x80, 0xa5, 0x92, 0xe8,
+	0x2f, 0xad, 0x5d, 0x77, 0xc1, 0x4c, 0x2b, 0x23, 0x1b, 0x0c, 0x46, 0x3e, 0x18, 0xdc, 0x17, 0xd0,
+	0x90, 0xb9, 0x13, 0xc3, 0xdb, 0x0b, 0x69, 0x12, 0xf1, 0x74, 0xa8, 0x2b, 0x4a, 0x3c, 0x44, 0xc3,
+	0x84, 0x31, 0x12, 0x0d, 0xd3, 0x60, 0x65, 0xb4, 0xdb, 0x83, 0x86, 0x2c, 0x1f, 0x31, 0x5e, 0xd4,
+	0xb4, 0x4d, 0xa3, 0x9d, 0x92, 0xe8, 0xa1, 0x9e, 0xa1, 0x2a, 0x87, 0xa8, 0x5a, 0x77, 0xe9, 0xfc,
+	0x74, 0xd7, 0xc0, 0x4c, 0x39, 0xe8, 0x61, 0x79, 0x4a, 0x9e, 0x4f, 0xbc, 0x1e, 0x8e, 0x9b, 0xd0,
+	0x54, 0x59, 0x41, 0xcf, 0x00, 0x26, 0x2a, 0x33, 0x3e, 0x99, 0xb1, 0xc3, 0x55, 0x72, 0x57, 0xc0,
+	0xba, 0xbb, 0xd0, 0x2d, 0x4b, 0x67, 0x45, 0x49, 0x78, 0x94, 0xc7, 0x77, 0xa6, 0x47, 0x52, 0xfc,
+	0x88, 0x01, 0xe4, 0xb3, 0x0e, 0x75, 0xa0, 0xb5, 0x79, 0x70, 0xb0, 0xbb, 0xbd, 0xb1, 0x6f, 0x5d,
+	0x41, 0x00, 0xcd, 0xdd, 0x8d, 0xbd, 0xcd, 0xad, 0x0d, 0xcb, 0x40, 0x26, 0xd4, 0x77, 0x7b, 0x6f,
+	0x0f, 0xad, 0x05, 0xc1, 0xdd, 0x3f, 0xda, 0xdb, 0xdc, 0xc6, 0x56, 0x4d, 0x7c, 0xbf, 0x3d, 0xc4,
+	0xbd, 0xfd, 0xd7, 0x56, 0x5d, 0x7c, 0xe3, 0xed, 0x57, 0x07, 0x78, 0xcb, 0x6a, 0xa0, 0x45, 0x30,
+	0x77, 0x8e, 0xf6, 0x5f, 0x1d, 0xf6, 0x0e, 0xf6, 0xad, 0x26, 0x6a, 0x43, 0x63, 0xef, 0x60, 0x7f,
+	0xfb, 0x2b, 0xcb, 0x74, 0xeb, 0x66, 0xcb, 0x6a, 0xad, 0xfd, 0x5e, 0x07, 0x53, 0xbf, 0x1e, 0x0c,
+	0xbd, 0x84, 0x96, 0xfe, 0x46, 0xf6, 0xbc, 0xdf, 0x66, 0xce, 0xfc, 0x67, 0xc7, 0xbd, 0x82, 0x76,
+	0xa1, 0x53, 0xf8, 0x09, 0x83, 0xee, 0x9c, 0xc3, 0x16, 0x7e, 0x24, 0x39, 0xcb, 0x73, 0xa4, 0x33,
+	0xac, 0x89, 0x75, 0x7b, 0x86, 0xb5, 0xc2, 0xb6, 0xee, 0x2c, 0xcf, 0x91, 0x66, 0xd6, 0x36, 0xa0,
+	0x9d, 0xad, 0xf8, 0xc8, 0xc9, 0xd1, 0xd5, 0xbd, 0xdf, 0x29, 0xbc, 0x3d, 0xf9, 0x9a, 0xef, 0x5e,
+	0x79, 0x62, 0x20, 0x0c, 0x4b, 0xa5, 0x25, 0x1c, 0x15, 0xfa, 0x70, 0xd6, 0x3e, 0xef, 0xdc, 0x9b,
+	0x2b, 0xcf, 0xdc, 0xfa, 0x1a, 0xac, 0xea, 0x6a, 0x8d, 0x1e, 0x9c, 0xdf, 0x91, 0x2b, 0xbb, 0xba,
+	0xe3, 0xfe, 0x19, 0x24, 0x33, 0xfe, 0x0a, 0xcc, 0x74, 0x39, 0x44, 0x85, 0xc4, 0x55, 0xd6, 0x5d,
+	0xc7, 0x99, 0x25, 0xca, 0x8c, 0xec, 0x40, 0x3b, 0xdb, 0x43, 0x8a, 0x81, 0xab, 0xee, 0x76, 0xce,
+	0xed, 0x99, 0xb2, 0xd4, 0xce, 0xa0, 0x29, 0xff, 0x59, 0x58, 0xff, 0x63, 0x00, 0xff, 0x1b, 0xef,
+	0x4f, 0x6b, 0x10, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ResolverClient is the client API for Resolver service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ResolverClient interface {
+	Resolve(ctx context.Context, in *ResolveRequest, opts ...grpc.CallOption) (*ResolveResponse, error)
+	ResolveMany(ctx context.Context, in *ResolveManyRequest, opts ...grpc.CallOption) (*ResolveManyResponse, error)
+	ResolvePage(ctx context.Context, in *ResolvePageRequest, opts ...grpc.CallOption) (*ResolvePageResponse, error)
+	WatchPage(ctx context.Context, in *WatchPageRequest, opts ...grpc.CallOption) (Resolver_WatchPageClient, error)
+	FormatFormula(ctx context.Context, in *FormatFormulaRequest, opts ...grpc.CallOption) (*FormatFormulaResponse, error)
+	TranslateFormula(ctx context.Context, in *TranslateFormulaRequest, opts ...grpc.CallOption) (*TranslateFormulaResponse, error)
+	GoalSeek(ctx context.Context, in *GoalSeekRequest, opts ...grpc.CallOption) (*GoalSeekResponse, error)
+	DataTable(ctx context.Context, in *DataTableRequest, opts ...grpc.CallOption) (*DataTableResponse, error)
+}
+
+type resolverClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewResolverClient(cc *grpc.ClientConn) ResolverClient {
 	return &resolverClient{cc}
 }
 
@@ -534,9 +1799,102 @@ func (c *resolverClient) Resolve(ctx context.Context, in *ResolveRequest, opts .
 	return out, nil
 }
 
+func (c *resolverClient) ResolveMany(ctx context.Context, in *ResolveManyRequest, opts ...grpc.CallOption) (*ResolveManyResponse, error) {
+	out := new(ResolveManyResponse)
+	err := c.cc.Invoke(ctx, "/resolver.Resolver/ResolveMany", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resolverClient) ResolvePage(ctx context.Context, in *ResolvePageRequest, opts ...grpc.CallOption) (*ResolvePageResponse, error) {
+	out := new(ResolvePageResponse)
+	err := c.cc.Invoke(ctx, "/resolver.Resolver/ResolvePage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resolverClient) WatchPage(ctx context.Context, in *WatchPageRequest, opts ...grpc.CallOption) (Resolver_WatchPageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Resolver_serviceDesc.Streams[0], "/resolver.Resolver/WatchPage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resolverWatchPageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Resolver_WatchPageClient interface {
+	Recv() (*PageUpdate, error)
+	grpc.ClientStream
+}
+
+type resolverWatchPageClient struct {
+	grpc.ClientStream
+}
+
+func (x *resolverWatchPageClient) Recv() (*PageUpdate, error) {
+	m := new(PageUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *resolverClient) FormatFormula(ctx context.Context, in *FormatFormulaRequest, opts ...grpc.CallOption) (*FormatFormulaResponse, error) {
+	out := new(FormatFormulaResponse)
+	err := c.cc.Invoke(ctx, "/resolver.Resolver/FormatFormula", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resolverClient) TranslateFormula(ctx context.Context, in *TranslateFormulaRequest, opts ...grpc.CallOption) (*TranslateFormulaResponse, error) {
+	out := new(TranslateFormulaResponse)
+	err := c.cc.Invoke(ctx, "/resolver.Resolver/TranslateFormula", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resolverClient) GoalSeek(ctx context.Context, in *GoalSeekRequest, opts ...grpc.CallOption) (*GoalSeekResponse, error) {
+	out := new(GoalSeekResponse)
+	err := c.cc.Invoke(ctx, "/resolver.Resolver/GoalSeek", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resolverClient) DataTable(ctx context.Context, in *DataTableRequest, opts ...grpc.CallOption) (*DataTableResponse, error) {
+	out := new(DataTableResponse)
+	err := c.cc.Invoke(ctx, "/resolver.Resolver/DataTable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ResolverServer is the server API for Resolver service.
 type ResolverServer interface {
 	Resolve(context.Context, *ResolveRequest) (*ResolveResponse, error)
+	ResolveMany(context.Context, *ResolveManyRequest) (*ResolveManyResponse, error)
+	ResolvePage(context.Context, *ResolvePageRequest) (*ResolvePageResponse, error)
+	WatchPage(*WatchPageRequest, Resolver_WatchPageServer) error
+	FormatFormula(context.Context, *FormatFormulaRequest) (*FormatFormulaResponse, error)
+	TranslateFormula(context.Context, *TranslateFormulaRequest) (*TranslateFormulaResponse, error)
+	GoalSeek(context.Context, *GoalSeekRequest) (*GoalSeekResponse, error)
+	DataTable(context.Context, *DataTableRequest) (*DataTableResponse, error)
 }
 
 func RegisterResolverServer(s *grpc.Server, srv ResolverServer) {
@@ -561,6 +1919,135 @@ func _Resolver_Resolve_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Resolver_ResolveMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResolverServer).ResolveMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/resolver.Resolver/ResolveMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResolverServer).ResolveMany(ctx, req.(*ResolveManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resolver_ResolvePage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolvePageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResolverServer).ResolvePage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/resolver.Resolver/ResolvePage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResolverServer).ResolvePage(ctx, req.(*ResolvePageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resolver_WatchPage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResolverServer).WatchPage(m, &resolverWatchPageServer{stream})
+}
+
+type Resolver_WatchPageServer interface {
+	Send(*PageUpdate) error
+	grpc.ServerStream
+}
+
+type resolverWatchPageServer struct {
+	grpc.ServerStream
+}
+
+func (x *resolverWatchPageServer) Send(m *PageUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Resolver_FormatFormula_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FormatFormulaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResolverServer).FormatFormula(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/resolver.Resolver/FormatFormula",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResolverServer).FormatFormula(ctx, req.(*FormatFormulaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resolver_TranslateFormula_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranslateFormulaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResolverServer).TranslateFormula(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/resolver.Resolver/TranslateFormula",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResolverServer).TranslateFormula(ctx, req.(*TranslateFormulaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resolver_GoalSeek_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GoalSeekRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResolverServer).GoalSeek(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/resolver.Resolver/GoalSeek",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResolverServer).GoalSeek(ctx, req.(*GoalSeekRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resolver_DataTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResolverServer).DataTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/resolver.Resolver/DataTable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResolverServer).DataTable(ctx, req.(*DataTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Resolver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "resolver.Resolver",
 	HandlerType: (*ResolverServer)(nil),
@@ -569,7 +2056,37 @@ var _Resolver_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Resolve",
 			Handler:    _Resolver_Resolve_Handler,
 		},
+		{
+			MethodName: "ResolveMany",
+			Handler:    _Resolver_ResolveMany_Handler,
+		},
+		{
+			MethodName: "ResolvePage",
+			Handler:    _Resolver_ResolvePage_Handler,
+		},
+		{
+			MethodName: "FormatFormula",
+			Handler:    _Resolver_FormatFormula_Handler,
+		},
+		{
+			MethodName: "TranslateFormula",
+			Handler:    _Resolver_TranslateFormula_Handler,
+		},
+		{
+			MethodName: "GoalSeek",
+			Handler:    _Resolver_GoalSeek_Handler,
+		},
+		{
+			MethodName: "DataTable",
+			Handler:    _Resolver_DataTable_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPage",
+			Handler:       _Resolver_WatchPage_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "resolver.proto",
 }
@@ -8,16 +8,25 @@ import (
 	"log"
 	"net"
 	"os"
+	"sort"
+	"strconv"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/tobyjsullivan/chalk/resolver/engine/types"
 
 	"github.com/tobyjsullivan/chalk/monolith"
 
+	"github.com/tobyjsullivan/chalk/parsing"
 	"github.com/tobyjsullivan/chalk/resolver"
 	"github.com/tobyjsullivan/chalk/resolver/engine"
+	"github.com/tobyjsullivan/chalk/resolver/engine/sheets"
 	"google.golang.org/grpc"
 )
 
+// Limits how long a data table may take when the request asks for longer, or sets no limit.
+const maxDataTableTimeout = 30 * time.Second
+
 // server is used to implement ResolverServer.
 type server struct {
 	engine *engine.Engine
@@ -26,7 +35,7 @@ type server struct {
 func (s *server) Resolve(ctx context.Context, in *resolver.ResolveRequest) (*resolver.ResolveResponse, error) {
 	log.Println("Received:", in.Formula)
 	var res *resolver.ResolveResponse
-	obj, err := s.engine.Query(ctx, in.PageId, in.Formula)
+	obj, err := s.engine.QueryWithOverrides(ctx, in.PageId, in.Formula, in.Overrides)
 	if err != nil {
 		res = toErrorResult(err)
 	} else {
@@ -36,6 +45,186 @@ func (s *server) Resolve(ctx context.Context, in *resolver.ResolveRequest) (*res
 	return res, nil
 }
 
+func (s *server) ResolveMany(ctx context.Context, in *resolver.ResolveManyRequest) (*resolver.ResolveManyResponse, error) {
+	log.Println("Received", len(in.Formulas), "formulas")
+	results, err := s.engine.QueryMany(ctx, in.PageId, in.Formulas)
+	if err != nil {
+		return &resolver.ResolveManyResponse{
+			Error: fmt.Sprint(err),
+		}, nil
+	}
+
+	out := make([]*resolver.ResolveResponse, len(results))
+	for i, res := range results {
+		if res.Err != nil {
+			out[i] = toErrorResult(res.Err)
+		} else {
+			out[i] = toResult(res.Value)
+		}
+	}
+
+	return &resolver.ResolveManyResponse{
+		Results: out,
+	}, nil
+}
+
+func (s *server) ResolvePage(ctx context.Context, in *resolver.ResolvePageRequest) (*resolver.ResolvePageResponse, error) {
+	log.Println("Received page:", in.PageId)
+	results, err := s.engine.QueryPageWithOverrides(ctx, in.PageId, in.Overrides)
+	if err != nil {
+		return &resolver.ResolvePageResponse{
+			Error: fmt.Sprint(err),
+		}, nil
+	}
+
+	out := make([]*resolver.VariableResult, len(results))
+	for i, res := range results {
+		out[i] = toVariableResult(res)
+	}
+
+	return &resolver.ResolvePageResponse{
+		Results: out,
+	}, nil
+}
+
+func (s *server) WatchPage(in *resolver.WatchPageRequest, stream resolver.Resolver_WatchPageServer) error {
+	log.Println("Watching page:", in.PageId)
+	// The last result sent for each variable so unchanged results can be skipped.
+	sent := make(map[string]*resolver.VariableResult)
+	err := s.engine.WatchPage(stream.Context(), in.PageId, func(results []*engine.VariableResult) error {
+		var changed []*resolver.VariableResult
+		for _, res := range results {
+			out := toVariableResult(res)
+			if prev, ok := sent[out.VariableId]; ok && proto.Equal(prev, out) {
+				continue
+			}
+			sent[out.VariableId] = out
+			changed = append(changed, out)
+		}
+
+		if len(changed) == 0 {
+			return nil
+		}
+		return stream.Send(&resolver.PageUpdate{
+			Results: changed,
+		})
+	})
+	log.Println("Stopped watching page:", in.PageId)
+
+	return err
+}
+
+func (s *server) FormatFormula(ctx context.Context, in *resolver.FormatFormulaRequest) (*resolver.FormatFormulaResponse, error) {
+	ast, err := parsing.Parse(in.Formula)
+	if err != nil {
+		return &resolver.FormatFormulaResponse{
+			Error: fmt.Sprint(err),
+		}, nil
+	}
+
+	return &resolver.FormatFormulaResponse{
+		Formula: parsing.Format(ast),
+	}, nil
+}
+
+func (s *server) TranslateFormula(ctx context.Context, in *resolver.TranslateFormulaRequest) (*resolver.TranslateFormulaResponse, error) {
+	formula, diagnostics := sheets.Translate(in.Formula)
+
+	out := &resolver.TranslateFormulaResponse{
+		Formula: formula,
+	}
+	for _, d := range diagnostics {
+		out.Diagnostics = append(out.Diagnostics, &resolver.TranslationDiagnostic{
+			Column:  int32(d.Column),
+			Length:  int32(d.Length),
+			Message: d.Message,
+		})
+	}
+
+	return out, nil
+}
+
+func (s *server) GoalSeek(ctx context.Context, in *resolver.GoalSeekRequest) (*resolver.GoalSeekResponse, error) {
+	log.Println("Seeking", in.Target, "=", in.Value, "by varying", in.Input)
+	res, err := s.engine.GoalSeek(ctx, in.PageId, &engine.Goal{
+		Target:        in.Target,
+		Value:         in.Value,
+		Input:         in.Input,
+		Low:           in.Low,
+		High:          in.High,
+		MaxIterations: int(in.MaxIterations),
+	})
+	if err != nil {
+		return &resolver.GoalSeekResponse{
+			Error: fmt.Sprint(err),
+		}, nil
+	}
+
+	return &resolver.GoalSeekResponse{
+		Solution:   res.Solution,
+		Value:      res.Value,
+		Iterations: int32(res.Iterations),
+		Converged:  res.Converged,
+	}, nil
+}
+
+func (s *server) DataTable(ctx context.Context, in *resolver.DataTableRequest) (*resolver.DataTableResponse, error) {
+	log.Println("Building data table for", in.Output)
+	timeout := time.Duration(in.TimeoutMs) * time.Millisecond
+	if timeout <= 0 || timeout > maxDataTableTimeout {
+		timeout = maxDataTableTimeout
+	}
+	rows, err := s.engine.DataTable(ctx, in.PageId, &engine.DataTable{
+		Output:       in.Output,
+		RowInput:     in.RowInput,
+		RowValues:    in.RowValues,
+		ColumnInput:  in.ColumnInput,
+		ColumnValues: in.ColumnValues,
+		Timeout:      timeout,
+	})
+	if err != nil {
+		return &resolver.DataTableResponse{
+			Error: fmt.Sprint(err),
+		}, nil
+	}
+
+	out := make([]*resolver.DataTableRow, len(rows))
+	for i, row := range rows {
+		cells := make([]*resolver.ResolveResponse, len(row))
+		for j, res := range row {
+			if res.Err != nil {
+				cells[j] = toErrorResult(res.Err)
+			} else {
+				cells[j] = toResult(res.Value)
+			}
+		}
+		out[i] = &resolver.DataTableRow{
+			Cells: cells,
+		}
+	}
+
+	return &resolver.DataTableResponse{
+		Rows: out,
+	}, nil
+}
+
+func toVariableResult(res *engine.VariableResult) *resolver.VariableResult {
+	var resp *resolver.ResolveResponse
+	if res.Err != nil {
+		resp = toErrorResult(res.Err)
+	} else {
+		resp = toResult(res.Value)
+	}
+
+	return &resolver.VariableResult{
+		VariableId: res.Variable.VariableId,
+		Name:       res.Variable.Name,
+		Formula:    res.Variable.Formula,
+		Result:     resp.Result,
+		Error:      resp.Error,
+	}
+}
+
 func toResult(res *types.Object) *resolver.ResolveResponse {
 	obj, err := toResultObject(res)
 	if err != nil {
@@ -52,17 +241,32 @@ func toResultObject(obj *types.Object) (*resolver.Object, error) {
 		return nil, nil
 	}
 	switch obj.Type() {
+	case types.TypeBlank:
+		// Blank cells within ranges are sent as empty strings, which display as empty cells.
+		return &resolver.Object{
+			Type: resolver.ObjectType_STRING,
+		}, nil
 	case types.TypeBoolean:
 		b, _ := obj.ToBoolean()
 		return &resolver.Object{
 			Type:      resolver.ObjectType_BOOLEAN,
 			BoolValue: b,
 		}, nil
+	case types.TypeMoney:
+		m, _ := obj.ToMoney()
+		return &resolver.Object{
+			Type: resolver.ObjectType_MONEY,
+			MoneyValue: &resolver.Money{
+				Amount:   m.Amount.String(),
+				Currency: m.Currency,
+			},
+		}, nil
 	case types.TypeNumber:
 		n, _ := obj.ToNumber()
 		return &resolver.Object{
 			Type:        resolver.ObjectType_NUMBER,
 			NumberValue: n,
+			Unit:        obj.Unit().String(),
 		}, nil
 	case types.TypeString:
 		s, _ := obj.ToString()
@@ -72,7 +276,6 @@ func toResultObject(obj *types.Object) (*resolver.Object, error) {
 		}, nil
 	case types.TypeList:
 		list, _ := obj.ToList()
-
 		els := make([]*resolver.Object, len(list.Elements))
 		var err error
 		for i, el := range list.Elements {
@@ -82,26 +285,32 @@ func toResultObject(obj *types.Object) (*resolver.Object, error) {
 			}
 		}
 
-		return &resolver.Object{
+		out := &resolver.Object{
 			Type: resolver.ObjectType_LIST,
 			ListValue: &resolver.List{
 				Elements: els,
 			},
-		}, nil
+		}
+		// Clients which can show tables may use the table instead of the list.
+		if columns, ok := tableColumns(list); ok {
+			out.TableValue = toResultTable(els, columns)
+		}
+
+		return out, nil
 	case types.TypeRecord:
 		record, _ := obj.ToRecord()
 
-		props := make([]*resolver.RecordProperty, 0, len(record.Properties))
-		for k, v := range record.Properties {
-			value, err := toResultObject(v)
+		props := make([]*resolver.RecordProperty, len(record.Properties))
+		for i, prop := range record.Properties {
+			value, err := toResultObject(prop.Value)
 			if err != nil {
 				return nil, err
 			}
 
-			props = append(props, &resolver.RecordProperty{
-				Name:  k,
+			props[i] = &resolver.RecordProperty{
+				Name:  prop.Name,
 				Value: value,
-			})
+			}
 		}
 
 		return &resolver.Object{
@@ -113,10 +322,62 @@ func toResultObject(obj *types.Object) (*resolver.Object, error) {
 	case types.TypeLambda:
 		lambda, _ := obj.ToLambda()
 
+		body, err := engine.FormatObject(lambda.Expression)
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, 0, len(lambda.Bindings))
+		for name := range lambda.Bindings {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		bindings := make([]*resolver.RecordProperty, len(names))
+		for i, name := range names {
+			value, err := toResultObject(lambda.Bindings[name])
+			if err != nil {
+				return nil, err
+			}
+
+			bindings[i] = &resolver.RecordProperty{
+				Name:  name,
+				Value: value,
+			}
+		}
+
+		var defaults map[string]string
+		for _, name := range lambda.FreeVariables {
+			def, ok := lambda.Defaults[parsing.NormaliseName(name)]
+			if !ok {
+				continue
+			}
+			if defaults == nil {
+				defaults = make(map[string]string)
+			}
+			defaults[name], err = engine.FormatObject(def)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		return &resolver.Object{
 			Type: resolver.ObjectType_LAMBDA,
 			LambdaValue: &resolver.Lambda{
 				FreeVariables: lambda.FreeVariables,
+				RestVariable:  lambda.RestVariable,
+				Body:          body,
+				Bindings:      bindings,
+				Defaults:      defaults,
+			},
+		}, nil
+	case types.TypeFunction:
+		name, _ := obj.FunctionName()
+
+		return &resolver.Object{
+			Type: resolver.ObjectType_FUNCTION,
+			FunctionValue: &resolver.Function{
+				Name: name,
 			},
 		}, nil
 	default:
@@ -124,6 +385,61 @@ func toResultObject(obj *types.Object) (*resolver.Object, error) {
 	}
 }
 
+// tableColumns returns the columns of a list which can be shown as a table. That's any non-empty list of records
+// which all have the same properties.
+func tableColumns(list *types.List) ([]string, bool) {
+	if len(list.Elements) == 0 {
+		return nil, false
+	}
+
+	var columns []string
+	for i, el := range list.Elements {
+		row, err := el.ToRecord()
+		if err != nil {
+			return nil, false
+		}
+		if i == 0 {
+			columns = row.Keys()
+			continue
+		}
+
+		if len(row.Properties) != len(columns) {
+			return nil, false
+		}
+		for _, c := range columns {
+			if _, ok := row.Get(c); !ok {
+				return nil, false
+			}
+		}
+	}
+
+	return columns, len(columns) > 0
+}
+
+// toResultTable arranges the records of a list, already converted, as rows of cells under the columns.
+func toResultTable(records []*resolver.Object, columns []string) *resolver.Table {
+	rows := make([]*resolver.TableRow, len(records))
+	for i, rec := range records {
+		values := make(map[string]*resolver.Object, len(rec.RecordValue.Properties))
+		for _, prop := range rec.RecordValue.Properties {
+			values[prop.Name] = prop.Value
+		}
+
+		cells := make([]*resolver.Object, len(columns))
+		for j, c := range columns {
+			cells[j] = values[c]
+		}
+		rows[i] = &resolver.TableRow{
+			Cells: cells,
+		}
+	}
+
+	return &resolver.Table{
+		Columns: columns,
+		Rows:    rows,
+	}
+}
+
 func toErrorResult(err error) *resolver.ResolveResponse {
 	return &resolver.ResolveResponse{
 		Error: fmt.Sprint(err),
@@ -138,6 +454,15 @@ func main() {
 
 	varsSvc := os.Getenv("VARIABLES_SVC")
 
+	var opts []engine.Option
+	if p := os.Getenv("PARALLELISM"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			log.Fatalf("invalid PARALLELISM: %v", err)
+		}
+		opts = append(opts, engine.WithParallelism(n))
+	}
+
 	varsConn, err := grpc.Dial(varsSvc, grpc.WithInsecure())
 	if err != nil {
 		log.Fatalf("failed to dial variables service: %v", err)
@@ -150,7 +475,7 @@ func main() {
 	}
 	s := grpc.NewServer()
 	resolver.RegisterResolverServer(s, &server{
-		engine: engine.NewEngine(monolith.NewVariablesClient(varsConn)),
+		engine: engine.NewEngine(monolith.NewVariablesClient(varsConn), opts...),
 	})
 
 	log.Println("Starting server on", lis.Addr())
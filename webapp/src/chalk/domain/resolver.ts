@@ -1,4 +1,4 @@
-export type Result = Error | NoneType | Boolean | Lambda | List | Number | Record | String;
+export type Result = Error | NoneType | Boolean | Function | Lambda | List | Money | Number | Record | String | Table;
 
 export const None: NoneType = {
   resultType: 'none',
@@ -13,9 +13,18 @@ export interface Boolean {
   value: boolean,
 }
 
+export interface Function {
+  resultType: 'function',
+  name: string,
+}
+
 export interface Lambda {
   resultType: 'lambda',
   freeVariables: [string],
+  restVariable?: string,
+  defaults?: {[name: string]: string},
+  body: string,
+  bindings: ReadonlyArray<RecordProperty>,
 }
 
 export interface List {
@@ -23,9 +32,17 @@ export interface List {
   elements: ReadonlyArray<Result>,
 }
 
+// Money keeps its amount as a decimal string so it isn't rounded before it's displayed.
+export interface Money {
+  resultType: 'money',
+  amount: string,
+  currency: string,
+}
+
 export interface Number {
   resultType: 'number',
   value: number,
+  unit?: string,
 }
 
 export interface Record {
@@ -43,6 +60,12 @@ export interface String {
   value: string,
 }
 
+export interface Table {
+  resultType: 'table',
+  columns: ReadonlyArray<string>,
+  rows: ReadonlyArray<ReadonlyArray<Result>>,
+}
+
 interface Error {
   resultType: 'error',
   message: string,
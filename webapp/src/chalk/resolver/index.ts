@@ -37,6 +37,7 @@ export function parseApiResultObject(obj: ApiResultObject): Result {
     return {
       resultType: 'number',
       value: obj.numberValue,
+      unit: obj.unit,
     };
   case 'string':
     if (obj.stringValue === undefined) {
@@ -51,18 +52,56 @@ export function parseApiResultObject(obj: ApiResultObject): Result {
       throw 'missing lambdaValue';
     }
 
+    const bindings = [];
+    for (const [key, value] of Object.entries(obj.lambdaValue.bindings || {})) {
+      bindings.push({
+        name: key,
+        value: parseApiResultObject(value),
+      });
+    }
+
     return {
       resultType: 'lambda',
       freeVariables: obj.lambdaValue.freeVariables,
+      restVariable: obj.lambdaValue.restVariable,
+      defaults: obj.lambdaValue.defaults,
+      body: obj.lambdaValue.body,
+      bindings,
+    }
+  case 'function':
+    if (obj.functionValue === undefined) {
+      throw 'missing functionValue';
+    }
+
+    return {
+      resultType: 'function',
+      name: obj.functionValue.name,
     }
   case 'list':
     if (obj.listValue === undefined) {
       throw 'missing listValue';
     }
+    // Lists of records which share their properties also come arranged as a table.
+    if (obj.tableValue !== undefined) {
+      return {
+        resultType: 'table',
+        columns: obj.tableValue.columns,
+        rows: obj.tableValue.rows.map((row) => row.map(parseApiResultObject)),
+      }
+    }
     return {
       resultType: 'list',
       elements: obj.listValue.elements.map(parseApiResultObject),
     }
+  case 'money':
+    if (obj.moneyValue === undefined) {
+      throw 'missing moneyValue';
+    }
+    return {
+      resultType: 'money',
+      amount: obj.moneyValue.amount,
+      currency: obj.moneyValue.currency,
+    };
   case 'record':
     if (obj.recordValue === undefined) {
       throw 'missing recordValue';
@@ -95,10 +134,22 @@ interface ApiResultObject {
     class: string,
   },
   booleanValue?: boolean,
+  moneyValue?: {
+    amount: string,
+    currency: string,
+  },
   numberValue?: number,
   stringValue?: string,
+  unit?: string,
+  functionValue?: {
+    name: string,
+  },
   lambdaValue?: {
     freeVariables: [string],
+    restVariable?: string,
+    defaults?: {[name: string]: string},
+    body: string,
+    bindings?: ReadonlyMap<string, ApiResultObject>,
   },
   listValue?: {
     elements: ReadonlyArray<ApiResultObject>,
@@ -106,4 +157,8 @@ interface ApiResultObject {
   recordValue?: {
     properties: ReadonlyMap<string, ApiResultObject>,
   },
+  tableValue?: {
+    columns: ReadonlyArray<string>,
+    rows: ReadonlyArray<ReadonlyArray<ApiResultObject>>,
+  },
 }
@@ -44,6 +44,42 @@ const SingleCell = ({content}: {content: string}) => {
   return (<ResultDisplayTable rows={[[content]]}/>)
 };
 
+const isWholePart = (part?: Intl.NumberFormatPart) => (
+  part !== undefined && (part.type === 'integer' || part.type === 'group')
+);
+
+// formatMoney formats an amount for the user's locale, such as "$1,234.50" or "1.234,50 €". Amounts are decimal
+// strings which a float can't always represent, so only the whole part is formatted as a number, for the currency
+// symbol and grouping, and the fraction's digits are kept exactly as they are.
+const formatMoney = (amount: string, currency: string) => {
+  const negative = amount.startsWith('-');
+  const [whole, fraction = ''] = (negative ? amount.slice(1) : amount).split('.');
+  const options: Intl.NumberFormatOptions = {style: 'currency', currency};
+
+  const currencyFormat = new Intl.NumberFormat(undefined, options);
+  const digits = fraction.padEnd(currencyFormat.resolvedOptions().minimumFractionDigits, '0');
+  const separator = currencyFormat.formatToParts(1.5).find((part) => part.type === 'decimal');
+  const decimal = separator ? separator.value : '.';
+
+  const wholeFormat = new Intl.NumberFormat(undefined, {...options, minimumFractionDigits: 0, maximumFractionDigits: 0});
+  const n = Number(whole);
+  // Beyond the safe integers, the digits are used without grouping rather than rounded.
+  const exact = Number.isSafeInteger(n);
+  const parts = wholeFormat.formatToParts(negative ? -n : n);
+
+  return parts.map((part, i) => {
+    if (!isWholePart(part)) {
+      return part.value;
+    }
+
+    let value = exact ? part.value : (isWholePart(parts[i - 1]) ? '' : whole);
+    if (digits && !isWholePart(parts[i + 1])) {
+      value += decimal + digits;
+    }
+    return value;
+  }).join('');
+};
+
 interface ResultDisplayPropsType {
   result: Result,
 }
@@ -58,8 +94,32 @@ const ResultDisplay = ({result}: ResultDisplayPropsType) => {
     case 'boolean':
       content = (<SingleCell content={result.value ? 'TRUE' : 'FALSE'} />);
       break;
+    case 'function':
+      content = (<SingleCell content={`ƒ ${result.name}`} />);
+      break;
     case 'lambda':
-      content = (<SingleCell content={`λ (${result.freeVariables.join(', ')})`} />);
+      const bindings = result.bindings.map(({name, value}) => (
+        <tr className="ResultDisplay-recordRow" key={name}>
+          <th className="ResultDisplay-recordProperty">{name}</th>
+          <td className="ResultDisplay-recordValue"><ResultDisplay result={value} /></td>
+        </tr>
+      ));
+
+      const defaults = result.defaults || {};
+      const named = result.freeVariables.map((v) => (v in defaults ? `${v} = ${defaults[v]}` : v));
+      const params = result.restVariable ? [...named, `...${result.restVariable}`] : named;
+      content = (
+        <div>
+          <SingleCell content={`λ (${params.join(', ')}) => ${result.body}`} />
+          {bindings.length > 0 && (
+            <table className="ResultDisplay-record">
+              <tbody>
+                {bindings}
+              </tbody>
+            </table>
+          )}
+        </div>
+      );
       break;
     case 'list':
       const items = result.elements.map((res, i) => (
@@ -73,8 +133,12 @@ const ResultDisplay = ({result}: ResultDisplayPropsType) => {
         </ul>
       );
       break;
+    case 'money':
+      content = (<SingleCell content={formatMoney(result.amount, result.currency)} />);
+      break;
     case 'number':
-      content = (<SingleCell content={`${result.value}`} />);
+      const unit = result.unit ? ` ${result.unit}` : '';
+      content = (<SingleCell content={`${result.value}${unit}`} />);
       break;
     case 'record':
       const propRows = result.properties.map(({name, value}) => (
@@ -95,6 +159,29 @@ const ResultDisplay = ({result}: ResultDisplayPropsType) => {
     case 'string':
       content = (<SingleCell content={result.value} />);
       break;
+    case 'table':
+      const headings = result.columns.map((c) => (
+        <th className="ResultDisplay-tableHeading" key={c}>{c}</th>
+      ));
+      const tableRows = result.rows.map((row, i) => (
+        <tr className="ResultDisplay-row" key={i}>
+          {row.map((value, j) => (
+            <td className="ResultDisplay-cell" key={j}><ResultDisplay result={value} /></td>
+          ))}
+        </tr>
+      ));
+
+      content = (
+        <table className="ResultDisplay-table">
+          <thead>
+            <tr>{headings}</tr>
+          </thead>
+          <tbody>
+            {tableRows}
+          </tbody>
+        </table>
+      );
+      break;
     case 'error':
       content = (
         <p className="ResultDisplay-error">{result.message}</p>